@@ -5,8 +5,10 @@ import (
 	"os"
 	"quizzly/cmd"
 	"quizzly/internal/quizzly"
+	quizzlyJobs "quizzly/internal/quizzly/jobs"
 	"quizzly/pkg/cookie"
 	"quizzly/pkg/files"
+	"quizzly/pkg/jobs"
 	"quizzly/pkg/supabase"
 
 	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
@@ -56,8 +58,18 @@ func main() {
 		web.ServerTypeHttp,
 	)
 
+	jobsRunner := jobs.NewDefaultRunner(log)
+	err = jobsRunner.RegisterAll(
+		quizzlyJobs.NewGameScheduleJob(quizzlyConfig.Game.MustGet(), log),
+		quizzlyJobs.NewSessionSweeperJob(quizzlyConfig.Session.MustGet()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	runner := cmd.NewRunner(log)
 	runner.Start(
 		server,
+		jobsRunner,
 	)
 }
@@ -0,0 +1,12 @@
+create table if not exists game_live_state (
+    game_id UUID primary key not null,
+    current_question_id UUID default null,
+    question_opened_at TIMESTAMPTZ default null,
+    question_closed_at TIMESTAMPTZ default null,
+
+    created_at TIMESTAMPTZ not null default NOW(),
+    updated_at TIMESTAMPTZ not null default NOW(),
+
+    foreign key (game_id) references game (id),
+    foreign key (current_question_id) references question (id)
+)
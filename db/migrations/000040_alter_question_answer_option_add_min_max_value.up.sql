@@ -0,0 +1,3 @@
+alter table question_answer_option
+    add column if not exists min_value numeric default null,
+    add column if not exists max_value numeric default null;
\ No newline at end of file
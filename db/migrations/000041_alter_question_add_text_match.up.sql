@@ -0,0 +1,4 @@
+alter table question
+    add column if not exists text_match_mode text default null,
+    add column if not exists text_match_distance integer default null,
+    add column if not exists text_match_ratio numeric default null;
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table player_session_item
+    add column if not exists review_comment text default null,
+    add column if not exists reviewed_at TIMESTAMPTZ default null;
\ No newline at end of file
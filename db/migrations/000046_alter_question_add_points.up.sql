@@ -0,0 +1,2 @@
+alter table question
+    add column if not exists points integer not null default 1;
\ No newline at end of file
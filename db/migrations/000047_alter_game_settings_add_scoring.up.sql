@@ -0,0 +1,4 @@
+alter table game_settings
+    add column if not exists speed_bonus boolean not null default false,
+    add column if not exists negative_marking boolean not null default false,
+    add column if not exists partial_credit boolean not null default false;
\ No newline at end of file
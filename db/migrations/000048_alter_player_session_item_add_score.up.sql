@@ -0,0 +1,2 @@
+alter table player_session_item
+    add column if not exists score numeric default null;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table game_settings
+    add column if not exists hide_leaderboard boolean not null default false;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table question
+    add column if not exists next_question_id uuid references question(id) default null;
\ No newline at end of file
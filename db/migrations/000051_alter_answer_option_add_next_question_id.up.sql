@@ -0,0 +1,2 @@
+alter table question_answer_option
+    add column if not exists next_question_id uuid references question(id) default null;
\ No newline at end of file
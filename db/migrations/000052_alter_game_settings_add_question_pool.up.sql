@@ -0,0 +1,3 @@
+alter table game_settings
+    add column if not exists question_pool_size integer default null,
+    add column if not exists stratify_pool boolean not null default false;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table question
+    add column if not exists tag text default null;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table player_session
+    add column if not exists question_ids uuid[] default null;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table player_session
+    add column if not exists shuffle_seed bigint not null default (random() * 2147483647)::bigint;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table player_session
+    add column if not exists attempt integer not null default 1;
\ No newline at end of file
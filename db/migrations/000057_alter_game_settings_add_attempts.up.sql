@@ -0,0 +1,3 @@
+alter table game_settings
+    add column if not exists max_attempts integer default null,
+    add column if not exists attempt_policy text not null default 'last';
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table game
+    add column if not exists opens_at timestamptz default null,
+    add column if not exists closes_at timestamptz default null;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table game_settings
+    add column if not exists inactivity_timeout integer default null;
\ No newline at end of file
@@ -9,6 +9,7 @@ import (
 	"quizzly/internal/quizzly/usecase/player"
 	"quizzly/internal/quizzly/usecase/session"
 	"quizzly/internal/quizzly/usecase/session/acceptor"
+	"quizzly/pkg/broker"
 	"quizzly/pkg/structs"
 
 	"github.com/jmoiron/sqlx"
@@ -19,6 +20,7 @@ type (
 		Game    structs.Singleton[contracts.GameUsecase]
 		Session structs.Singleton[contracts.SessionUsecase]
 		Player  structs.Singleton[contracts.PLayerUsecase]
+		Events  structs.Singleton[broker.Broker[model.GameEvent]]
 	}
 )
 
@@ -27,11 +29,16 @@ func NewConfiguration(
 	trm trm.Manager,
 ) *Configuration {
 	repos := repositories.NewConfiguration(db)
+	events := structs.NewSingleton(func() (broker.Broker[model.GameEvent], error) {
+		return broker.NewDefaultBroker[model.GameEvent](), nil
+	})
+
 	return &Configuration{
 		Game: structs.NewSingleton(func() (contracts.GameUsecase, error) {
 			return game.NewUsecase(
 				repos.Game.MustGet(),
 				repos.Session.MustGet(),
+				events.MustGet(),
 				trm,
 			), nil
 		}),
@@ -40,12 +47,21 @@ func NewConfiguration(
 				repos.Session.MustGet(),
 				repos.Game.MustGet(),
 				repos.Player.MustGet(),
+				events.MustGet(),
 				trm,
 				map[model.QuestionType]session.AnswerOptionIDAcceptor{
 					model.QuestionTypeChoice:         acceptor.NewSingleChoiceAcceptor(),
 					model.QuestionTypeOneOfChoice:    acceptor.NewOneOfChoiceAcceptor(),
 					model.QuestionTypeMultipleChoice: acceptor.NewMultipleChoiceAcceptor(),
 					model.QuestionTypeFillTheGap:     acceptor.NewFillTheGapAcceptor(),
+					model.QuestionTypeOrdering:       acceptor.NewOrderingAcceptor(),
+					model.QuestionTypeMatching:       acceptor.NewMatchingAcceptor(),
+					model.QuestionTypeNumeric:        acceptor.NewNumericAcceptor(),
+					model.QuestionTypeCloze:          acceptor.NewClozeAcceptor(),
+					model.QuestionTypePoll:           acceptor.NewPollAcceptor(),
+					model.QuestionTypeMultiplePoll:   acceptor.NewPollAcceptor(),
+					model.QuestionTypeOpenText:       acceptor.NewOpenTextAcceptor(),
+					model.QuestionTypeHotspot:        acceptor.NewHotspotAcceptor(),
 				},
 			), nil
 		}),
@@ -54,5 +70,6 @@ func NewConfiguration(
 				repos.Player.MustGet(),
 			), nil
 		}),
+		Events: events,
 	}
 }
@@ -1,6 +1,9 @@
 package contracts
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrQuestionQueueIsEmpty     = errors.New("question queue is empty")
@@ -8,6 +11,32 @@ var (
 	ErrSessionNotFound          = errors.New("player's session not found")
 	ErrSessionNotFinished       = errors.New("player's session not finished")
 	ErrGameNotFound             = errors.New("game not found")
+	ErrGameNotStarted           = errors.New("game isn't started")
+	ErrGameAlreadyStarted       = errors.New("game is already started")
+	ErrQuestionNotFound         = errors.New("question not found")
 	ErrEmptyQuestions           = errors.New("empty questions")
 	ErrEmptyAnswerOptions       = errors.New("empty answer options")
+	ErrNoCorrectAnswerOptions   = errors.New("no correct answer options")
+	ErrInvalidAnswerOptions     = errors.New("invalid answer options")
+	ErrGameIsNotLive            = errors.New("game isn't live")
+	ErrLiveQuestionClosed       = errors.New("live question is closed")
+	ErrSessionItemNotFound      = errors.New("session item not found")
+	ErrQuestionFlowCycle        = errors.New("question flow has a cycle")
+	ErrQuestionFlowUnreachable  = errors.New("question flow has unreachable questions")
+	ErrQuestionFlowNotSupported = errors.New("question flow isn't supported by game settings")
+	ErrAttemptsExhausted        = errors.New("player has no attempts left")
 )
+
+// QuestionError ошибка в одном из вопросов, добавляемых списком. Number - номер вопроса в списке, начиная с 1
+type QuestionError struct {
+	Number int
+	Err    error
+}
+
+func (e *QuestionError) Error() string {
+	return fmt.Sprintf("question %d: %s", e.Number, e.Err)
+}
+
+func (e *QuestionError) Unwrap() error {
+	return e.Err
+}
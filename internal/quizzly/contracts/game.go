@@ -3,6 +3,7 @@ package contracts
 import (
 	"context"
 	"quizzly/internal/quizzly/model"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,8 +16,19 @@ type (
 		Settings model.GameSettings
 	}
 
+	// SetNextQuestionIn если AnswerOptionID не задан, переход задается для всего вопроса.
+	// NextQuestionID nil сбрасывает переход
+	SetNextQuestionIn struct {
+		GameID         uuid.UUID
+		QuestionID     uuid.UUID
+		AnswerOptionID *model.AnswerOptionID
+		NextQuestionID *uuid.UUID
+	}
+
 	GameUsecase interface {
 		Create(ctx context.Context, in *CreateGameIn) (uuid.UUID, error)
+		// ImportGame создает игру сразу с вопросами, переходы между вопросами сохраняются
+		ImportGame(ctx context.Context, in *CreateGameIn, questions []model.Question) (uuid.UUID, error)
 		Update(ctx context.Context, in *model.Game) error
 		Start(ctx context.Context, id uuid.UUID) error
 		Finish(ctx context.Context, id uuid.UUID) error
@@ -24,10 +36,22 @@ type (
 		Get(ctx context.Context, id uuid.UUID) (*model.Game, error)
 		GetByAuthor(ctx context.Context, authorID uuid.UUID) ([]model.Game, error)
 		GetPublic(ctx context.Context) ([]model.Game, error)
+		// GetScheduled игры, которые по расписанию пора запустить или завершить к моменту at
+		GetScheduled(ctx context.Context, at time.Time) ([]model.Game, error)
 
 		CreateQuestion(ctx context.Context, in *model.Question) error
+		// ImportQuestions добавляет вопросы в созданную игру: либо все, либо ни одного.
+		// Если title не nil, название игры меняется в той же транзакции
+		ImportQuestions(ctx context.Context, gameID uuid.UUID, title *string, questions []model.Question) error
 		UpdateQuestion(ctx context.Context, in *model.Question) error
 		DeleteQuestion(ctx context.Context, id uuid.UUID) error
+		// SetNextQuestion переходы меняются только до начала игры и только между вопросами одной игры
+		SetNextQuestion(ctx context.Context, in *SetNextQuestionIn) error
 		GetQuestions(ctx context.Context, gameID uuid.UUID) ([]model.Question, error)
+
+		GetLiveState(ctx context.Context, gameID uuid.UUID) (*model.GameLiveState, error)
+		OpenLiveQuestion(ctx context.Context, gameID uuid.UUID, questionID uuid.UUID) error
+		CloseLiveQuestion(ctx context.Context, gameID uuid.UUID) error
+		NextLiveQuestion(ctx context.Context, gameID uuid.UUID) error
 	}
 )
@@ -4,8 +4,12 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"quizzly/internal/quizzly/model"
+	"time"
 )
 
+// AnswerGracePeriod запас времени на доставку ответа, отправленного до окончания таймера
+const AnswerGracePeriod = 3 * time.Second
+
 type (
 	AcceptAnswersIn struct {
 		GameID     uuid.UUID
@@ -17,8 +21,14 @@ type (
 	AcceptAnswersOut struct {
 		IsCorrect bool
 		Details   []AnswerResult
+		// IsPendingReview ответ проверит автор игры, IsCorrect не заполняется
+		IsPendingReview bool
+		// IsTimedOut ответ пришел после окончания времени на вопрос и не засчитан
+		IsTimedOut bool
 
 		RightAnswers []model.AnswerOption
+		// Votes распределение ответов всех игроков, заполняется только для опросов
+		Votes []model.AnswerVotes
 	}
 
 	AnswerResult struct {
@@ -30,6 +40,12 @@ type (
 		Status          model.SessionStatus
 		CurrentQuestion *model.Question
 		Progress        Progress
+		// WaitingForHost в live-игре ведущий еще не открыл вопрос или игрок уже ответил на текущий
+		WaitingForHost bool
+		// Deadline время, до которого нужно ответить на текущий вопрос, nil если время не ограничено
+		Deadline *time.Time
+		// Pairs правая колонка в вопросах на сопоставление: различные правые части в случайном порядке
+		Pairs []string
 	}
 
 	Progress struct {
@@ -37,20 +53,46 @@ type (
 		Total    int64
 	}
 
+	ReviewAnswerIn struct {
+		GameID    uuid.UUID
+		ItemID    int64
+		IsCorrect bool
+		Comment   *string
+	}
+
+	GetExtendedSessionsIn struct {
+		GameID uuid.UUID
+		Page   int64
+		Limit  int64
+		// AllAttempts по умолчанию от каждого игрока возвращается только последняя попытка
+		AllAttempts bool
+	}
+
 	GetExtendedSessionsOut struct {
 		Result     []model.ExtendedSession
 		TotalCount int64
+		// FinishedCount брошенные прохождения не считаются завершенными
+		FinishedCount  int64
+		AbandonedCount int64
 	}
 
 	SessionUsecase interface {
 		Start(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error
 		Finish(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error
 		Restart(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error
+		// AbandonStale помечает брошенными прохождения, в которых игрок давно не отвечал
+		AbandonStale(ctx context.Context, at time.Time) error
 
 		AcceptAnswers(ctx context.Context, in *AcceptAnswersIn) (*AcceptAnswersOut, error)
 		GetCurrentState(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*SessionState, error)
 
 		GetStatistics(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*model.SessionStatistics, error)
-		GetExtendedSessions(ctx context.Context, gameID uuid.UUID, page int64, limit int64) (*GetExtendedSessionsOut, error)
+		GetExtendedSessions(ctx context.Context, in *GetExtendedSessionsIn) (*GetExtendedSessionsOut, error)
+		GetExtendedSession(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*model.ExtendedSession, error)
+
+		GetLeaderboard(ctx context.Context, gameID uuid.UUID, order model.LeaderboardOrder) ([]model.LeaderboardEntry, error)
+
+		GetPendingReviews(ctx context.Context, gameID uuid.UUID) ([]model.ReviewItem, error)
+		ReviewAnswer(ctx context.Context, in *ReviewAnswerIn) error
 	}
 )
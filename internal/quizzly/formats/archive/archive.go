@@ -0,0 +1,189 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	manifestName = "manifest.json"
+	imagesDir    = "images"
+
+	// MaxSize размер самого архива
+	MaxSize = 100 << 20
+	// maxFileSize и maxUnpackedSize ограничивают распакованные файлы по отдельности и в сумме,
+	// сжатые данные могут быть во много раз меньше распакованных
+	maxFileSize     = 20 << 20
+	maxUnpackedSize = 200 << 20
+)
+
+var (
+	// ErrInvalidArchive файл не является архивом игры или поврежден
+	ErrInvalidArchive = errors.New("invalid game archive")
+	// ErrInvalidQuestionID у вопроса нет идентификатора или он повторяется, тогда переходы между вопросами не восстановить
+	ErrInvalidQuestionID = errors.New("invalid question id")
+)
+
+// unpacker файлы архива читаются с общим ограничением на распакованный размер
+type unpacker struct {
+	reader *zip.Reader
+	left   int64
+}
+
+// InvalidQuestionError вопрос архива не прошел те же проверки, что и вопрос из формы, текст ошибки написан для автора игры
+type InvalidQuestionError struct {
+	Err error
+}
+
+// Game переносимая копия игры: настройки, вопросы в порядке прохождения и изображения вопросов
+type Game struct {
+	Title     *string
+	Type      model.GameType
+	Settings  model.GameSettings
+	Questions []model.Question
+	// Images содержимое изображений по ImageID вопросов
+	Images map[string][]byte
+}
+
+// Write записывает игру в zip-архив: манифест manifest.json и изображения в папке images
+func Write(out io.Writer, in *Game) error {
+	content := manifest{
+		Version:   Version,
+		Title:     in.Title,
+		Type:      in.Type,
+		Settings:  convertSettings(in.Settings),
+		Questions: make([]manifestQuestion, 0, len(in.Questions)),
+	}
+	for i := range in.Questions {
+		content.Questions = append(content.Questions, convertQuestion(&in.Questions[i]))
+	}
+
+	writer := zip.NewWriter(out)
+	manifestWriter, err := writer.Create(manifestName)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(manifestWriter)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(content); err != nil {
+		return err
+	}
+
+	for name, data := range in.Images {
+		imageWriter, err := writer.Create(path.Join(imagesDir, name))
+		if err != nil {
+			return err
+		}
+		if _, err = imageWriter.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// Read разбирает архив, созданный Write. Вопросы получают новые идентификаторы, переходы между ними сохраняются.
+// Ошибка в конкретном вопросе возвращается как contracts.QuestionError, слишком большой архив - как formats.ErrTooLarge
+func Read(in io.Reader) (*Game, error) {
+	data, err := formats.ReadAll(in, MaxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	files := &unpacker{reader: reader, left: maxUnpackedSize}
+
+	content := manifest{}
+	manifestData, err := files.read(manifestName)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(manifestData, &content); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	if err = migrate(&content); err != nil {
+		return nil, err
+	}
+	if content.Type != model.GameTypeAsync && content.Type != model.GameTypeLive {
+		return nil, fmt.Errorf("%w: unsupported game type %q", ErrInvalidArchive, content.Type)
+	}
+
+	ids := make(map[uuid.UUID]uuid.UUID, len(content.Questions))
+	for i, question := range content.Questions {
+		if _, ok := ids[question.ID]; ok || question.ID == uuid.Nil {
+			return nil, &contracts.QuestionError{Number: i + 1, Err: ErrInvalidQuestionID}
+		}
+		// манифест мог быть изменен вручную, поэтому вопросы проверяются так же, как при создании из формы
+		if _, err = formats.ConvertQuestion(convertQuestionIn(&question)); err != nil {
+			return nil, &contracts.QuestionError{Number: i + 1, Err: &InvalidQuestionError{Err: err}}
+		}
+		ids[question.ID] = uuid.New()
+	}
+
+	result := &Game{
+		Title:     content.Title,
+		Type:      content.Type,
+		Settings:  convertManifestSettings(content.Settings),
+		Questions: make([]model.Question, 0, len(content.Questions)),
+		Images:    make(map[string][]byte),
+	}
+	for i := range content.Questions {
+		question := convertManifestQuestion(&content.Questions[i], ids)
+		if question.ImageID != nil {
+			if _, ok := result.Images[*question.ImageID]; !ok {
+				image, err := files.read(path.Join(imagesDir, *question.ImageID))
+				if err != nil {
+					return nil, err
+				}
+				result.Images[*question.ImageID] = image
+			}
+		}
+
+		result.Questions = append(result.Questions, question)
+	}
+
+	return result, nil
+}
+
+// migrate приводит манифест старой версии к текущей
+func migrate(in *manifest) error {
+	if in.Version < 1 || in.Version > Version {
+		return fmt.Errorf("%w: unsupported manifest version %d", ErrInvalidArchive, in.Version)
+	}
+
+	return nil
+}
+
+func (u *unpacker) read(name string) ([]byte, error) {
+	file, err := u.reader.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is absent", ErrInvalidArchive, name)
+	}
+	defer file.Close()
+
+	data, err := formats.ReadAll(file, min(maxFileSize, u.left))
+	u.left -= int64(len(data))
+	return data, err
+}
+
+func (e *InvalidQuestionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidQuestionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,294 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRoundTrip(t *testing.T) {
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	in := &Game{
+		Title: structs.Pointer(`Игра "с кавычками" & <тегами>`),
+		Type:  model.GameTypeAsync,
+		Settings: model.GameSettings{
+			ShuffleAnswers:    true,
+			QuestionTimeLimit: structs.Pointer(30 * time.Second),
+			MaxAttempts:       structs.Pointer(2),
+		},
+		Questions: []model.Question{
+			{
+				ID:      first,
+				Type:    model.QuestionTypeChoice,
+				Text:    "Куда дальше?",
+				ImageID: structs.Pointer("map.png"),
+				Points:  2,
+				AnswerOptions: []model.AnswerOption{
+					{Answer: "Налево", IsCorrect: true, NextQuestionID: &second},
+					{Answer: "Направо", NextQuestionID: &third},
+				},
+			},
+			{
+				ID:             second,
+				Type:           model.QuestionTypeFillTheGap,
+				Text:           "Столица Италии?",
+				TimeLimit:      structs.Pointer(15 * time.Second),
+				Points:         1,
+				Tag:            structs.Pointer("Города"),
+				TextMatch:      model.TextMatchSettings{Mode: model.TextMatchModeFuzzy, Ratio: structs.Pointer(0.8)},
+				NextQuestionID: &third,
+				AnswerOptions:  []model.AnswerOption{{Answer: "Рим", IsCorrect: true}},
+			},
+			{
+				ID:      third,
+				Type:    model.QuestionTypeMatching,
+				Text:    "Сопоставьте",
+				ImageID: structs.Pointer("map.png"),
+				Points:  1,
+				AnswerOptions: []model.AnswerOption{
+					{Answer: "Франция", IsCorrect: true, Pair: structs.Pointer("Париж")},
+					{Answer: "Италия", IsCorrect: true, Pair: structs.Pointer("Рим")},
+				},
+			},
+		},
+		Images: map[string][]byte{"map.png": []byte("png")},
+	}
+
+	out := bytes.Buffer{}
+	if err := Write(&out, in); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+	result, err := Read(&out)
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+
+	// вопросы получают новые идентификаторы, переходы должны вести на новые идентификаторы тех же вопросов
+	ids := make(map[uuid.UUID]uuid.UUID, len(in.Questions))
+	for i := range in.Questions {
+		if result.Questions[i].ID == in.Questions[i].ID || result.Questions[i].ID == uuid.Nil {
+			t.Fatalf("question %d got no new id", i+1)
+		}
+		ids[in.Questions[i].ID] = result.Questions[i].ID
+	}
+	expected := *in
+	expected.Questions = make([]model.Question, 0, len(in.Questions))
+	for _, question := range in.Questions {
+		question.ID = ids[question.ID]
+		question.NextQuestionID = remapID(question.NextQuestionID, ids)
+		question.AnswerOptions = append([]model.AnswerOption(nil), question.AnswerOptions...)
+		for i := range question.AnswerOptions {
+			question.AnswerOptions[i].NextQuestionID = remapID(question.AnswerOptions[i].NextQuestionID, ids)
+		}
+		expected.Questions = append(expected.Questions, question)
+	}
+
+	if !reflect.DeepEqual(result, &expected) {
+		t.Errorf("expected %+v, got %+v", &expected, result)
+	}
+}
+
+// TestConvertQuestionIn вопрос любого типа, созданный из формы, проходит проверку при чтении архива без изменений
+func TestConvertQuestionIn(t *testing.T) {
+	tests := []struct {
+		name string
+		in   formats.QuestionIn
+	}{
+		{name: "choice", in: formats.QuestionIn{Type: model.QuestionTypeChoice, Options: []string{"Да", "Нет"}, Correct: []bool{true, false}}},
+		{name: "multiple choice", in: formats.QuestionIn{Type: model.QuestionTypeMultipleChoice, Options: []string{"a", "b", "c"}, Correct: []bool{true, true, false}}},
+		{name: "fill the gap", in: formats.QuestionIn{Type: model.QuestionTypeFillTheGap, Options: []string{"Рим", "Roma"}, TextMatch: formats.TextMatchIn{Ratio: "85"}}},
+		{name: "regex", in: formats.QuestionIn{Type: model.QuestionTypeFillTheGap, Options: []string{`^\d+$`}, TextMatch: formats.TextMatchIn{Mode: string(model.TextMatchModeRegex)}}},
+		{name: "ordering", in: formats.QuestionIn{Type: model.QuestionTypeOrdering, Options: []string{"Первый", "Второй", "Третий"}}},
+		{name: "matching", in: formats.QuestionIn{Type: model.QuestionTypeMatching, Options: []string{"Франция", "Италия"}, Pairs: []string{"Париж", "Рим"}}},
+		{name: "numeric", in: formats.QuestionIn{Type: model.QuestionTypeNumeric, Numeric: formats.NumericIn{Value: "3,14", Tolerance: "5", ToleranceType: formats.NumericTolerancePercent}}},
+		{name: "cloze", in: formats.QuestionIn{Type: model.QuestionTypeCloze, Text: "Столица Франции - {Париж|Paris}", TextMatch: formats.TextMatchIn{Distance: "1"}}},
+		{name: "poll", in: formats.QuestionIn{Type: model.QuestionTypeMultiplePoll, Options: []string{"Чай", "Кофе"}}},
+		{name: "open text", in: formats.QuestionIn{Type: model.QuestionTypeOpenText, Options: []string{"Образец"}}},
+		{name: "hotspot", in: formats.QuestionIn{Type: model.QuestionTypeHotspot, ImageID: structs.Pointer("map.png"), Regions: []string{"0.1 0.2,0.5 0.8"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.in.Text == "" {
+				tt.in.Text = "Вопрос"
+			}
+			tt.in.Points = "2"
+			tt.in.TimeLimit = "20"
+			tt.in.Tag = "Тег"
+
+			question, err := formats.ConvertQuestion(&tt.in)
+			if err != nil {
+				t.Fatalf("convert error: %v", err)
+			}
+			stored := convertQuestion(question)
+
+			result, err := formats.ConvertQuestion(convertQuestionIn(&stored))
+			if err != nil {
+				t.Fatalf("archive question is rejected: %v", err)
+			}
+			// точное значение с погрешностью в архиве хранится границами, при импорте остается исходный текст
+			if question.Type == model.QuestionTypeNumeric {
+				result.AnswerOptions[0].Answer = question.AnswerOptions[0].Answer
+			}
+			if !reflect.DeepEqual(result, question) {
+				t.Errorf("expected %+v, got %+v", question, result)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	id := uuid.New()
+	question := `{"id":"` + id.String() + `","type":"choice","text":"Вопрос","points":1,"answer_options":[{"answer":"Да","is_correct":true}]}`
+	nilQuestion := `{"id":"` + uuid.Nil.String() + `","type":"choice","text":"Вопрос","points":1,"answer_options":[{"answer":"Да","is_correct":true}]}`
+	withImage := `{"id":"` + id.String() + `","type":"choice","text":"Вопрос","image":"map.png","points":1,"answer_options":[{"answer":"Да","is_correct":true}]}`
+	unknownType := `{"id":"` + uuid.New().String() + `","type":"foo","text":"Вопрос","points":1,"answer_options":[{"answer":"Да","is_correct":true}]}`
+	noCorrect := `{"id":"` + uuid.New().String() + `","type":"choice","text":"Вопрос","points":1,"answer_options":[{"answer":"Да"}]}`
+	numeric := `{"id":"` + uuid.New().String() + `","type":"numeric","text":"Сколько?","points":1,"answer_options":[{"answer":"2","is_correct":true,"min_value":3,"max_value":1}]}`
+
+	tests := []struct {
+		name     string
+		in       []byte
+		expected error
+		// invalid вопрос не прошел проверку formats.ConvertQuestion
+		invalid bool
+		number  int
+	}{
+		{
+			name:     "not a zip",
+			in:       []byte("not a zip"),
+			expected: ErrInvalidArchive,
+		},
+		{
+			name:     "no manifest",
+			in:       newArchive(t, map[string][]byte{"images/map.png": []byte("png")}),
+			expected: ErrInvalidArchive,
+		},
+		{
+			name:     "broken manifest",
+			in:       newArchive(t, map[string][]byte{manifestName: []byte("{")}),
+			expected: ErrInvalidArchive,
+		},
+		{
+			name:     "unsupported version",
+			in:       newArchive(t, map[string][]byte{manifestName: []byte(`{"version":99,"questions":[]}`)}),
+			expected: ErrInvalidArchive,
+		},
+		{
+			name:     "missing image",
+			in:       newArchive(t, map[string][]byte{manifestName: []byte(`{"version":1,"type":"async","questions":[` + withImage + `]}`)}),
+			expected: ErrInvalidArchive,
+		},
+		{
+			name:     "unsupported game type",
+			in:       newArchive(t, map[string][]byte{manifestName: []byte(`{"version":1,"type":"foo","questions":[` + question + `]}`)}),
+			expected: ErrInvalidArchive,
+		},
+		{
+			name:    "unsupported question type",
+			in:      newArchive(t, map[string][]byte{manifestName: []byte(`{"version":1,"type":"async","questions":[` + question + `,` + unknownType + `]}`)}),
+			invalid: true,
+			number:  2,
+		},
+		{
+			name:    "no correct answer",
+			in:      newArchive(t, map[string][]byte{manifestName: []byte(`{"version":1,"type":"async","questions":[` + noCorrect + `]}`)}),
+			invalid: true,
+			number:  1,
+		},
+		{
+			name:    "inverted numeric range",
+			in:      newArchive(t, map[string][]byte{manifestName: []byte(`{"version":1,"type":"live","questions":[` + question + `,` + numeric + `]}`)}),
+			invalid: true,
+			number:  2,
+		},
+		{
+			name:     "nil question id",
+			in:       newArchive(t, map[string][]byte{manifestName: []byte(`{"version":1,"type":"async","questions":[` + question + `,` + nilQuestion + `]}`)}),
+			expected: ErrInvalidQuestionID,
+			number:   2,
+		},
+		{
+			name:     "duplicate question id",
+			in:       newArchive(t, map[string][]byte{manifestName: []byte(`{"version":1,"type":"async","questions":[` + question + `,` + question + `]}`)}),
+			expected: ErrInvalidQuestionID,
+			number:   2,
+		},
+		{
+			name:     "large manifest",
+			in:       newArchive(t, map[string][]byte{manifestName: bytes.Repeat([]byte(" "), maxFileSize+1)}),
+			expected: formats.ErrTooLarge,
+		},
+		{
+			name: "large unpacked total",
+			// каждый файл в пределах ограничения, но вместе они больше допустимого
+			in:       newArchive(t, imageArchive(maxUnpackedSize/maxFileSize+1)),
+			expected: formats.ErrTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tt.in))
+			var invalidErr *InvalidQuestionError
+			if tt.invalid && !errors.As(err, &invalidErr) {
+				t.Fatalf("expected invalid question, got %v", err)
+			}
+			if !tt.invalid && !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+
+			var questionErr *contracts.QuestionError
+			if tt.number > 0 && (!errors.As(err, &questionErr) || questionErr.Number != tt.number) {
+				t.Errorf("expected error in question %d, got %v", tt.number, err)
+			}
+		})
+	}
+}
+
+// newArchive zip-архив с файлами, данные сжимаются, как в настоящем архиве
+func newArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	out := bytes.Buffer{}
+	writer := zip.NewWriter(&out)
+	for name, data := range files {
+		fileWriter, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("write error: %v", err)
+		}
+		if _, err = fileWriter.Write(data); err != nil {
+			t.Fatalf("write error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	return out.Bytes()
+}
+
+// imageArchive манифест и count изображений наибольшего допустимого размера
+func imageArchive(count int) map[string][]byte {
+	questions := make([]string, 0, count)
+	result := make(map[string][]byte, count+1)
+	for i := range count {
+		name := strconv.Itoa(i)
+		questions = append(questions, `{"id":"`+uuid.New().String()+`","type":"choice","text":"Вопрос","image":"`+name+`","points":1,"answer_options":[{"answer":"Да","is_correct":true}]}`)
+		result[imagesDir+"/"+name] = bytes.Repeat([]byte{0}, maxFileSize)
+	}
+	result[manifestName] = []byte(`{"version":1,"type":"async","questions":[` + strings.Join(questions, ",") + `]}`)
+
+	return result
+}
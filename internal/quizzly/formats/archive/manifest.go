@@ -0,0 +1,283 @@
+package archive
+
+import (
+	"cmp"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version версия формата манифеста. При несовместимых изменениях версия увеличивается,
+// а чтение старых версий приводится к текущей в migrate
+const Version = 1
+
+type (
+	manifest struct {
+		Version   int                `json:"version"`
+		Title     *string            `json:"title,omitempty"`
+		Type      model.GameType     `json:"type"`
+		Settings  manifestSettings   `json:"settings"`
+		Questions []manifestQuestion `json:"questions"`
+	}
+
+	// manifestSettings длительности хранятся в секундах
+	manifestSettings struct {
+		IsPrivate         bool                `json:"is_private"`
+		ShuffleQuestions  bool                `json:"shuffle_questions"`
+		ShuffleAnswers    bool                `json:"shuffle_answers"`
+		ShowRightAnswers  bool                `json:"show_right_answers"`
+		InputCustomName   bool                `json:"input_custom_name"`
+		QuestionTimeLimit *int64              `json:"question_time_limit,omitempty"`
+		SpeedBonus        bool                `json:"speed_bonus"`
+		NegativeMarking   bool                `json:"negative_marking"`
+		PartialCredit     bool                `json:"partial_credit"`
+		HideLeaderboard   bool                `json:"hide_leaderboard"`
+		QuestionPoolSize  *int                `json:"question_pool_size,omitempty"`
+		StratifyPool      bool                `json:"stratify_pool"`
+		MaxAttempts       *int                `json:"max_attempts,omitempty"`
+		AttemptPolicy     model.AttemptPolicy `json:"attempt_policy,omitempty"`
+		InactivityTimeout *int64              `json:"inactivity_timeout,omitempty"`
+	}
+
+	// manifestQuestion Image имя файла в папке images архива.
+	// ID нужен только для переходов между вопросами внутри архива, при импорте выдаются новые
+	manifestQuestion struct {
+		ID             uuid.UUID              `json:"id"`
+		Type           model.QuestionType     `json:"type"`
+		Text           string                 `json:"text"`
+		Image          *string                `json:"image,omitempty"`
+		TimeLimit      *int64                 `json:"time_limit,omitempty"`
+		Points         int                    `json:"points"`
+		Tag            *string                `json:"tag,omitempty"`
+		TextMatch      *manifestTextMatch     `json:"text_match,omitempty"`
+		NextQuestionID *uuid.UUID             `json:"next_question_id,omitempty"`
+		AnswerOptions  []manifestAnswerOption `json:"answer_options"`
+	}
+
+	manifestTextMatch struct {
+		Mode     model.TextMatchMode `json:"mode"`
+		Distance *int                `json:"distance,omitempty"`
+		Ratio    *float64            `json:"ratio,omitempty"`
+	}
+
+	manifestAnswerOption struct {
+		Answer         string     `json:"answer"`
+		IsCorrect      bool       `json:"is_correct"`
+		Position       *int       `json:"position,omitempty"`
+		Pair           *string    `json:"pair,omitempty"`
+		MinValue       *float64   `json:"min_value,omitempty"`
+		MaxValue       *float64   `json:"max_value,omitempty"`
+		Region         *string    `json:"region,omitempty"`
+		NextQuestionID *uuid.UUID `json:"next_question_id,omitempty"`
+	}
+)
+
+func convertSettings(in model.GameSettings) manifestSettings {
+	return manifestSettings{
+		IsPrivate:         in.IsPrivate,
+		ShuffleQuestions:  in.ShuffleQuestions,
+		ShuffleAnswers:    in.ShuffleAnswers,
+		ShowRightAnswers:  in.ShowRightAnswers,
+		InputCustomName:   in.InputCustomName,
+		QuestionTimeLimit: durationToSeconds(in.QuestionTimeLimit),
+		SpeedBonus:        in.SpeedBonus,
+		NegativeMarking:   in.NegativeMarking,
+		PartialCredit:     in.PartialCredit,
+		HideLeaderboard:   in.HideLeaderboard,
+		QuestionPoolSize:  in.QuestionPoolSize,
+		StratifyPool:      in.StratifyPool,
+		MaxAttempts:       in.MaxAttempts,
+		AttemptPolicy:     in.AttemptPolicy,
+		InactivityTimeout: durationToSeconds(in.InactivityTimeout),
+	}
+}
+
+func convertManifestSettings(in manifestSettings) model.GameSettings {
+	return model.GameSettings{
+		IsPrivate:         in.IsPrivate,
+		ShuffleQuestions:  in.ShuffleQuestions,
+		ShuffleAnswers:    in.ShuffleAnswers,
+		ShowRightAnswers:  in.ShowRightAnswers,
+		InputCustomName:   in.InputCustomName,
+		QuestionTimeLimit: secondsToDuration(in.QuestionTimeLimit),
+		SpeedBonus:        in.SpeedBonus,
+		NegativeMarking:   in.NegativeMarking,
+		PartialCredit:     in.PartialCredit,
+		HideLeaderboard:   in.HideLeaderboard,
+		QuestionPoolSize:  in.QuestionPoolSize,
+		StratifyPool:      in.StratifyPool,
+		MaxAttempts:       in.MaxAttempts,
+		AttemptPolicy:     in.AttemptPolicy,
+		InactivityTimeout: secondsToDuration(in.InactivityTimeout),
+	}
+}
+
+func convertQuestion(in *model.Question) manifestQuestion {
+	result := manifestQuestion{
+		ID:             in.ID,
+		Type:           in.Type,
+		Text:           in.Text,
+		Image:          in.ImageID,
+		TimeLimit:      durationToSeconds(in.TimeLimit),
+		Points:         in.Points,
+		Tag:            in.Tag,
+		NextQuestionID: in.NextQuestionID,
+		AnswerOptions:  make([]manifestAnswerOption, 0, len(in.AnswerOptions)),
+	}
+	if in.TextMatch.Mode != "" {
+		result.TextMatch = &manifestTextMatch{
+			Mode:     in.TextMatch.Mode,
+			Distance: in.TextMatch.Distance,
+			Ratio:    in.TextMatch.Ratio,
+		}
+	}
+	for _, answerOption := range in.AnswerOptions {
+		result.AnswerOptions = append(result.AnswerOptions, manifestAnswerOption{
+			Answer:         answerOption.Answer,
+			IsCorrect:      answerOption.IsCorrect,
+			Position:       answerOption.Position,
+			Pair:           answerOption.Pair,
+			MinValue:       answerOption.MinValue,
+			MaxValue:       answerOption.MaxValue,
+			Region:         answerOption.Region,
+			NextQuestionID: answerOption.NextQuestionID,
+		})
+	}
+
+	return result
+}
+
+// convertManifestQuestion ids сопоставляет идентификаторы вопросов из архива с новыми
+func convertManifestQuestion(in *manifestQuestion, ids map[uuid.UUID]uuid.UUID) model.Question {
+	result := model.Question{
+		ID:             ids[in.ID],
+		Type:           in.Type,
+		Text:           in.Text,
+		ImageID:        in.Image,
+		TimeLimit:      secondsToDuration(in.TimeLimit),
+		Points:         in.Points,
+		Tag:            in.Tag,
+		NextQuestionID: remapID(in.NextQuestionID, ids),
+		AnswerOptions:  make([]model.AnswerOption, 0, len(in.AnswerOptions)),
+	}
+	if in.TextMatch != nil {
+		result.TextMatch = model.TextMatchSettings{
+			Mode:     in.TextMatch.Mode,
+			Distance: in.TextMatch.Distance,
+			Ratio:    in.TextMatch.Ratio,
+		}
+	}
+	for _, answerOption := range in.AnswerOptions {
+		result.AnswerOptions = append(result.AnswerOptions, model.AnswerOption{
+			Answer:         answerOption.Answer,
+			IsCorrect:      answerOption.IsCorrect,
+			Position:       answerOption.Position,
+			Pair:           answerOption.Pair,
+			MinValue:       answerOption.MinValue,
+			MaxValue:       answerOption.MaxValue,
+			Region:         answerOption.Region,
+			NextQuestionID: remapID(answerOption.NextQuestionID, ids),
+		})
+	}
+
+	return result
+}
+
+// convertQuestionIn вопрос в виде, который проверяет formats.ConvertQuestion
+func convertQuestionIn(in *manifestQuestion) *formats.QuestionIn {
+	result := &formats.QuestionIn{
+		Type:    in.Type,
+		Text:    in.Text,
+		ImageID: in.Image,
+		Tag:     value(in.Tag),
+	}
+	if in.Points != 0 {
+		result.Points = strconv.Itoa(in.Points)
+	}
+	if in.TimeLimit != nil {
+		result.TimeLimit = strconv.FormatInt(*in.TimeLimit, 10)
+	}
+	if in.TextMatch != nil {
+		result.TextMatch = formats.TextMatchIn{Mode: string(in.TextMatch.Mode)}
+		if in.TextMatch.Distance != nil {
+			result.TextMatch.Distance = strconv.Itoa(*in.TextMatch.Distance)
+		}
+		if in.TextMatch.Ratio != nil {
+			result.TextMatch.Ratio = formatFloat(*in.TextMatch.Ratio * 100)
+		}
+	}
+
+	answerOptions := in.AnswerOptions
+	if in.Type == model.QuestionTypeOrdering {
+		answerOptions = slices.Clone(answerOptions)
+		slices.SortStableFunc(answerOptions, func(a, b manifestAnswerOption) int {
+			return cmp.Compare(value(a.Position), value(b.Position))
+		})
+	}
+	for _, answerOption := range answerOptions {
+		result.Options = append(result.Options, answerOption.Answer)
+		result.Correct = append(result.Correct, answerOption.IsCorrect)
+		result.Pairs = append(result.Pairs, value(answerOption.Pair))
+		result.Regions = append(result.Regions, value(answerOption.Region))
+	}
+	if in.Type == model.QuestionTypeNumeric && len(in.AnswerOptions) > 0 {
+		if in.AnswerOptions[0].MinValue != nil {
+			result.Numeric.Min = formatFloat(*in.AnswerOptions[0].MinValue)
+		}
+		if in.AnswerOptions[0].MaxValue != nil {
+			result.Numeric.Max = formatFloat(*in.AnswerOptions[0].MaxValue)
+		}
+	}
+
+	return result
+}
+
+// remapID переход на вопрос, которого нет в архиве, сбрасывается
+func remapID(in *uuid.UUID, ids map[uuid.UUID]uuid.UUID) *uuid.UUID {
+	if in == nil {
+		return nil
+	}
+
+	result, ok := ids[*in]
+	if !ok {
+		return nil
+	}
+
+	return &result
+}
+
+func durationToSeconds(in *time.Duration) *int64 {
+	if in == nil {
+		return nil
+	}
+
+	result := int64(in.Seconds())
+	return &result
+}
+
+func secondsToDuration(in *int64) *time.Duration {
+	if in == nil {
+		return nil
+	}
+
+	result := time.Duration(*in) * time.Second
+	return &result
+}
+
+func formatFloat(in float64) string {
+	return strconv.FormatFloat(in, 'f', -1, 64)
+}
+
+// value nil заменяется нулевым значением
+func value[T any](in *T) T {
+	var result T
+	if in != nil {
+		result = *in
+	}
+
+	return result
+}
@@ -0,0 +1,372 @@
+package gift
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"strconv"
+	"strings"
+)
+
+const (
+	commentPrefix  = "//"
+	categoryPrefix = "$CATEGORY:"
+	titleDelimiter = "::"
+
+	answersStart   = "{"
+	answersEnd     = "}"
+	correctMarker  = '='
+	wrongMarker    = '~'
+	feedbackMarker = "#"
+	weightMarker   = "%"
+	matchingMarker = "->"
+
+	gapPlaceholder = "___"
+	answerTrue     = "Верно"
+	answerFalse    = "Неверно"
+
+	maxLineLength = 1 << 20
+)
+
+var textFormats = []string{"[html]", "[moodle]", "[plain]", "[markdown]"}
+
+type (
+	block struct {
+		line     int
+		text     string
+		category string
+	}
+
+	answer struct {
+		text      string
+		marker    byte
+		isCorrect bool
+	}
+)
+
+// Parse разбирает вопросы в формате GIFT. Поддерживаются вопросы с выбором ответа, короткий ответ и верно/неверно.
+// Если хотя бы один вопрос не разобран, возвращается formats.Report со всеми ошибками
+func Parse(in io.Reader) ([]model.Question, error) {
+	blocks, report, err := split(in)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.Question, 0, len(blocks))
+	for _, item := range blocks {
+		question, err := parseQuestion(item)
+		if err != nil {
+			report = append(report, formats.LineError{Line: item.line, Message: err.Error()})
+			continue
+		}
+
+		result = append(result, *question)
+	}
+
+	if len(report) > 0 {
+		return nil, report
+	}
+
+	return result, nil
+}
+
+// split делит файл на вопросы по пустым строкам вне фигурных скобок и пропускает комментарии
+func split(in io.Reader) ([]block, formats.Report, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineLength)
+
+	var (
+		result   []block
+		report   formats.Report
+		current  *block
+		category string
+		depth    int
+	)
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if depth > 0 {
+			report = append(report, formats.LineError{Line: current.line, Message: "не закрыта фигурная скобка"})
+		} else {
+			result = append(result, *current)
+		}
+		current = nil
+		depth = 0
+	}
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\uFEFF")
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, commentPrefix) {
+			continue
+		}
+		if trimmed == "" && depth == 0 {
+			flush()
+			continue
+		}
+		if current == nil && strings.HasPrefix(trimmed, categoryPrefix) {
+			category = parseCategory(trimmed)
+			continue
+		}
+
+		if current == nil {
+			current = &block{line: lineNumber, category: category}
+		} else {
+			current.text += "\n"
+		}
+		current.text += line
+		depth += countUnescaped(line, answersStart) - countUnescaped(line, answersEnd)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	flush()
+
+	return result, report, nil
+}
+
+// parseCategory последняя часть пути категории используется как тег вопроса
+func parseCategory(line string) string {
+	path := strings.TrimSpace(strings.TrimPrefix(line, categoryPrefix))
+	parts := strings.Split(path, "/")
+	return strings.TrimSpace(parts[len(parts)-1])
+}
+
+func parseQuestion(in block) (*model.Question, error) {
+	text := strings.TrimSpace(in.text)
+	if strings.HasPrefix(text, titleDelimiter) {
+		end := indexUnescaped(text[len(titleDelimiter):], titleDelimiter)
+		if end < 0 {
+			return nil, errors.New("не закрыт заголовок вопроса")
+		}
+		text = strings.TrimSpace(text[len(titleDelimiter)*2+end:])
+	}
+	for _, textFormat := range textFormats {
+		text = strings.TrimSpace(strings.TrimPrefix(text, textFormat))
+	}
+
+	start := indexUnescaped(text, answersStart)
+	if start < 0 {
+		return nil, errors.New("вопрос без ответов не поддерживается")
+	}
+	end := indexUnescaped(text[start:], answersEnd)
+	if end < 0 {
+		return nil, errors.New("не закрыта фигурная скобка")
+	}
+	end += start
+
+	// вопрос с пропуском: ответы стоят посреди текста
+	questionText := unescape(strings.TrimSpace(text[:start]))
+	after := strings.TrimSpace(text[end+len(answersEnd):])
+	if indexUnescaped(after, answersStart) >= 0 {
+		return nil, errors.New("в вопросе может быть только один блок ответов")
+	}
+	if after != "" {
+		questionText = strings.TrimSpace(unescape(text[:start]) + gapPlaceholder + unescape(text[end+len(answersEnd):]))
+	}
+	if questionText == "" {
+		return nil, errors.New("пустой текст вопроса")
+	}
+
+	question := &model.Question{
+		Text:   questionText,
+		Points: model.DefaultQuestionPoints,
+	}
+	if in.category != "" {
+		question.Tag = &in.category
+	}
+
+	body := strings.TrimSpace(text[start+len(answersStart) : end])
+	switch {
+	case body == "":
+		return nil, errors.New("вопросы с развернутым ответом не поддерживаются")
+	case strings.HasPrefix(body, feedbackMarker):
+		return nil, errors.New("числовые вопросы не поддерживаются")
+	}
+
+	if isCorrect, ok := parseTrueFalse(body); ok {
+		question.Type = model.QuestionTypeChoice
+		question.AnswerOptions = []model.AnswerOption{
+			{Answer: answerTrue, IsCorrect: isCorrect},
+			{Answer: answerFalse, IsCorrect: !isCorrect},
+		}
+		return question, nil
+	}
+
+	answers, err := parseAnswers(body)
+	if err != nil {
+		return nil, err
+	}
+
+	correctCount := 0
+	hasWrong := false
+	for _, item := range answers {
+		if item.isCorrect {
+			correctCount++
+		}
+		if item.marker == wrongMarker {
+			hasWrong = true
+		}
+		question.AnswerOptions = append(question.AnswerOptions, model.AnswerOption{
+			Answer:    item.text,
+			IsCorrect: item.isCorrect,
+		})
+	}
+
+	switch {
+	case !hasWrong:
+		// только правильные ответы - это короткий ответ, регистр, как и в Moodle, не учитывается
+		question.Type = model.QuestionTypeFillTheGap
+		question.TextMatch = model.TextMatchSettings{Mode: model.TextMatchModeCaseInsensitive}
+	case correctCount == 1:
+		question.Type = model.QuestionTypeChoice
+	default:
+		question.Type = model.QuestionTypeMultipleChoice
+	}
+	if correctCount == 0 {
+		return nil, errors.New("не указан правильный ответ")
+	}
+
+	return question, nil
+}
+
+func parseTrueFalse(body string) (bool, bool) {
+	value := body
+	if feedback := indexUnescaped(body, feedbackMarker); feedback >= 0 {
+		value = body[:feedback]
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "T", "TRUE":
+		return true, true
+	case "F", "FALSE":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// parseAnswers варианты начинаются с "=" (правильный) или "~" (неправильный).
+// Вариант с положительным весом "~%50%" считается правильным, отзывы после "#" отбрасываются
+func parseAnswers(body string) ([]answer, error) {
+	starts := make([]int, 0, 4)
+	for i := 0; i < len(body); i++ {
+		if body[i] == '\\' {
+			i++
+			continue
+		}
+		if body[i] == correctMarker || body[i] == wrongMarker {
+			starts = append(starts, i)
+		}
+	}
+	if len(starts) == 0 || strings.TrimSpace(body[:starts[0]]) != "" {
+		return nil, errors.New(`варианты ответа должны начинаться с "=" или "~"`)
+	}
+
+	result := make([]answer, 0, len(starts))
+	for i, start := range starts {
+		end := len(body)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+
+		item, err := parseAnswer(body[start], body[start+1:end])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *item)
+	}
+
+	return result, nil
+}
+
+func parseAnswer(marker byte, text string) (*answer, error) {
+	if feedback := indexUnescaped(text, feedbackMarker); feedback >= 0 {
+		text = text[:feedback]
+	}
+	if indexUnescaped(text, matchingMarker) >= 0 {
+		return nil, errors.New("вопросы на сопоставление не поддерживаются")
+	}
+
+	text = strings.TrimSpace(text)
+	weight := float64(0)
+	hasWeight := strings.HasPrefix(text, weightMarker)
+	if hasWeight {
+		end := strings.Index(text[len(weightMarker):], weightMarker)
+		if end < 0 {
+			return nil, errors.New("не закрыт вес варианта ответа")
+		}
+
+		var err error
+		weight, err = strconv.ParseFloat(text[len(weightMarker):len(weightMarker)+end], 64)
+		if err != nil {
+			return nil, errors.New("неверный вес варианта ответа")
+		}
+		text = strings.TrimSpace(text[len(weightMarker)*2+end:])
+	}
+
+	text = unescape(text)
+	if text == "" {
+		return nil, errors.New("пустой вариант ответа")
+	}
+
+	return &answer{
+		text:      text,
+		marker:    marker,
+		isCorrect: marker == correctMarker && (!hasWeight || weight > 0) || marker == wrongMarker && weight > 0,
+	}, nil
+}
+
+// indexUnescaped позиция первого вхождения sub, не экранированного обратной косой чертой
+func indexUnescaped(s string, sub string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], sub) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func countUnescaped(s string, sub string) int {
+	result := 0
+	for i := indexUnescaped(s, sub); i >= 0; i = indexUnescaped(s, sub) {
+		result++
+		s = s[i+len(sub):]
+	}
+
+	return result
+}
+
+// unescape убирает экранирование специальных символов, "\n" заменяется переносом строки
+func unescape(s string) string {
+	builder := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			builder.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		if s[i] == 'n' {
+			builder.WriteByte('\n')
+			continue
+		}
+		builder.WriteByte(s[i])
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,238 @@
+package gift
+
+import (
+	"errors"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type expectedQuestion struct {
+	text    string
+	kind    model.QuestionType
+	tag     string
+	answers []string
+	correct []bool
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected []expectedQuestion
+	}{
+		{
+			name: "choice",
+			in:   "::Q1:: Столица Франции? {=Париж ~Лион ~Марсель}",
+			expected: []expectedQuestion{{
+				text:    "Столица Франции?",
+				kind:    model.QuestionTypeChoice,
+				answers: []string{"Париж", "Лион", "Марсель"},
+				correct: []bool{true, false, false},
+			}},
+		},
+		{
+			name: "multiple choice by weights",
+			in:   "Простые числа {~%50%2 ~%50%3 ~%-100%4}",
+			expected: []expectedQuestion{{
+				text:    "Простые числа",
+				kind:    model.QuestionTypeMultipleChoice,
+				answers: []string{"2", "3", "4"},
+				correct: []bool{true, true, false},
+			}},
+		},
+		{
+			name: "short answer",
+			in:   "Столица Италии? {=Рим =Roma}",
+			expected: []expectedQuestion{{
+				text:    "Столица Италии?",
+				kind:    model.QuestionTypeFillTheGap,
+				answers: []string{"Рим", "Roma"},
+				correct: []bool{true, true},
+			}},
+		},
+		{
+			name: "true false",
+			in:   "Земля круглая {T}",
+			expected: []expectedQuestion{{
+				text:    "Земля круглая",
+				kind:    model.QuestionTypeChoice,
+				answers: []string{answerTrue, answerFalse},
+				correct: []bool{true, false},
+			}},
+		},
+		{
+			name: "gap in the middle and category",
+			in:   "$CATEGORY: $course$/Города/Легкие\n\nПариж {=столица ~город} Франции",
+			expected: []expectedQuestion{{
+				text:    "Париж " + gapPlaceholder + " Франции",
+				kind:    model.QuestionTypeChoice,
+				tag:     "Легкие",
+				answers: []string{"столица", "город"},
+				correct: []bool{true, false},
+			}},
+		},
+		{
+			name: "escaping, feedback and comments",
+			in:   "// комментарий\n[markdown]Сколько будет 1\\=1? {=Да\\~ точно #верно ~Нет #неверно}",
+			expected: []expectedQuestion{{
+				text:    "Сколько будет 1=1?",
+				kind:    model.QuestionTypeChoice,
+				answers: []string{"Да~ точно", "Нет"},
+				correct: []bool{true, false},
+			}},
+		},
+		{
+			name: "several questions",
+			in:   "Первый {=a ~b}\n\nВторой {\n=c\n~d\n}\n",
+			expected: []expectedQuestion{
+				{text: "Первый", kind: model.QuestionTypeChoice, answers: []string{"a", "b"}, correct: []bool{true, false}},
+				{text: "Второй", kind: model.QuestionTypeChoice, answers: []string{"c", "d"}, correct: []bool{true, false}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions, err := Parse(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(questions) != len(tt.expected) {
+				t.Fatalf("expected %d questions, got %d", len(tt.expected), len(questions))
+			}
+
+			for i, expected := range tt.expected {
+				question := questions[i]
+				if question.Text != expected.text {
+					t.Errorf("text: expected %q, got %q", expected.text, question.Text)
+				}
+				if question.Type != expected.kind {
+					t.Errorf("type: expected %s, got %s", expected.kind, question.Type)
+				}
+				if tag := derefString(question.Tag); tag != expected.tag {
+					t.Errorf("tag: expected %q, got %q", expected.tag, tag)
+				}
+
+				answers := make([]string, 0, len(question.AnswerOptions))
+				correct := make([]bool, 0, len(question.AnswerOptions))
+				for _, item := range question.AnswerOptions {
+					answers = append(answers, item.Answer)
+					correct = append(correct, item.IsCorrect)
+				}
+				if !reflect.DeepEqual(answers, expected.answers) {
+					t.Errorf("answers: expected %q, got %q", expected.answers, answers)
+				}
+				if !reflect.DeepEqual(correct, expected.correct) {
+					t.Errorf("correct: expected %v, got %v", expected.correct, correct)
+				}
+			}
+		})
+	}
+}
+
+func TestParseReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected formats.Report
+	}{
+		{
+			name:     "no answers",
+			in:       "Вопрос без ответов",
+			expected: formats.Report{{Line: 1, Message: "вопрос без ответов не поддерживается"}},
+		},
+		{
+			name:     "unclosed brace",
+			in:       "Первый {=a ~b}\n\nВторой {=a ~b",
+			expected: formats.Report{{Line: 3, Message: "не закрыта фигурная скобка"}},
+		},
+		{
+			name:     "unclosed title",
+			in:       "::Заголовок Вопрос {=a}",
+			expected: formats.Report{{Line: 1, Message: "не закрыт заголовок вопроса"}},
+		},
+		{
+			name:     "two answer blocks",
+			in:       "Вопрос {=a ~b} и еще {=c ~d}",
+			expected: formats.Report{{Line: 1, Message: "в вопросе может быть только один блок ответов"}},
+		},
+		{
+			name:     "empty text",
+			in:       "{=a ~b}",
+			expected: formats.Report{{Line: 1, Message: "пустой текст вопроса"}},
+		},
+		{
+			name:     "open text",
+			in:       "Расскажите о себе {}",
+			expected: formats.Report{{Line: 1, Message: "вопросы с развернутым ответом не поддерживаются"}},
+		},
+		{
+			name:     "numeric",
+			in:       "Сколько? {#2:1}",
+			expected: formats.Report{{Line: 1, Message: "числовые вопросы не поддерживаются"}},
+		},
+		{
+			name:     "matching",
+			in:       "Сопоставьте {=a -> b =c -> d}",
+			expected: formats.Report{{Line: 1, Message: "вопросы на сопоставление не поддерживаются"}},
+		},
+		{
+			name:     "answers without markers",
+			in:       "Вопрос {a b}",
+			expected: formats.Report{{Line: 1, Message: `варианты ответа должны начинаться с "=" или "~"`}},
+		},
+		{
+			name:     "unclosed weight",
+			in:       "Вопрос {~%50 a ~b}",
+			expected: formats.Report{{Line: 1, Message: "не закрыт вес варианта ответа"}},
+		},
+		{
+			name:     "invalid weight",
+			in:       "Вопрос {~%abc%a ~b}",
+			expected: formats.Report{{Line: 1, Message: "неверный вес варианта ответа"}},
+		},
+		{
+			name:     "empty answer",
+			in:       "Вопрос {= ~b}",
+			expected: formats.Report{{Line: 1, Message: "пустой вариант ответа"}},
+		},
+		{
+			name:     "no correct answer",
+			in:       "Вопрос {~a ~b}",
+			expected: formats.Report{{Line: 1, Message: "не указан правильный ответ"}},
+		},
+		{
+			name: "all errors at once",
+			in:   "Хороший {=a ~b}\n\nПлохой\n\nЕще плохой {~a}",
+			expected: formats.Report{
+				{Line: 3, Message: "вопрос без ответов не поддерживается"},
+				{Line: 5, Message: "не указан правильный ответ"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.in))
+
+			var report formats.Report
+			if !errors.As(err, &report) {
+				t.Fatalf("expected report, got %v", err)
+			}
+			if !reflect.DeepEqual(report, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, report)
+			}
+		})
+	}
+}
+
+func derefString(in *string) string {
+	if in == nil {
+		return ""
+	}
+
+	return *in
+}
@@ -0,0 +1,28 @@
+package formats
+
+import (
+	"context"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/files"
+)
+
+// LoadImages содержимое изображений вопросов по ImageID, каждое изображение загружается один раз
+func LoadImages(ctx context.Context, images files.Manager, questions []model.Question) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+	for _, question := range questions {
+		if question.ImageID == nil {
+			continue
+		}
+		if _, ok := result[*question.ImageID]; ok {
+			continue
+		}
+
+		image, err := images.Get(ctx, *question.ImageID)
+		if err != nil {
+			return nil, err
+		}
+		result[*question.ImageID] = image.Bytes()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package formats
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrTooLarge файл или распакованная часть архива больше допустимого размера
+var ErrTooLarge = errors.New("file is too large")
+
+type limitedReader struct {
+	in   io.Reader
+	left int64
+}
+
+// LimitReader в отличие от io.LimitReader не обрезает данные молча, а возвращает ErrTooLarge.
+// Размерам из заголовков zip верить нельзя, поэтому ограничивается то, что реально прочитано
+func LimitReader(in io.Reader, limit int64) io.Reader {
+	return &limitedReader{in: in, left: limit}
+}
+
+// ReadAll читает не больше limit байт
+func ReadAll(in io.Reader, limit int64) ([]byte, error) {
+	return io.ReadAll(LimitReader(in, limit))
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	// один байт сверх лимита отличает файл на границе от файла больше лимита
+	if int64(len(p)) > r.left+1 {
+		p = p[:r.left+1]
+	}
+
+	n, err := r.in.Read(p)
+	r.left -= int64(n)
+	if r.left < 0 {
+		return n + int(r.left), ErrTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,99 @@
+package lms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/files"
+	"strconv"
+	"strings"
+)
+
+// questionNameLength длина названия вопроса, которое LMS показывают в банке вопросов
+const questionNameLength = 50
+
+// Exporter выгружает вопросы игры в форматы систем управления обучением.
+// Изображения вопросов загружаются из хранилища и встраиваются в выгрузку
+type Exporter struct {
+	images files.Manager
+}
+
+func NewExporter(images files.Manager) *Exporter {
+	return &Exporter{images: images}
+}
+
+// WriteMoodleXML вопросы в формате Moodle XML для импорта в банк вопросов курса
+func (e *Exporter) WriteMoodleXML(ctx context.Context, out io.Writer, questions []model.Question) error {
+	images, err := formats.LoadImages(ctx, e.images, questions)
+	if err != nil {
+		return err
+	}
+
+	return writeMoodleXML(out, questions, images)
+}
+
+// WriteQTI zip-пакет IMS QTI 2.1: отдельный файл на каждый вопрос, тест из всех вопросов и изображения
+func (e *Exporter) WriteQTI(ctx context.Context, out io.Writer, title string, questions []model.Question) error {
+	images, err := formats.LoadImages(ctx, e.images, questions)
+	if err != nil {
+		return err
+	}
+
+	return writeQTI(out, title, questions, images)
+}
+
+// questionName начало текста вопроса без ответов на пропуски
+func questionName(in *model.Question) string {
+	name := []rune(strings.Join(strings.Fields(in.GetDisplayText()), " "))
+	if len(name) <= questionNameLength {
+		return string(name)
+	}
+
+	return string(name[:questionNameLength]) + "…"
+}
+
+// htmlText текст вопроса хранится без разметки, переносы строк заменяются на <br>
+func htmlText(in string) string {
+	return strings.ReplaceAll(html.EscapeString(in), "\n", "<br>")
+}
+
+// formatFraction доля с точностью, которую принимает Moodle, например 33.33333
+func formatFraction(in float64) string {
+	return strconv.FormatFloat(math.Round(in*1e5)/1e5, 'f', -1, 64)
+}
+
+// hotspotRegions области вариантов ответа в пикселях изображения
+func hotspotRegions(in *model.Question, imageData []byte) ([]model.HotspotRegion, image.Config, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, config, fmt.Errorf("hotspot image size: %w", err)
+	}
+
+	result := make([]model.HotspotRegion, 0, len(in.AnswerOptions))
+	for _, answerOption := range in.AnswerOptions {
+		region, err := answerOption.GetRegion()
+		if err != nil {
+			return nil, config, err
+		}
+
+		scaled := make(model.HotspotRegion, 0, len(region))
+		for _, point := range region {
+			scaled = append(scaled, model.HotspotPoint{
+				X: math.Round(point.X * float64(config.Width)),
+				Y: math.Round(point.Y * float64(config.Height)),
+			})
+		}
+		result = append(result, scaled)
+	}
+
+	return result, config, nil
+}
@@ -0,0 +1,351 @@
+package lms
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"net/url"
+	"path"
+	"quizzly/internal/quizzly/model"
+	"strconv"
+	"strings"
+)
+
+const (
+	moodleFormatHTML  = "html"
+	moodleFractionMax = 100
+	// moodlePluginFile ссылка на файл, приложенный к тексту вопроса
+	moodlePluginFile = "@@PLUGINFILE@@/"
+)
+
+// moodleFractions доли в процентах, которые Moodle принимает при импорте, вопрос с любой другой долей отклоняется
+var moodleFractions = []float64{
+	100, 90, 83.33333, 80, 75, 70, 66.66667, 60, 50, 40, 33.33333, 30, 25, 20, 16.66667, 14.28571, 12.5, 11.11111, 10, 5, 0,
+}
+
+type (
+	moodleQuiz struct {
+		XMLName   xml.Name         `xml:"quiz"`
+		Questions []moodleQuestion `xml:"question"`
+	}
+
+	moodleText struct {
+		Text string `xml:"text"`
+	}
+
+	moodleFormattedText struct {
+		Format string       `xml:"format,attr"`
+		Text   string       `xml:"text"`
+		Files  []moodleFile `xml:"file"`
+	}
+
+	moodleFile struct {
+		Name     string `xml:"name,attr"`
+		Path     string `xml:"path,attr"`
+		Encoding string `xml:"encoding,attr"`
+		Data     string `xml:",chardata"`
+	}
+
+	moodleQuestion struct {
+		Type            string               `xml:"type,attr"`
+		Name            moodleText           `xml:"name"`
+		QuestionText    moodleFormattedText  `xml:"questiontext"`
+		DefaultGrade    int                  `xml:"defaultgrade"`
+		Single          string               `xml:"single,omitempty"`
+		ShuffleAnswers  string               `xml:"shuffleanswers,omitempty"`
+		UseCase         string               `xml:"usecase,omitempty"`
+		ResponseFormat  string               `xml:"responseformat,omitempty"`
+		GraderInfo      *moodleFormattedText `xml:"graderinfo"`
+		LayoutType      string               `xml:"layouttype,omitempty"`
+		SelectType      string               `xml:"selecttype,omitempty"`
+		GradingType     string               `xml:"gradingtype,omitempty"`
+		BackgroundImage *moodleFile          `xml:"file"`
+		Answers         []moodleAnswer       `xml:"answer"`
+		Subquestions    []moodleSubquestion  `xml:"subquestion"`
+		Drags           []moodleDrag         `xml:"drag"`
+		Drops           []moodleDrop         `xml:"drop"`
+		Tags            *moodleTags          `xml:"tags"`
+	}
+
+	moodleAnswer struct {
+		Fraction  string `xml:"fraction,attr"`
+		Format    string `xml:"format,attr,omitempty"`
+		Text      string `xml:"text"`
+		Tolerance string `xml:"tolerance,omitempty"`
+	}
+
+	moodleSubquestion struct {
+		Format string     `xml:"format,attr"`
+		Text   string     `xml:"text"`
+		Answer moodleText `xml:"answer"`
+	}
+
+	moodleDrag struct {
+		No        int    `xml:"no"`
+		Text      string `xml:"text"`
+		NoOfDrags int    `xml:"noofdrags"`
+	}
+
+	moodleDrop struct {
+		No     int    `xml:"no"`
+		Shape  string `xml:"shape"`
+		Coords string `xml:"coords"`
+		Choice int    `xml:"choice"`
+	}
+
+	moodleTags struct {
+		Tags []moodleText `xml:"tag"`
+	}
+)
+
+func writeMoodleXML(out io.Writer, questions []model.Question, images map[string][]byte) error {
+	quiz := moodleQuiz{Questions: make([]moodleQuestion, 0, len(questions))}
+	for i := range questions {
+		question, err := convertMoodleQuestion(&questions[i], images)
+		if err != nil {
+			return fmt.Errorf("question %d: %w", i+1, err)
+		}
+		quiz.Questions = append(quiz.Questions, question)
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	return encoder.Encode(quiz)
+}
+
+// convertMoodleQuestion каждый тип вопроса выгружается в ближайший тип вопроса Moodle
+func convertMoodleQuestion(in *model.Question, images map[string][]byte) (moodleQuestion, error) {
+	text := htmlText(in.Text)
+	if in.Type == model.QuestionTypeCloze {
+		text = moodleClozeText(in)
+	}
+
+	result := moodleQuestion{
+		Name:         moodleText{Text: questionName(in)},
+		QuestionText: moodleFormattedText{Format: moodleFormatHTML, Text: text},
+		DefaultGrade: in.Points,
+	}
+	if in.Tag != nil {
+		result.Tags = &moodleTags{Tags: []moodleText{{Text: *in.Tag}}}
+	}
+	// в вопросе с областями изображение служит фоном, а не частью текста
+	if in.ImageID != nil && in.Type != model.QuestionTypeHotspot {
+		file := moodleImage(*in.ImageID, images)
+		result.QuestionText.Text += fmt.Sprintf(`<p><img src="%s%s" alt=""></p>`, moodlePluginFile, url.PathEscape(file.Name))
+		result.QuestionText.Files = append(result.QuestionText.Files, file)
+	}
+
+	switch in.Type {
+	case model.QuestionTypeChoice, model.QuestionTypeOneOfChoice, model.QuestionTypePoll:
+		// в опросе нет правильного ответа, поэтому любой вариант засчитывается
+		result.Type = "multichoice"
+		result.Single = "true"
+		result.ShuffleAnswers = "true"
+		for _, answerOption := range in.AnswerOptions {
+			fraction := 0
+			if answerOption.IsCorrect || in.Type == model.QuestionTypePoll {
+				fraction = moodleFractionMax
+			}
+			result.Answers = append(result.Answers, moodleAnswer{
+				Fraction: strconv.Itoa(fraction),
+				Format:   moodleFormatHTML,
+				Text:     html.EscapeString(answerOption.Answer),
+			})
+		}
+	case model.QuestionTypeMultipleChoice, model.QuestionTypeMultiplePoll:
+		// баллы делятся между правильными вариантами, за неправильный вариант ответ не засчитывается
+		result.Type = "multichoice"
+		result.Single = "false"
+		result.ShuffleAnswers = "true"
+		correctCount := 0
+		for _, answerOption := range in.AnswerOptions {
+			if answerOption.IsCorrect || in.Type == model.QuestionTypeMultiplePoll {
+				correctCount++
+			}
+		}
+		for _, answerOption := range in.AnswerOptions {
+			fraction := -moodleFractionMax
+			if answerOption.IsCorrect || in.Type == model.QuestionTypeMultiplePoll {
+				fraction = moodleFractionMax
+			}
+			result.Answers = append(result.Answers, moodleAnswer{
+				Fraction: moodleFraction(float64(fraction) / float64(max(correctCount, 1))),
+				Format:   moodleFormatHTML,
+				Text:     html.EscapeString(answerOption.Answer),
+			})
+		}
+	case model.QuestionTypeFillTheGap:
+		result.Type = "shortanswer"
+		result.UseCase = "0"
+		if in.TextMatch.Mode == model.TextMatchModeExact {
+			result.UseCase = "1"
+		}
+		for _, answerOption := range in.AnswerOptions {
+			result.Answers = append(result.Answers, moodleAnswer{
+				Fraction: strconv.Itoa(moodleFractionMax),
+				Text:     answerOption.Answer,
+			})
+		}
+	case model.QuestionTypeNumeric:
+		// диапазон записывается серединой и допустимым отклонением от нее
+		result.Type = "numerical"
+		for _, answerOption := range in.AnswerOptions {
+			if answerOption.MinValue == nil || answerOption.MaxValue == nil {
+				continue
+			}
+			result.Answers = append(result.Answers, moodleAnswer{
+				Fraction:  strconv.Itoa(moodleFractionMax),
+				Text:      strconv.FormatFloat((*answerOption.MinValue+*answerOption.MaxValue)/2, 'f', -1, 64),
+				Tolerance: strconv.FormatFloat((*answerOption.MaxValue-*answerOption.MinValue)/2, 'f', -1, 64),
+			})
+		}
+	case model.QuestionTypeOrdering:
+		result.Type = "ordering"
+		result.LayoutType = "VERTICAL"
+		result.SelectType = "ALL"
+		result.GradingType = "ABSOLUTE_POSITION"
+		for _, answerOption := range in.GetOrderedAnswers() {
+			result.Answers = append(result.Answers, moodleAnswer{
+				Fraction: "1",
+				Format:   moodleFormatHTML,
+				Text:     html.EscapeString(answerOption.Answer),
+			})
+		}
+	case model.QuestionTypeMatching:
+		result.Type = "matching"
+		result.ShuffleAnswers = "true"
+		for _, answerOption := range in.AnswerOptions {
+			result.Subquestions = append(result.Subquestions, moodleSubquestion{
+				Format: moodleFormatHTML,
+				Text:   html.EscapeString(answerOption.Answer),
+				Answer: moodleText{Text: answerOption.GetPair()},
+			})
+		}
+	case model.QuestionTypeCloze:
+		result.Type = "cloze"
+	case model.QuestionTypeOpenText:
+		result.Type = "essay"
+		result.ResponseFormat = "editor"
+		if len(in.AnswerOptions) > 0 && in.AnswerOptions[0].Answer != "" {
+			result.GraderInfo = &moodleFormattedText{Format: moodleFormatHTML, Text: htmlText(in.AnswerOptions[0].Answer)}
+		}
+	case model.QuestionTypeHotspot:
+		if err := fillMoodleHotspot(&result, in, images); err != nil {
+			return result, err
+		}
+	default:
+		return result, fmt.Errorf("unsupported question type %s", in.Type)
+	}
+
+	return result, nil
+}
+
+// fillMoodleHotspot подпись каждой области становится маркером, который нужно перетащить в эту область
+func fillMoodleHotspot(out *moodleQuestion, in *model.Question, images map[string][]byte) error {
+	if in.ImageID == nil {
+		return fmt.Errorf("hotspot question without image")
+	}
+
+	regions, _, err := hotspotRegions(in, images[*in.ImageID])
+	if err != nil {
+		return err
+	}
+
+	out.Type = "ddmarker"
+	background := moodleImage(*in.ImageID, images)
+	out.BackgroundImage = &background
+	for i, answerOption := range in.AnswerOptions {
+		out.Drags = append(out.Drags, moodleDrag{No: i + 1, Text: answerOption.Answer, NoOfDrags: 1})
+
+		region := regions[i]
+		drop := moodleDrop{No: i + 1, Choice: i + 1, Shape: "polygon"}
+		if region.IsRectangle() {
+			drop.Shape = "rectangle"
+			drop.Coords = fmt.Sprintf(
+				"%d,%d;%d,%d",
+				int(min(region[0].X, region[1].X)),
+				int(min(region[0].Y, region[1].Y)),
+				int(math.Abs(region[1].X-region[0].X)),
+				int(math.Abs(region[1].Y-region[0].Y)),
+			)
+		} else {
+			points := make([]string, 0, len(region))
+			for _, point := range region {
+				points = append(points, fmt.Sprintf("%d,%d", int(point.X), int(point.Y)))
+			}
+			drop.Coords = strings.Join(points, ";")
+		}
+		out.Drops = append(out.Drops, drop)
+	}
+
+	return nil
+}
+
+// moodleClozeText пропуски записываются в синтаксисе Moodle {1:SHORTANSWER:=ответ~=синоним}
+func moodleClozeText(in *model.Question) string {
+	answerType := "SHORTANSWER"
+	if in.TextMatch.Mode == model.TextMatchModeExact {
+		answerType = "SHORTANSWER_C"
+	}
+
+	builder := strings.Builder{}
+	for _, part := range model.ParseCloze(in.Text) {
+		if !part.IsGap() {
+			builder.WriteString(htmlText(part.Text))
+			continue
+		}
+
+		answers := make([]string, 0, len(part.Answers))
+		for _, answer := range part.Answers {
+			answers = append(answers, "="+moodleClozeEscaper.Replace(answer))
+		}
+		fmt.Fprintf(&builder, "{1:%s:%s}", answerType, strings.Join(answers, "~"))
+	}
+
+	return builder.String()
+}
+
+// moodleClozeEscaper в ответах пропусков экранируются служебные символы синтаксиса пропусков и разметка
+var moodleClozeEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+	`\`, `\\`,
+	`}`, `\}`,
+	`#`, `\#`,
+	`~`, `\~`,
+	`/`, `\/`,
+	`"`, `\"`,
+)
+
+func moodleImage(imageID string, images map[string][]byte) moodleFile {
+	return moodleFile{
+		Name:     path.Base(imageID),
+		Path:     "/",
+		Encoding: "base64",
+		Data:     base64.StdEncoding.EncodeToString(images[imageID]),
+	}
+}
+
+// moodleFraction ближайшая допустимая доля с тем же знаком. Если правильных вариантов больше десяти,
+// сумма долей отличается от 100%, но Moodle все равно ограничивает оценку за вопрос от 0 до 100%
+func moodleFraction(in float64) string {
+	result := moodleFractions[0]
+	for _, fraction := range moodleFractions[1:] {
+		if math.Abs(math.Abs(in)-fraction) < math.Abs(math.Abs(in)-result) {
+			result = fraction
+		}
+	}
+	if result == 0 {
+		return "0"
+	}
+
+	return formatFraction(math.Copysign(result, in))
+}
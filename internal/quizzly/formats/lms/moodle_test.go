@@ -0,0 +1,206 @@
+package lms
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"image"
+	"image/png"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// specialText символы, которые нужно экранировать и в XML, и в HTML внутри него
+const specialText = `1 < 2 & "кавычки" 'апостроф' ]]> <b>не тег</b>`
+
+func TestMoodleFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       float64
+		expected string
+	}{
+		{name: "whole", in: 100, expected: "100"},
+		{name: "half", in: -50, expected: "-50"},
+		{name: "third", in: 100.0 / 3, expected: "33.33333"},
+		{name: "seventh", in: 100.0 / 7, expected: "14.28571"},
+		{name: "thirteenth", in: 100.0 / 13, expected: "10"},
+		{name: "negative thirteenth", in: -100.0 / 13, expected: "-10"},
+		{name: "negative forty first", in: -100.0 / 41, expected: "0"},
+		{name: "zero", in: 0, expected: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := moodleFraction(tt.in); result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWriteMoodleXML(t *testing.T) {
+	questions, images := sampleQuestions(t)
+
+	out := bytes.Buffer{}
+	if err := writeMoodleXML(&out, questions, images); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	var quiz moodleQuiz
+	if err := xml.Unmarshal(out.Bytes(), &quiz); err != nil {
+		t.Fatalf("invalid xml: %v", err)
+	}
+	if len(quiz.Questions) != len(questions) {
+		t.Fatalf("expected %d questions, got %d", len(questions), len(quiz.Questions))
+	}
+
+	expectedTypes := []string{"multichoice", "multichoice", "shortanswer", "numerical", "ordering", "matching", "cloze", "essay", "ddmarker"}
+	for i, question := range quiz.Questions {
+		if question.Type != expectedTypes[i] {
+			t.Errorf("question %d: expected type %s, got %s", i+1, expectedTypes[i], question.Type)
+		}
+	}
+
+	t.Run("escaping", func(t *testing.T) {
+		// текст вопроса хранится как HTML, поэтому после разбора XML остается экранированный HTML
+		if text := html.UnescapeString(quiz.Questions[0].QuestionText.Text); text != specialText {
+			t.Errorf("question text: expected %q, got %q", specialText, text)
+		}
+		if text := html.UnescapeString(quiz.Questions[0].Answers[0].Text); text != specialText {
+			t.Errorf("answer: expected %q, got %q", specialText, text)
+		}
+		if text := quiz.Questions[2].Answers[0].Text; text != specialText {
+			t.Errorf("short answer: expected %q, got %q", specialText, text)
+		}
+	})
+
+	t.Run("fractions", func(t *testing.T) {
+		allowed := make([]string, 0, len(moodleFractions)*2)
+		for _, fraction := range moodleFractions {
+			allowed = append(allowed, formatFraction(fraction), formatFraction(-fraction))
+		}
+
+		for _, answer := range quiz.Questions[1].Answers {
+			if !slices.Contains(allowed, answer.Fraction) {
+				t.Errorf("fraction %s is not accepted by Moodle", answer.Fraction)
+			}
+		}
+	})
+
+	t.Run("numeric range", func(t *testing.T) {
+		answer := quiz.Questions[3].Answers[0]
+		if answer.Text != "2" || answer.Tolerance != "1" {
+			t.Errorf("expected 2 ± 1, got %s ± %s", answer.Text, answer.Tolerance)
+		}
+	})
+
+	t.Run("cloze", func(t *testing.T) {
+		expected := "{1:SHORTANSWER:=Париж~=Paris}"
+		if !strings.Contains(quiz.Questions[6].QuestionText.Text, expected) {
+			t.Errorf("expected %q in %q", expected, quiz.Questions[6].QuestionText.Text)
+		}
+	})
+
+	t.Run("hotspot", func(t *testing.T) {
+		drops := quiz.Questions[8].Drops
+		if len(drops) != 1 || drops[0].Shape != "rectangle" || drops[0].Coords != "10,20;40,60" {
+			t.Errorf("unexpected drops %+v", drops)
+		}
+	})
+}
+
+// sampleQuestions по вопросу каждого типа, который выгружается в LMS, и изображение 100x100 для вопроса с областями
+func sampleQuestions(t *testing.T) ([]model.Question, map[string][]byte) {
+	t.Helper()
+
+	imageData := bytes.Buffer{}
+	if err := png.Encode(&imageData, image.NewRGBA(image.Rect(0, 0, 100, 100))); err != nil {
+		t.Fatalf("png error: %v", err)
+	}
+
+	// у каждого из 13 правильных вариантов доля 100/13, которую Moodle не принимает
+	manyCorrect := make([]model.AnswerOption, 0, 14)
+	for i := range 13 {
+		manyCorrect = append(manyCorrect, model.AnswerOption{Answer: strconv.Itoa(i), IsCorrect: true})
+	}
+	manyCorrect = append(manyCorrect, model.AnswerOption{Answer: "нет"})
+
+	return []model.Question{
+		{
+			Type:   model.QuestionTypeChoice,
+			Text:   specialText,
+			Points: 1,
+			Tag:    structs.Pointer("Тег"),
+			AnswerOptions: []model.AnswerOption{
+				{Answer: specialText, IsCorrect: true},
+				{Answer: "Нет"},
+			},
+		},
+		{
+			Type:          model.QuestionTypeMultipleChoice,
+			Text:          "Отметьте все",
+			Points:        1,
+			AnswerOptions: manyCorrect,
+		},
+		{
+			Type:          model.QuestionTypeFillTheGap,
+			Text:          "Впишите",
+			Points:        1,
+			AnswerOptions: []model.AnswerOption{{Answer: specialText, IsCorrect: true}},
+		},
+		{
+			Type:   model.QuestionTypeNumeric,
+			Text:   "Сколько?",
+			Points: 1,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "от 1 до 3", IsCorrect: true, MinValue: structs.Pointer(1.0), MaxValue: structs.Pointer(3.0)},
+			},
+		},
+		{
+			Type:   model.QuestionTypeOrdering,
+			Text:   "Упорядочьте",
+			Points: 1,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "Второй", IsCorrect: true, Position: structs.Pointer(2)},
+				{Answer: "Первый", IsCorrect: true, Position: structs.Pointer(1)},
+			},
+		},
+		{
+			Type:   model.QuestionTypeMatching,
+			Text:   "Сопоставьте",
+			Points: 1,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "Франция", IsCorrect: true, Pair: structs.Pointer("Париж")},
+				{Answer: "Италия", IsCorrect: true, Pair: structs.Pointer("Рим")},
+			},
+		},
+		{
+			Type:   model.QuestionTypeCloze,
+			Text:   "Столица Франции - {Париж|Paris}",
+			Points: 1,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "Париж", IsCorrect: true, Position: structs.Pointer(1)},
+				{Answer: "Paris", IsCorrect: true, Position: structs.Pointer(1)},
+			},
+		},
+		{
+			Type:          model.QuestionTypeOpenText,
+			Text:          "Расскажите о себе",
+			Points:        1,
+			AnswerOptions: []model.AnswerOption{{Answer: specialText}},
+		},
+		{
+			Type:    model.QuestionTypeHotspot,
+			Text:    "Где столица?",
+			ImageID: structs.Pointer("map.png"),
+			Points:  1,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "Столица", IsCorrect: true, Region: structs.Pointer("0.1 0.2,0.5 0.8")},
+			},
+		},
+	}, map[string][]byte{"map.png": imageData.Bytes()}
+}
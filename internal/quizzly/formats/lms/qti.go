@@ -0,0 +1,505 @@
+package lms
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs/collections/slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	qtiNamespace   = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	imsNamespace   = "http://www.imsglobal.org/xsd/imscp_v1p1"
+	qtiItemType    = "imsqti_item_xmlv2p1"
+	qtiTestType    = "imsqti_test_xmlv2p1"
+	qtiManifest    = "imsmanifest.xml"
+	qtiTestPath    = "test.xml"
+	qtiItemsDir    = "items"
+	qtiImagesDir   = "images"
+	qtiResponse    = "RESPONSE"
+	qtiScore       = "SCORE"
+	qtiMaxScore    = "MAXSCORE"
+	qtiMapPoint    = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/map_response_point"
+	qtiMapResponse = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/map_response"
+)
+
+type (
+	qtiItem struct {
+		XMLName       xml.Name                 `xml:"assessmentItem"`
+		Namespace     string                   `xml:"xmlns,attr"`
+		Identifier    string                   `xml:"identifier,attr"`
+		Title         string                   `xml:"title,attr"`
+		Adaptive      bool                     `xml:"adaptive,attr"`
+		TimeDependent bool                     `xml:"timeDependent,attr"`
+		Responses     []qtiResponseDeclaration `xml:"responseDeclaration"`
+		Outcomes      []qtiOutcomeDeclaration  `xml:"outcomeDeclaration"`
+		Body          qtiMarkup                `xml:"itemBody"`
+		Processing    *qtiResponseProcessing   `xml:"responseProcessing"`
+	}
+
+	// qtiMarkup готовая разметка, содержимое записывается без экранирования
+	qtiMarkup struct {
+		Markup string `xml:",innerxml"`
+	}
+
+	qtiResponseDeclaration struct {
+		Identifier  string          `xml:"identifier,attr"`
+		Cardinality string          `xml:"cardinality,attr"`
+		BaseType    string          `xml:"baseType,attr"`
+		Correct     *qtiValues      `xml:"correctResponse"`
+		Mapping     *qtiMapping     `xml:"mapping"`
+		AreaMapping *qtiAreaMapping `xml:"areaMapping"`
+	}
+
+	qtiValues struct {
+		Values []string `xml:"value"`
+	}
+
+	qtiMapping struct {
+		DefaultValue float64       `xml:"defaultValue,attr"`
+		UpperBound   *float64      `xml:"upperBound,attr"`
+		Entries      []qtiMapEntry `xml:"mapEntry"`
+	}
+
+	qtiMapEntry struct {
+		MapKey        string  `xml:"mapKey,attr"`
+		MappedValue   float64 `xml:"mappedValue,attr"`
+		CaseSensitive *bool   `xml:"caseSensitive,attr"`
+	}
+
+	qtiAreaMapping struct {
+		DefaultValue float64           `xml:"defaultValue,attr"`
+		UpperBound   float64           `xml:"upperBound,attr"`
+		Entries      []qtiAreaMapEntry `xml:"areaMapEntry"`
+	}
+
+	qtiAreaMapEntry struct {
+		Shape       string  `xml:"shape,attr"`
+		Coords      string  `xml:"coords,attr"`
+		MappedValue float64 `xml:"mappedValue,attr"`
+	}
+
+	qtiOutcomeDeclaration struct {
+		Identifier   string    `xml:"identifier,attr"`
+		Cardinality  string    `xml:"cardinality,attr"`
+		BaseType     string    `xml:"baseType,attr"`
+		DefaultValue qtiValues `xml:"defaultValue"`
+	}
+
+	// qtiResponseProcessing либо стандартный шаблон, либо собственные правила
+	qtiResponseProcessing struct {
+		Template string `xml:"template,attr,omitempty"`
+		Markup   string `xml:",innerxml"`
+	}
+
+	qtiTest struct {
+		XMLName    xml.Name `xml:"assessmentTest"`
+		Namespace  string   `xml:"xmlns,attr"`
+		Identifier string   `xml:"identifier,attr"`
+		Title      string   `xml:"title,attr"`
+		Part       struct {
+			Identifier     string `xml:"identifier,attr"`
+			NavigationMode string `xml:"navigationMode,attr"`
+			SubmissionMode string `xml:"submissionMode,attr"`
+			Section        struct {
+				Identifier string       `xml:"identifier,attr"`
+				Title      string       `xml:"title,attr"`
+				Visible    bool         `xml:"visible,attr"`
+				Items      []qtiItemRef `xml:"assessmentItemRef"`
+			} `xml:"assessmentSection"`
+		} `xml:"testPart"`
+	}
+
+	qtiManifestFile struct {
+		XMLName       xml.Name      `xml:"manifest"`
+		Namespace     string        `xml:"xmlns,attr"`
+		Identifier    string        `xml:"identifier,attr"`
+		Schema        string        `xml:"metadata>schema"`
+		SchemaVersion string        `xml:"metadata>schemaversion"`
+		Organizations struct{}      `xml:"organizations"`
+		Resources     []qtiResource `xml:"resources>resource"`
+	}
+
+	qtiResource struct {
+		Identifier   string          `xml:"identifier,attr"`
+		Type         string          `xml:"type,attr"`
+		Href         string          `xml:"href,attr"`
+		Files        []qtiHref       `xml:"file"`
+		Dependencies []qtiDependency `xml:"dependency"`
+	}
+
+	qtiItemRef struct {
+		Identifier string `xml:"identifier,attr"`
+		Href       string `xml:"href,attr"`
+	}
+
+	qtiDependency struct {
+		IdentifierRef string `xml:"identifierref,attr"`
+	}
+
+	qtiHref struct {
+		Href string `xml:"href,attr"`
+	}
+)
+
+func writeQTI(out io.Writer, title string, questions []model.Question, images map[string][]byte) error {
+	archive := zip.NewWriter(out)
+	manifest := qtiManifestFile{
+		Namespace:     imsNamespace,
+		Identifier:    "MANIFEST",
+		Schema:        "QTIv2.1 Package",
+		SchemaVersion: "1.0.0",
+	}
+	test := qtiTest{Namespace: qtiNamespace, Identifier: "TEST", Title: title}
+	test.Part.Identifier = "PART"
+	test.Part.NavigationMode = "linear"
+	test.Part.SubmissionMode = "individual"
+	test.Part.Section.Identifier = "SECTION"
+	test.Part.Section.Title = title
+	test.Part.Section.Visible = true
+	testResource := qtiResource{Identifier: "TEST", Type: qtiTestType, Href: qtiTestPath, Files: []qtiHref{{Href: qtiTestPath}}}
+
+	for i := range questions {
+		identifier := fmt.Sprintf("ITEM_%d", i+1)
+		itemPath := path.Join(qtiItemsDir, strings.ToLower(identifier)+".xml")
+
+		item, err := convertQTIItem(&questions[i], identifier, images)
+		if err != nil {
+			return fmt.Errorf("question %d: %w", i+1, err)
+		}
+		if err = writeXMLFile(archive, itemPath, item); err != nil {
+			return err
+		}
+
+		resource := qtiResource{Identifier: identifier, Type: qtiItemType, Href: itemPath, Files: []qtiHref{{Href: itemPath}}}
+		if questions[i].ImageID != nil {
+			resource.Files = append(resource.Files, qtiHref{Href: qtiImagePath(*questions[i].ImageID)})
+		}
+		manifest.Resources = append(manifest.Resources, resource)
+
+		test.Part.Section.Items = append(test.Part.Section.Items, qtiItemRef{Identifier: identifier, Href: itemPath})
+		testResource.Dependencies = append(testResource.Dependencies, qtiDependency{IdentifierRef: identifier})
+	}
+	manifest.Resources = append(manifest.Resources, testResource)
+
+	if err := writeXMLFile(archive, qtiTestPath, test); err != nil {
+		return err
+	}
+	for imageID, data := range images {
+		writer, err := archive.Create(qtiImagePath(imageID))
+		if err != nil {
+			return err
+		}
+		if _, err = writer.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := writeXMLFile(archive, qtiManifest, manifest); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// convertQTIItem каждый тип вопроса выгружается в ближайшее взаимодействие QTI
+func convertQTIItem(in *model.Question, identifier string, images map[string][]byte) (*qtiItem, error) {
+	points := float64(in.Points)
+	result := &qtiItem{
+		Namespace:  qtiNamespace,
+		Identifier: identifier,
+		Title:      questionName(in),
+		Outcomes: []qtiOutcomeDeclaration{
+			{Identifier: qtiScore, Cardinality: "single", BaseType: "float", DefaultValue: qtiValues{Values: []string{"0"}}},
+			{Identifier: qtiMaxScore, Cardinality: "single", BaseType: "float", DefaultValue: qtiValues{Values: []string{formatFraction(points)}}},
+		},
+	}
+
+	body := strings.Builder{}
+	if in.Type != model.QuestionTypeCloze {
+		fmt.Fprintf(&body, "<p>%s</p>", htmlText(in.Text))
+	}
+	// в вопросе с областями изображение выводится внутри взаимодействия
+	if in.ImageID != nil && in.Type != model.QuestionTypeHotspot {
+		fmt.Fprintf(&body, `<p><img src="%s" alt=""/></p>`, qtiImageHref(*in.ImageID))
+	}
+
+	switch in.Type {
+	case model.QuestionTypeChoice, model.QuestionTypeOneOfChoice, model.QuestionTypePoll:
+		response := qtiResponseDeclaration{Identifier: qtiResponse, Cardinality: "single", BaseType: "identifier"}
+		writeQTIChoices(&body, "choiceInteraction", ` maxChoices="1"`, in.AnswerOptions)
+		if in.Type != model.QuestionTypePoll {
+			response.Correct, response.Mapping = qtiChoiceKey(in.AnswerOptions, points, false)
+			result.Processing = &qtiResponseProcessing{Template: qtiMapResponse}
+		}
+		result.Responses = append(result.Responses, response)
+	case model.QuestionTypeMultipleChoice, model.QuestionTypeMultiplePoll:
+		response := qtiResponseDeclaration{Identifier: qtiResponse, Cardinality: "multiple", BaseType: "identifier"}
+		writeQTIChoices(&body, "choiceInteraction", ` maxChoices="0"`, in.AnswerOptions)
+		if in.Type != model.QuestionTypeMultiplePoll {
+			response.Correct, _ = qtiChoiceKey(in.AnswerOptions, points, true)
+			result.Processing = qtiMatchCorrect(points)
+		}
+		result.Responses = append(result.Responses, response)
+	case model.QuestionTypeFillTheGap:
+		fmt.Fprintf(&body, `<p><textEntryInteraction responseIdentifier="%s" expectedLength="20"/></p>`, qtiResponse)
+		result.Responses = append(result.Responses, qtiTextResponse(qtiResponse, in.TextMatch.Mode, slices.SafeMap(in.AnswerOptions, func(answerOption model.AnswerOption) string {
+			return answerOption.Answer
+		}), points))
+		result.Processing = &qtiResponseProcessing{Template: qtiMapResponse}
+	case model.QuestionTypeNumeric:
+		fmt.Fprintf(&body, `<p><textEntryInteraction responseIdentifier="%s" expectedLength="10"/></p>`, qtiResponse)
+		result.Responses = append(result.Responses, qtiResponseDeclaration{Identifier: qtiResponse, Cardinality: "single", BaseType: "float"})
+		result.Processing = qtiNumericProcessing(in.AnswerOptions, points)
+	case model.QuestionTypeOrdering:
+		response := qtiResponseDeclaration{Identifier: qtiResponse, Cardinality: "ordered", BaseType: "identifier", Correct: &qtiValues{}}
+		for _, answerOption := range in.GetOrderedAnswers() {
+			response.Correct.Values = append(response.Correct.Values, qtiChoiceID(answerOption.ID))
+		}
+		writeQTIChoices(&body, "orderInteraction", "", in.AnswerOptions)
+		result.Responses = append(result.Responses, response)
+		result.Processing = qtiMatchCorrect(points)
+	case model.QuestionTypeMatching:
+		result.Responses = append(result.Responses, qtiMatching(&body, in.AnswerOptions, points))
+		result.Processing = &qtiResponseProcessing{Template: qtiMapResponse}
+	case model.QuestionTypeCloze:
+		result.Responses, result.Processing = qtiCloze(&body, in, points)
+	case model.QuestionTypeOpenText:
+		// ответ проверяется вручную, правил начисления баллов нет
+		fmt.Fprintf(&body, `<extendedTextInteraction responseIdentifier="%s"/>`, qtiResponse)
+		result.Responses = append(result.Responses, qtiResponseDeclaration{Identifier: qtiResponse, Cardinality: "single", BaseType: "string"})
+	case model.QuestionTypeHotspot:
+		response, err := qtiHotspot(&body, in, images, points)
+		if err != nil {
+			return nil, err
+		}
+		result.Responses = append(result.Responses, response)
+		result.Processing = &qtiResponseProcessing{Template: qtiMapPoint}
+	default:
+		return nil, fmt.Errorf("unsupported question type %s", in.Type)
+	}
+
+	result.Body.Markup = body.String()
+	return result, nil
+}
+
+func writeQTIChoices(body *strings.Builder, interaction string, attributes string, answerOptions []model.AnswerOption) {
+	fmt.Fprintf(body, `<%s responseIdentifier="%s" shuffle="true"%s>`, interaction, qtiResponse, attributes)
+	for _, answerOption := range answerOptions {
+		fmt.Fprintf(body, `<simpleChoice identifier="%s">%s</simpleChoice>`, qtiChoiceID(answerOption.ID), html.EscapeString(answerOption.Answer))
+	}
+	fmt.Fprintf(body, `</%s>`, interaction)
+}
+
+// qtiChoiceKey с одним ответом засчитывается любой правильный вариант, с несколькими - только все сразу
+func qtiChoiceKey(answerOptions []model.AnswerOption, points float64, all bool) (*qtiValues, *qtiMapping) {
+	correct := &qtiValues{}
+	mapping := &qtiMapping{UpperBound: &points}
+	for _, answerOption := range answerOptions {
+		if !answerOption.IsCorrect {
+			continue
+		}
+		if all || len(correct.Values) == 0 {
+			correct.Values = append(correct.Values, qtiChoiceID(answerOption.ID))
+		}
+		mapping.Entries = append(mapping.Entries, qtiMapEntry{MapKey: qtiChoiceID(answerOption.ID), MappedValue: points})
+	}
+
+	return correct, mapping
+}
+
+func qtiTextResponse(identifier string, mode model.TextMatchMode, answers []string, points float64) qtiResponseDeclaration {
+	caseSensitive := mode == model.TextMatchModeExact
+	result := qtiResponseDeclaration{
+		Identifier:  identifier,
+		Cardinality: "single",
+		BaseType:    "string",
+		Correct:     &qtiValues{},
+		Mapping:     &qtiMapping{UpperBound: &points},
+	}
+	for i, answer := range answers {
+		if i == 0 {
+			result.Correct.Values = append(result.Correct.Values, answer)
+		}
+		result.Mapping.Entries = append(result.Mapping.Entries, qtiMapEntry{MapKey: answer, MappedValue: points, CaseSensitive: &caseSensitive})
+	}
+
+	return result
+}
+
+func qtiMatching(body *strings.Builder, answerOptions []model.AnswerOption, points float64) qtiResponseDeclaration {
+	result := qtiResponseDeclaration{
+		Identifier:  qtiResponse,
+		Cardinality: "multiple",
+		BaseType:    "directedPair",
+		Correct:     &qtiValues{},
+		Mapping:     &qtiMapping{UpperBound: &points},
+	}
+
+	pairPoints := points / float64(max(len(answerOptions), 1))
+	fmt.Fprintf(body, `<matchInteraction responseIdentifier="%s" shuffle="true" maxAssociations="%d"><simpleMatchSet>`, qtiResponse, len(answerOptions))
+	for _, answerOption := range answerOptions {
+		fmt.Fprintf(body, `<simpleAssociableChoice identifier="%s" matchMax="1">%s</simpleAssociableChoice>`, qtiChoiceID(answerOption.ID), html.EscapeString(answerOption.Answer))
+
+		pair := fmt.Sprintf("%s %s", qtiChoiceID(answerOption.ID), qtiPairID(answerOption.ID))
+		result.Correct.Values = append(result.Correct.Values, pair)
+		result.Mapping.Entries = append(result.Mapping.Entries, qtiMapEntry{MapKey: pair, MappedValue: pairPoints})
+	}
+	body.WriteString(`</simpleMatchSet><simpleMatchSet>`)
+	for _, answerOption := range answerOptions {
+		fmt.Fprintf(body, `<simpleAssociableChoice identifier="%s" matchMax="1">%s</simpleAssociableChoice>`, qtiPairID(answerOption.ID), html.EscapeString(answerOption.GetPair()))
+	}
+	body.WriteString(`</simpleMatchSet></matchInteraction>`)
+
+	return result
+}
+
+// qtiCloze каждый пропуск - отдельное поле ввода, баллы делятся между пропусками поровну
+func qtiCloze(body *strings.Builder, in *model.Question, points float64) ([]qtiResponseDeclaration, *qtiResponseProcessing) {
+	parts := model.ParseCloze(in.Text)
+	gapsCount := 0
+	for _, part := range parts {
+		if part.IsGap() {
+			gapsCount++
+		}
+	}
+
+	responses := make([]qtiResponseDeclaration, 0, gapsCount)
+	processing := strings.Builder{}
+	body.WriteString("<p>")
+	for _, part := range parts {
+		if !part.IsGap() {
+			body.WriteString(htmlText(part.Text))
+			continue
+		}
+
+		identifier := fmt.Sprintf("%s_%d", qtiResponse, len(responses)+1)
+		fmt.Fprintf(body, `<textEntryInteraction responseIdentifier="%s" expectedLength="15"/>`, identifier)
+		responses = append(responses, qtiTextResponse(identifier, in.TextMatch.Mode, part.Answers, points/float64(gapsCount)))
+		fmt.Fprintf(
+			&processing,
+			`<setOutcomeValue identifier="%s"><sum><variable identifier="%s"/><mapResponse identifier="%s"/></sum></setOutcomeValue>`,
+			qtiScore, qtiScore, identifier,
+		)
+	}
+	body.WriteString("</p>")
+
+	return responses, &qtiResponseProcessing{Markup: processing.String()}
+}
+
+// qtiHotspot игрок отмечает точку на изображении, баллы начисляются за попадание в любую из областей
+func qtiHotspot(body *strings.Builder, in *model.Question, images map[string][]byte, points float64) (qtiResponseDeclaration, error) {
+	if in.ImageID == nil {
+		return qtiResponseDeclaration{}, fmt.Errorf("hotspot question without image")
+	}
+
+	regions, config, err := hotspotRegions(in, images[*in.ImageID])
+	if err != nil {
+		return qtiResponseDeclaration{}, err
+	}
+
+	result := qtiResponseDeclaration{
+		Identifier:  qtiResponse,
+		Cardinality: "single",
+		BaseType:    "point",
+		AreaMapping: &qtiAreaMapping{UpperBound: points},
+	}
+	for _, region := range regions {
+		entry := qtiAreaMapEntry{Shape: "poly", MappedValue: points}
+		coords := make([]string, 0, len(region)*2)
+		for _, point := range region {
+			coords = append(coords, strconv.Itoa(int(point.X)), strconv.Itoa(int(point.Y)))
+		}
+		if region.IsRectangle() {
+			entry.Shape = "rect"
+			coords = []string{
+				strconv.Itoa(int(min(region[0].X, region[1].X))),
+				strconv.Itoa(int(min(region[0].Y, region[1].Y))),
+				strconv.Itoa(int(max(region[0].X, region[1].X))),
+				strconv.Itoa(int(max(region[0].Y, region[1].Y))),
+			}
+		}
+		entry.Coords = strings.Join(coords, ",")
+		result.AreaMapping.Entries = append(result.AreaMapping.Entries, entry)
+	}
+
+	fmt.Fprintf(
+		body,
+		`<selectPointInteraction responseIdentifier="%s" maxChoices="1"><object type="%s" data="%s" width="%d" height="%d"/></selectPointInteraction>`,
+		qtiResponse,
+		html.EscapeString(mime.TypeByExtension(path.Ext(*in.ImageID))),
+		qtiImageHref(*in.ImageID),
+		config.Width,
+		config.Height,
+	)
+
+	return result, nil
+}
+
+// qtiMatchCorrect баллы начисляются, только если ответ полностью совпадает с правильным
+func qtiMatchCorrect(points float64) *qtiResponseProcessing {
+	return &qtiResponseProcessing{Markup: fmt.Sprintf(
+		`<responseCondition><responseIf><match><variable identifier="%s"/><correct identifier="%s"/></match>`+
+			`<setOutcomeValue identifier="%s"><baseValue baseType="float">%s</baseValue></setOutcomeValue></responseIf></responseCondition>`,
+		qtiResponse, qtiResponse, qtiScore, formatFraction(points),
+	)}
+}
+
+// qtiNumericProcessing ответ засчитывается, если число попадает в допустимый диапазон
+func qtiNumericProcessing(answerOptions []model.AnswerOption, points float64) *qtiResponseProcessing {
+	markup := strings.Builder{}
+	for _, answerOption := range answerOptions {
+		if answerOption.MinValue == nil || answerOption.MaxValue == nil {
+			continue
+		}
+
+		fmt.Fprintf(
+			&markup,
+			`<responseCondition><responseIf><and>`+
+				`<gte><variable identifier="%s"/><baseValue baseType="float">%s</baseValue></gte>`+
+				`<lte><variable identifier="%s"/><baseValue baseType="float">%s</baseValue></lte>`+
+				`</and><setOutcomeValue identifier="%s"><baseValue baseType="float">%s</baseValue></setOutcomeValue></responseIf></responseCondition>`,
+			qtiResponse, strconv.FormatFloat(*answerOption.MinValue, 'f', -1, 64),
+			qtiResponse, strconv.FormatFloat(*answerOption.MaxValue, 'f', -1, 64),
+			qtiScore, formatFraction(points),
+		)
+	}
+
+	return &qtiResponseProcessing{Markup: markup.String()}
+}
+
+func writeXMLFile(archive *zip.Writer, name string, content any) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+
+	return xml.NewEncoder(writer).Encode(content)
+}
+
+func qtiChoiceID(id model.AnswerOptionID) string {
+	return fmt.Sprintf("CHOICE_%d", id)
+}
+
+func qtiPairID(id model.AnswerOptionID) string {
+	return fmt.Sprintf("PAIR_%d", id)
+}
+
+func qtiImagePath(imageID string) string {
+	return path.Join(qtiImagesDir, imageID)
+}
+
+// qtiImageHref ссылка на изображение из файла вопроса, файлы вопросов лежат в отдельной папке
+func qtiImageHref(imageID string) string {
+	return html.EscapeString((&url.URL{Path: "../" + qtiImagePath(imageID)}).String())
+}
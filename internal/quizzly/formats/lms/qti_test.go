@@ -0,0 +1,108 @@
+package lms
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"quizzly/internal/quizzly/model"
+	"strings"
+	"testing"
+)
+
+func TestWriteQTI(t *testing.T) {
+	questions, images := sampleQuestions(t)
+	id := model.AnswerOptionID(0)
+	for i := range questions {
+		for j := range questions[i].AnswerOptions {
+			id++
+			questions[i].AnswerOptions[j].ID = id
+		}
+	}
+
+	out := bytes.Buffer{}
+	if err := writeQTI(&out, specialText, questions, images); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	texts := make(map[string]string, len(archive.File))
+	for _, file := range archive.File {
+		if !strings.HasSuffix(file.Name, ".xml") {
+			continue
+		}
+
+		// разметка тела вопроса собирается вручную, поэтому каждый файл проверяется целиком
+		text, err := readXMLText(file)
+		if err != nil {
+			t.Fatalf("%s is not well-formed: %v", file.Name, err)
+		}
+		texts[file.Name] = text
+	}
+
+	t.Run("manifest references", func(t *testing.T) {
+		manifestFile, err := archive.Open(qtiManifest)
+		if err != nil {
+			t.Fatalf("no manifest: %v", err)
+		}
+		defer manifestFile.Close()
+
+		var manifest qtiManifestFile
+		if err = xml.NewDecoder(manifestFile).Decode(&manifest); err != nil {
+			t.Fatalf("invalid manifest: %v", err)
+		}
+		if len(manifest.Resources) != len(questions)+1 {
+			t.Errorf("expected %d resources, got %d", len(questions)+1, len(manifest.Resources))
+		}
+		for _, resource := range manifest.Resources {
+			for _, file := range resource.Files {
+				if _, err = archive.Open(file.Href); err != nil {
+					t.Errorf("%s is referenced but absent", file.Href)
+				}
+			}
+		}
+	})
+
+	t.Run("escaping", func(t *testing.T) {
+		if !strings.Contains(texts[qtiTestPath], specialText) {
+			t.Errorf("test title is not escaped: %q", texts[qtiTestPath])
+		}
+		if !strings.Contains(texts["items/item_1.xml"], specialText) {
+			t.Errorf("question text is not escaped: %q", texts["items/item_1.xml"])
+		}
+	})
+}
+
+// readXMLText проверяет, что файл разбирается до конца, и возвращает весь текст из него, включая атрибуты
+func readXMLText(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	result := strings.Builder{}
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return result.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch token := token.(type) {
+		case xml.CharData:
+			result.Write(token)
+		case xml.StartElement:
+			for _, attribute := range token.Attr {
+				result.WriteString(attribute.Value)
+			}
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package markdown
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	titlePrefix    = "# "
+	questionPrefix = "## "
+	commentStart   = "<!--"
+	commentEnd     = "-->"
+	escapeMarker   = `\`
+
+	// imagesPath изображения берутся только из уже загруженных в quizzly файлов
+	imagesPath = "/files/images/"
+
+	maxLineLength = 1 << 20
+)
+
+var (
+	imagePattern  = regexp.MustCompile(`^!\[[^\]]*\]\(<?([^)\s>]+)>?(?:\s+"[^"]*")?\)$`)
+	optionPattern = regexp.MustCompile(`^[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	// typePattern тип вопроса, если его нельзя определить по числу правильных ответов, например "<!-- type: poll -->"
+	typePattern = regexp.MustCompile(`^<!--\s*type:\s*(\S+)\s*-->$`)
+
+	// Types вопросы этих типов записываются списком с флажками без потери ответов
+	Types = []model.QuestionType{
+		model.QuestionTypeChoice,
+		model.QuestionTypeOneOfChoice,
+		model.QuestionTypeMultipleChoice,
+		model.QuestionTypePoll,
+		model.QuestionTypeMultiplePoll,
+	}
+)
+
+type (
+	// Document игра в формате Markdown: заголовок H1 - название игры, заголовок H2 - вопрос
+	Document struct {
+		Title     *string
+		Questions []model.Question
+	}
+
+	block struct {
+		line     int
+		question model.Question
+		hasImage bool
+		failed   bool
+	}
+
+	// UnsupportedError вопросы, которые нельзя записать в Markdown без потери ответов. Numbers - номера вопросов с единицы
+	UnsupportedError struct {
+		Numbers []int
+	}
+)
+
+// Parse разбирает игру в формате Markdown. Варианты ответа - список с флажками, "[x]" отмечает правильный ответ.
+// Если хотя бы один вопрос не разобран, возвращается formats.Report со всеми ошибками
+func Parse(in io.Reader) (*Document, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineLength)
+
+	var (
+		result  = &Document{}
+		report  formats.Report
+		current *block
+	)
+	// ошибка в строке вопроса не дает проверить вопрос целиком, чтобы не дублировать сообщения
+	fail := func(line int, message string) {
+		report = append(report, formats.LineError{Line: line, Message: message})
+		if current != nil {
+			current.failed = true
+		}
+	}
+	flush := func() {
+		if current == nil || current.failed {
+			current = nil
+			return
+		}
+
+		if err := validate(&current.question); err != nil {
+			report = append(report, formats.LineError{Line: current.line, Message: err.Error()})
+		} else {
+			result.Questions = append(result.Questions, current.question)
+		}
+		current = nil
+	}
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\uFEFF")
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if match := typePattern.FindStringSubmatch(trimmed); match != nil {
+			switch questionType := model.QuestionType(match[1]); {
+			case current == nil:
+				fail(lineNumber, "тип вопроса указан до первого вопроса")
+			case current.question.Type != "":
+				fail(lineNumber, "тип вопроса указан дважды")
+			case !slices.Contains(Types, questionType):
+				fail(lineNumber, fmt.Sprintf("тип вопроса %q не поддерживается", questionType))
+			default:
+				current.question.Type = questionType
+			}
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, commentStart) && strings.HasSuffix(trimmed, commentEnd) {
+			continue
+		}
+
+		if title, ok := heading(trimmed, titlePrefix); ok {
+			if result.Title != nil {
+				fail(lineNumber, "название игры указано дважды")
+				continue
+			}
+			if title == "" {
+				fail(lineNumber, "пустое название игры")
+				continue
+			}
+			result.Title = &title
+			continue
+		}
+
+		if text, ok := heading(trimmed, questionPrefix); ok {
+			flush()
+			current = &block{
+				line: lineNumber,
+				question: model.Question{
+					Text:   unescape(text),
+					Points: model.DefaultQuestionPoints,
+				},
+			}
+			continue
+		}
+
+		if current == nil {
+			fail(lineNumber, `текст вне вопроса, вопрос начинается с заголовка "## "`)
+			continue
+		}
+
+		if match := optionPattern.FindStringSubmatch(trimmed); match != nil {
+			answer := unescape(strings.TrimSpace(match[2]))
+			if answer == "" {
+				fail(lineNumber, "пустой вариант ответа")
+				continue
+			}
+			current.question.AnswerOptions = append(current.question.AnswerOptions, model.AnswerOption{
+				Answer:    answer,
+				IsCorrect: match[1] != " ",
+			})
+			continue
+		}
+
+		if len(current.question.AnswerOptions) > 0 {
+			fail(lineNumber, "текст вопроса должен идти до вариантов ответа")
+			continue
+		}
+
+		if match := imagePattern.FindStringSubmatch(trimmed); match != nil {
+			imageID, err := parseImage(match[1])
+			if err != nil {
+				fail(lineNumber, err.Error())
+				continue
+			}
+			if current.hasImage {
+				fail(lineNumber, "у вопроса может быть только одно изображение")
+				continue
+			}
+			current.question.ImageID = &imageID
+			current.hasImage = true
+			continue
+		}
+
+		current.question.Text = strings.TrimSpace(current.question.Text + "\n" + unescape(trimmed))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	if len(report) > 0 {
+		return nil, report
+	}
+
+	return result, nil
+}
+
+// heading текст заголовка, пустой заголовок из одних "#" тоже считается заголовком
+func heading(line string, prefix string) (string, bool) {
+	if line == strings.TrimSpace(prefix) {
+		return "", true
+	}
+	if !strings.HasPrefix(line, prefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(line, prefix)), true
+}
+
+// validate тип вопроса без явного указания определяется числом правильных ответов
+func validate(question *model.Question) error {
+	if question.Text == "" {
+		return errors.New("пустой текст вопроса")
+	}
+	if len(question.AnswerOptions) == 0 {
+		return errors.New("нет вариантов ответа")
+	}
+
+	correctCount := countCorrect(question)
+	if question.Type == "" {
+		question.Type = inferType(question)
+	}
+
+	switch question.Type {
+	case model.QuestionTypePoll, model.QuestionTypeMultiplePoll:
+		if correctCount > 0 {
+			return errors.New(`в опросе нет правильных ответов, отметьте все варианты как "[ ]"`)
+		}
+		if len(question.AnswerOptions) < 2 {
+			return errors.New("в опросе должно быть не меньше двух вариантов ответа")
+		}
+	default:
+		if correctCount == 0 {
+			return errors.New(`не отмечен правильный ответ, отметьте его как "[x]"`)
+		}
+		if question.Type == model.QuestionTypeChoice && correctCount > 1 {
+			return errors.New("в вопросе с одним ответом может быть только один правильный вариант")
+		}
+	}
+
+	return nil
+}
+
+// inferType тип, который получит вопрос без явного указания типа
+func inferType(question *model.Question) model.QuestionType {
+	switch countCorrect(question) {
+	case 0:
+		return ""
+	case 1:
+		return model.QuestionTypeChoice
+	default:
+		return model.QuestionTypeMultipleChoice
+	}
+}
+
+func countCorrect(question *model.Question) int {
+	result := 0
+	for _, item := range question.AnswerOptions {
+		if item.IsCorrect {
+			result++
+		}
+	}
+
+	return result
+}
+
+// parseImage имя изображения из ссылки вида "/files/images/<имя>", адрес сайта перед ссылкой не важен
+func parseImage(link string) (string, error) {
+	index := strings.Index(link, imagesPath)
+	if index < 0 {
+		return "", fmt.Errorf(`поддерживаются только изображения, загруженные в quizzly (ссылка вида "%s...")`, imagesPath)
+	}
+
+	name := link[index+len(imagesPath):]
+	if end := strings.IndexAny(name, "?#"); end >= 0 {
+		name = name[:end]
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return "", errors.New("неверная ссылка на изображение")
+	}
+
+	return name, nil
+}
+
+// Write выгружает игру в формате Markdown. Если в игре есть вопросы без вариантов для выбора, например с вводом ответа,
+// ничего не записывается и возвращается UnsupportedError: в формате их не выразить, а молча пропускать нельзя
+func Write(out io.Writer, title *string, questions []model.Question) error {
+	unsupported := &UnsupportedError{}
+	for i, question := range questions {
+		if !slices.Contains(Types, question.Type) {
+			unsupported.Numbers = append(unsupported.Numbers, i+1)
+		}
+	}
+	if len(unsupported.Numbers) > 0 {
+		return unsupported
+	}
+
+	writer := bufio.NewWriter(out)
+	if title != nil {
+		fmt.Fprintf(writer, "%s%s\n\n", titlePrefix, singleLine(*title))
+	}
+
+	for _, question := range questions {
+		lines := strings.Split(strings.TrimSpace(question.Text), "\n")
+		fmt.Fprintf(writer, "%s%s\n", questionPrefix, escapeInline(strings.TrimSpace(lines[0])))
+		if question.Type != inferType(&question) {
+			fmt.Fprintf(writer, "%s type: %s %s\n", commentStart, question.Type, commentEnd)
+		}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintln(writer, escape(line))
+			}
+		}
+		if question.ImageID != nil {
+			fmt.Fprintf(writer, "\n![](%s%s)\n", imagesPath, *question.ImageID)
+		}
+
+		fmt.Fprintln(writer)
+		for _, item := range question.AnswerOptions {
+			mark := " "
+			if item.IsCorrect {
+				mark = "x"
+			}
+			fmt.Fprintf(writer, "- [%s] %s\n", mark, escapeInline(singleLine(item.Answer)))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	return writer.Flush()
+}
+
+// escape строки текста вопроса, похожие на разметку формата, экранируются обратной косой чертой
+func escape(line string) string {
+	if strings.HasPrefix(line, "#") ||
+		strings.HasPrefix(line, escapeMarker) ||
+		strings.HasPrefix(line, commentStart) ||
+		optionPattern.MatchString(line) ||
+		imagePattern.MatchString(line) {
+		return escapeMarker + line
+	}
+
+	return line
+}
+
+// escapeInline в заголовке и варианте ответа разметка не распознается, экранируется только сам символ экранирования
+func escapeInline(text string) string {
+	if strings.HasPrefix(text, escapeMarker) {
+		return escapeMarker + text
+	}
+
+	return text
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("questions %v can not be written to markdown", e.Numbers)
+}
+
+func unescape(line string) string {
+	return strings.TrimPrefix(line, escapeMarker)
+}
+
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,279 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	questions := []model.Question{
+		{
+			Type:   model.QuestionTypeChoice,
+			Text:   "Столица Франции?",
+			Points: model.DefaultQuestionPoints,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "Париж", IsCorrect: true},
+				{Answer: "Лион"},
+			},
+		},
+		{
+			Type:    model.QuestionTypeMultipleChoice,
+			Text:    "Простые числа\nиз списка",
+			ImageID: structs.Pointer("primes.png"),
+			Points:  model.DefaultQuestionPoints,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "2", IsCorrect: true},
+				{Answer: "3", IsCorrect: true},
+				{Answer: "4"},
+			},
+		},
+		{
+			Type:   model.QuestionTypePoll,
+			Text:   "Нравится?",
+			Points: model.DefaultQuestionPoints,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "Да"},
+				{Answer: "Нет"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		in       string
+		title    *string
+		expected []model.Question
+	}{
+		{
+			name: "title, image, types and comments",
+			in: "\uFEFF# Игра\n\n<!-- комментарий -->\n## Столица Франции?\n\n- [x] Париж\n- [ ] Лион\n\n" +
+				"## Простые числа\nиз списка\n![схема](https://quizzly.example/files/images/primes.png \"подпись\")\n\n* [X] 2\n+ [x] 3\n- [ ] 4\n\n" +
+				"## Нравится?\n<!-- type: poll -->\n- [ ] Да\n- [ ] Нет\n",
+			title:    structs.Pointer("Игра"),
+			expected: questions,
+		},
+		{
+			name: "escaped markup in text and answers",
+			in:   "## \\\\ вопрос\n\\# не заголовок\n\\- [x] не вариант\n\n- [x] \\\\ ответ\n- [ ] \\# ответ\n",
+			expected: []model.Question{{
+				Type:   model.QuestionTypeChoice,
+				Text:   "\\ вопрос\n# не заголовок\n- [x] не вариант",
+				Points: model.DefaultQuestionPoints,
+				AnswerOptions: []model.AnswerOption{
+					{Answer: "\\ ответ", IsCorrect: true},
+					{Answer: "# ответ"},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := Parse(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(document.Title, tt.title) {
+				t.Errorf("title: expected %v, got %v", tt.title, document.Title)
+			}
+			if !reflect.DeepEqual(document.Questions, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, document.Questions)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	title := "Игра # с решеткой"
+	questions := []model.Question{
+		{
+			Type:   model.QuestionTypeOneOfChoice,
+			Text:   "# Вопрос с решеткой\n- [x] строка как вариант\n\\ строка с обратной чертой",
+			Points: model.DefaultQuestionPoints,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "\\ один", IsCorrect: true},
+				{Answer: "два", IsCorrect: true},
+				{Answer: "три"},
+			},
+		},
+		{
+			Type:    model.QuestionTypeMultipleChoice,
+			Text:    "Выберите один",
+			ImageID: structs.Pointer("image.png"),
+			Points:  model.DefaultQuestionPoints,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "да", IsCorrect: true},
+				{Answer: "нет"},
+			},
+		},
+		{
+			Type:   model.QuestionTypeMultiplePoll,
+			Text:   "Что нравится?",
+			Points: model.DefaultQuestionPoints,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "Чай"},
+				{Answer: "Кофе"},
+			},
+		},
+	}
+
+	out := bytes.Buffer{}
+	if err := Write(&out, &title, questions); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	document, err := Parse(&out)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if document.Title == nil || *document.Title != title {
+		t.Errorf("title: expected %q, got %v", title, document.Title)
+	}
+	if !reflect.DeepEqual(document.Questions, questions) {
+		t.Errorf("expected %+v, got %+v", questions, document.Questions)
+	}
+}
+
+func TestWriteUnsupported(t *testing.T) {
+	questions := []model.Question{
+		{Type: model.QuestionTypeChoice, Text: "Выбор", AnswerOptions: []model.AnswerOption{{Answer: "да", IsCorrect: true}}},
+		{Type: model.QuestionTypeFillTheGap, Text: "Ввод", AnswerOptions: []model.AnswerOption{{Answer: "да", IsCorrect: true}}},
+		{Type: model.QuestionTypeNumeric, Text: "Число"},
+	}
+
+	out := bytes.Buffer{}
+	err := Write(&out, nil, questions)
+
+	var unsupportedErr *UnsupportedError
+	if !errors.As(err, &unsupportedErr) {
+		t.Fatalf("expected unsupported error, got %v", err)
+	}
+	if !reflect.DeepEqual(unsupportedErr.Numbers, []int{2, 3}) {
+		t.Errorf("expected questions [2 3], got %v", unsupportedErr.Numbers)
+	}
+	if out.Len() > 0 {
+		t.Errorf("nothing should be written, got %q", out.String())
+	}
+}
+
+func TestParseReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected formats.Report
+	}{
+		{
+			name:     "text outside question",
+			in:       "Просто текст\n## Вопрос\n- [x] a\n",
+			expected: formats.Report{{Line: 1, Message: `текст вне вопроса, вопрос начинается с заголовка "## "`}},
+		},
+		{
+			name:     "two titles",
+			in:       "# Первое\n# Второе\n## Вопрос\n- [x] a\n",
+			expected: formats.Report{{Line: 2, Message: "название игры указано дважды"}},
+		},
+		{
+			name:     "empty title",
+			in:       "#\n## Вопрос\n- [x] a\n",
+			expected: formats.Report{{Line: 1, Message: "пустое название игры"}},
+		},
+		{
+			name:     "empty question text",
+			in:       "##\n- [x] a\n",
+			expected: formats.Report{{Line: 1, Message: "пустой текст вопроса"}},
+		},
+		{
+			name:     "no answers",
+			in:       "## Вопрос\n",
+			expected: formats.Report{{Line: 1, Message: "нет вариантов ответа"}},
+		},
+		{
+			name:     "empty answer",
+			in:       "## Вопрос\n- [x] \\\n- [ ] b\n",
+			expected: formats.Report{{Line: 2, Message: "пустой вариант ответа"}},
+		},
+		{
+			name:     "no correct answer",
+			in:       "## Вопрос\n- [ ] a\n- [ ] b\n",
+			expected: formats.Report{{Line: 1, Message: `не отмечен правильный ответ, отметьте его как "[x]"`}},
+		},
+		{
+			name:     "text after answers",
+			in:       "## Вопрос\n- [x] a\nеще текст\n",
+			expected: formats.Report{{Line: 3, Message: "текст вопроса должен идти до вариантов ответа"}},
+		},
+		{
+			name:     "foreign image",
+			in:       "## Вопрос\n![](https://example.com/image.png)\n- [x] a\n",
+			expected: formats.Report{{Line: 2, Message: `поддерживаются только изображения, загруженные в quizzly (ссылка вида "/files/images/...")`}},
+		},
+		{
+			name:     "broken image link",
+			in:       "## Вопрос\n![](/files/images/a/b.png)\n- [x] a\n",
+			expected: formats.Report{{Line: 2, Message: "неверная ссылка на изображение"}},
+		},
+		{
+			name:     "two images",
+			in:       "## Вопрос\n![](/files/images/a.png)\n![](/files/images/b.png)\n- [x] a\n",
+			expected: formats.Report{{Line: 3, Message: "у вопроса может быть только одно изображение"}},
+		},
+		{
+			name:     "type before question",
+			in:       "<!-- type: poll -->\n## Вопрос\n- [x] a\n- [ ] b\n",
+			expected: formats.Report{{Line: 1, Message: "тип вопроса указан до первого вопроса"}},
+		},
+		{
+			name:     "two types",
+			in:       "## Вопрос\n<!-- type: poll -->\n<!-- type: multiple_poll -->\n- [ ] a\n- [ ] b\n",
+			expected: formats.Report{{Line: 3, Message: "тип вопроса указан дважды"}},
+		},
+		{
+			name:     "unsupported type",
+			in:       "## Вопрос\n<!-- type: numeric -->\n- [x] 1\n",
+			expected: formats.Report{{Line: 2, Message: `тип вопроса "numeric" не поддерживается`}},
+		},
+		{
+			name:     "poll with correct answer",
+			in:       "## Вопрос\n<!-- type: poll -->\n- [x] a\n- [ ] b\n",
+			expected: formats.Report{{Line: 1, Message: `в опросе нет правильных ответов, отметьте все варианты как "[ ]"`}},
+		},
+		{
+			name:     "poll with one option",
+			in:       "## Вопрос\n<!-- type: multiple_poll -->\n- [ ] a\n",
+			expected: formats.Report{{Line: 1, Message: "в опросе должно быть не меньше двух вариантов ответа"}},
+		},
+		{
+			name:     "several correct in choice",
+			in:       "## Вопрос\n<!-- type: choice -->\n- [x] a\n- [x] b\n",
+			expected: formats.Report{{Line: 1, Message: "в вопросе с одним ответом может быть только один правильный вариант"}},
+		},
+		{
+			name: "all errors at once",
+			in:   "## Хороший\n- [x] a\n\n## Без ответа\n- [ ] a\n\n## Плохой\n- [x] a\nтекст\n",
+			expected: formats.Report{
+				{Line: 4, Message: `не отмечен правильный ответ, отметьте его как "[x]"`},
+				{Line: 9, Message: "текст вопроса должен идти до вариантов ответа"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.in))
+
+			var report formats.Report
+			if !errors.As(err, &report) {
+				t.Fatalf("expected report, got %v", err)
+			}
+			if !reflect.DeepEqual(report, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, report)
+			}
+		})
+	}
+}
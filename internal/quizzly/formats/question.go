@@ -0,0 +1,403 @@
+package formats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/helper"
+	"quizzly/pkg/structs"
+	"quizzly/pkg/structs/collections/slices"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	NumericToleranceAbsolute = "absolute"
+	NumericTolerancePercent  = "percent"
+)
+
+// QuestionTypes типы вопросов, которые можно создать из формы или загрузить из файла
+var QuestionTypes = []model.QuestionType{
+	model.QuestionTypeChoice,
+	model.QuestionTypeOneOfChoice,
+	model.QuestionTypeMultipleChoice,
+	model.QuestionTypeFillTheGap,
+	model.QuestionTypeOrdering,
+	model.QuestionTypeMatching,
+	model.QuestionTypeNumeric,
+	model.QuestionTypeCloze,
+	model.QuestionTypePoll,
+	model.QuestionTypeMultiplePoll,
+	model.QuestionTypeOpenText,
+	model.QuestionTypeHotspot,
+}
+
+type (
+	// QuestionIn вопрос в том виде, в котором его вводит автор: значения берутся из полей формы или ячеек таблицы
+	QuestionIn struct {
+		Type      model.QuestionType
+		Text      string
+		ImageID   *string
+		Points    string
+		TimeLimit string
+		Tag       string
+		// Options тексты вариантов ответа, в вопросах на упорядочивание - в правильном порядке,
+		// в вопросах с областями - подписи областей, в вопросах с развернутым ответом - образец ответа
+		Options []string
+		// Correct отметки правильных вариантов в вопросах с выбором ответа, по одной на вариант
+		Correct []bool
+		// Pairs правые части пар в вопросах на сопоставление, по одной на вариант
+		Pairs []string
+		// Regions области в вопросах с областями в формате model.ParseHotspotRegion
+		Regions   []string
+		Numeric   NumericIn
+		TextMatch TextMatchIn
+	}
+
+	// NumericIn диапазон Min..Max имеет приоритет над точным значением с погрешностью
+	NumericIn struct {
+		Value         string
+		Tolerance     string
+		ToleranceType string
+		Min           string
+		Max           string
+	}
+
+	// TextMatchIn доля совпадения Ratio в процентах имеет приоритет над числом опечаток Distance
+	TextMatchIn struct {
+		Mode     string
+		Distance string
+		Ratio    string
+	}
+)
+
+// ConvertQuestion проверяет вопрос и собирает из него модель. Правила одни для формы создания вопроса и для импорта,
+// поэтому ошибки сформулированы для автора игры
+func ConvertQuestion(in *QuestionIn) (*model.Question, error) {
+	if !slices.ContainsValue(QuestionTypes, in.Type) {
+		return nil, fmt.Errorf("неизвестный тип вопроса %q", in.Type)
+	}
+
+	question := &model.Question{
+		Text:    strings.TrimSpace(in.Text),
+		Type:    in.Type,
+		ImageID: in.ImageID,
+		Points:  model.DefaultQuestionPoints,
+	}
+	if question.Text == "" {
+		return nil, errors.New("пустой текст вопроса")
+	}
+	if strings.TrimSpace(in.Tag) != "" {
+		question.Tag = structs.Pointer(strings.TrimSpace(in.Tag))
+	}
+	if strings.TrimSpace(in.Points) != "" {
+		points, err := strconv.Atoi(strings.TrimSpace(in.Points))
+		if err != nil || points <= 0 {
+			return nil, errors.New("баллы должны быть целым положительным числом")
+		}
+		question.Points = points
+	}
+
+	timeLimit, err := helper.ParseSeconds(in.TimeLimit)
+	if err != nil {
+		return nil, errors.New("время на ответ должно быть целым положительным числом секунд")
+	}
+	question.TimeLimit = timeLimit
+
+	question.AnswerOptions, err = convertAnswerOptions(in)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateAnswerOptionsCount(question); err != nil {
+		return nil, err
+	}
+
+	if question.Type == model.QuestionTypeFillTheGap || question.Type == model.QuestionTypeCloze {
+		question.TextMatch, err = convertTextMatch(&in.TextMatch, question.AnswerOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return question, nil
+}
+
+// convertAnswerOptions пустые варианты пропускаются, как незаполненные строки формы
+func convertAnswerOptions(in *QuestionIn) ([]model.AnswerOption, error) {
+	switch in.Type {
+	case model.QuestionTypeChoice, model.QuestionTypeOneOfChoice, model.QuestionTypeMultipleChoice:
+		return convertChoiceOptions(in)
+	case model.QuestionTypeFillTheGap:
+		return convertOptions(in.Options, func(text string, _ int) model.AnswerOption {
+			return model.AnswerOption{Answer: text, IsCorrect: true}
+		}), nil
+	case model.QuestionTypePoll, model.QuestionTypeMultiplePoll:
+		return convertOptions(in.Options, func(text string, _ int) model.AnswerOption {
+			return model.AnswerOption{Answer: text}
+		}), nil
+	case model.QuestionTypeOrdering:
+		return convertOptions(in.Options, func(text string, position int) model.AnswerOption {
+			return model.AnswerOption{Answer: text, IsCorrect: true, Position: structs.Pointer(position)}
+		}), nil
+	case model.QuestionTypeCloze:
+		return convertClozeOptions(in.Text), nil
+	case model.QuestionTypeMatching:
+		return convertMatchingOptions(in.Options, in.Pairs), nil
+	case model.QuestionTypeNumeric:
+		answerOption, err := convertNumericOption(&in.Numeric)
+		if err != nil {
+			return nil, err
+		}
+		return []model.AnswerOption{*answerOption}, nil
+	case model.QuestionTypeHotspot:
+		if in.ImageID == nil {
+			return nil, errors.New("для вопроса с областями нужно изображение")
+		}
+		return convertHotspotOptions(in.Options, in.Regions)
+	case model.QuestionTypeOpenText:
+		// единственный вариант хранит образец ответа для проверяющего, он может быть пустым
+		var sample string
+		if len(in.Options) > 0 {
+			sample = strings.TrimSpace(in.Options[0])
+		}
+		return []model.AnswerOption{{Answer: sample}}, nil
+	}
+
+	return nil, nil
+}
+
+// convertOptions position - номер варианта среди непустых, начиная с единицы
+func convertOptions(texts []string, convert func(text string, position int) model.AnswerOption) []model.AnswerOption {
+	result := make([]model.AnswerOption, 0, len(texts))
+	for _, text := range texts {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		result = append(result, convert(text, len(result)+1))
+	}
+
+	return result
+}
+
+func convertChoiceOptions(in *QuestionIn) ([]model.AnswerOption, error) {
+	result := make([]model.AnswerOption, 0, len(in.Options))
+	correctCount := 0
+	for i, text := range in.Options {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		isCorrect := i < len(in.Correct) && in.Correct[i]
+		if isCorrect {
+			correctCount++
+		}
+		result = append(result, model.AnswerOption{Answer: text, IsCorrect: isCorrect})
+	}
+
+	if len(result) > 0 && correctCount == 0 {
+		return nil, errors.New("не указан правильный ответ")
+	}
+	if in.Type == model.QuestionTypeChoice && correctCount > 1 {
+		return nil, errors.New("в вопросе с одним ответом может быть только один правильный вариант")
+	}
+
+	return result, nil
+}
+
+// convertClozeOptions каждый допустимый ответ пропуска сохраняется с номером пропуска в качестве позиции
+func convertClozeOptions(text string) []model.AnswerOption {
+	result := make([]model.AnswerOption, 0, 4)
+	position := 0
+	for _, part := range model.ParseCloze(text) {
+		if !part.IsGap() {
+			continue
+		}
+
+		position++
+		for _, answer := range part.Answers {
+			result = append(result, model.AnswerOption{
+				Answer:    answer,
+				IsCorrect: true,
+				Position:  structs.Pointer(position),
+			})
+		}
+	}
+
+	return result
+}
+
+// convertMatchingOptions пары, у которых не заполнена одна из частей, не сохраняются
+func convertMatchingOptions(texts []string, pairs []string) []model.AnswerOption {
+	result := make([]model.AnswerOption, 0, len(texts))
+	for i, text := range texts {
+		if i >= len(pairs) {
+			break
+		}
+
+		text = strings.TrimSpace(text)
+		pair := strings.TrimSpace(pairs[i])
+		if text == "" || pair == "" {
+			continue
+		}
+
+		result = append(result, model.AnswerOption{
+			Answer:    text,
+			IsCorrect: true,
+			Pair:      &pair,
+		})
+	}
+
+	return result
+}
+
+// convertNumericOption любой способ задания ответа сводится к границам допустимого значения
+func convertNumericOption(in *NumericIn) (*model.AnswerOption, error) {
+	if strings.TrimSpace(in.Min) != "" || strings.TrimSpace(in.Max) != "" {
+		minValue, err := helper.ParseNumber(in.Min)
+		if err != nil {
+			return nil, fmt.Errorf("неверное число %q", in.Min)
+		}
+		maxValue, err := helper.ParseNumber(in.Max)
+		if err != nil {
+			return nil, fmt.Errorf("неверное число %q", in.Max)
+		}
+		if minValue > maxValue {
+			return nil, errors.New("нижняя граница диапазона больше верхней")
+		}
+
+		return &model.AnswerOption{
+			Answer:    fmt.Sprintf("от %s до %s", formatNumber(minValue), formatNumber(maxValue)),
+			IsCorrect: true,
+			MinValue:  &minValue,
+			MaxValue:  &maxValue,
+		}, nil
+	}
+
+	value, err := helper.ParseNumber(in.Value)
+	if err != nil {
+		return nil, fmt.Errorf("неверное число %q", in.Value)
+	}
+
+	tolerance := 0.0
+	if strings.TrimSpace(in.Tolerance) != "" {
+		tolerance, err = helper.ParseNumber(in.Tolerance)
+		if err != nil || tolerance < 0 {
+			return nil, errors.New("погрешность должна быть неотрицательным числом")
+		}
+	}
+
+	answer := formatNumber(value)
+	delta := tolerance
+	switch in.ToleranceType {
+	case NumericTolerancePercent:
+		delta = math.Abs(value) * tolerance / 100
+		if tolerance > 0 {
+			answer = fmt.Sprintf("%s ± %s%%", answer, formatNumber(tolerance))
+		}
+	case NumericToleranceAbsolute, "":
+		if tolerance > 0 {
+			answer = fmt.Sprintf("%s ± %s", answer, formatNumber(tolerance))
+		}
+	default:
+		return nil, fmt.Errorf("неизвестный тип погрешности %q", in.ToleranceType)
+	}
+
+	return &model.AnswerOption{
+		Answer:    answer,
+		IsCorrect: true,
+		MinValue:  structs.Pointer(value - delta),
+		MaxValue:  structs.Pointer(value + delta),
+	}, nil
+}
+
+// convertHotspotOptions каждая отмеченная область считается правильной, подпись области необязательна
+func convertHotspotOptions(labels []string, regions []string) ([]model.AnswerOption, error) {
+	result := make([]model.AnswerOption, 0, len(regions))
+	for i, region := range regions {
+		parsed, err := model.ParseHotspotRegion(region)
+		if err != nil {
+			return nil, fmt.Errorf("неверная область %q", region)
+		}
+
+		label := fmt.Sprintf("Область %d", i+1)
+		if i < len(labels) && strings.TrimSpace(labels[i]) != "" {
+			label = strings.TrimSpace(labels[i])
+		}
+
+		result = append(result, model.AnswerOption{
+			Answer:    label,
+			IsCorrect: true,
+			Region:    structs.Pointer(parsed.String()),
+		})
+	}
+
+	return result, nil
+}
+
+func validateAnswerOptionsCount(question *model.Question) error {
+	switch question.Type {
+	case model.QuestionTypeOpenText:
+	case model.QuestionTypeCloze:
+		if len(question.AnswerOptions) == 0 {
+			return errors.New("в тексте вопроса нет пропусков в фигурных скобках")
+		}
+	case model.QuestionTypeHotspot:
+		if len(question.AnswerOptions) == 0 {
+			return errors.New("на изображении не отмечено ни одной области")
+		}
+	case model.QuestionTypePoll, model.QuestionTypeMultiplePoll:
+		if len(question.AnswerOptions) < 2 {
+			return errors.New("в опросе должно быть не меньше двух вариантов ответа")
+		}
+	default:
+		if len(question.AnswerOptions) == 0 {
+			return errors.New("нет вариантов ответа")
+		}
+	}
+
+	return nil
+}
+
+// convertTextMatch пустой режим означает нечеткое совпадение с настройками по умолчанию
+func convertTextMatch(in *TextMatchIn, answerOptions []model.AnswerOption) (model.TextMatchSettings, error) {
+	result := model.TextMatchSettings{Mode: model.TextMatchMode(strings.TrimSpace(in.Mode))}
+
+	switch result.Mode {
+	case model.TextMatchModeExact, model.TextMatchModeCaseInsensitive:
+	case model.TextMatchModeRegex:
+		for _, answerOption := range answerOptions {
+			if _, err := regexp.Compile(answerOption.Answer); err != nil {
+				return result, fmt.Errorf("неверное регулярное выражение %q", answerOption.Answer)
+			}
+		}
+	case model.TextMatchModeFuzzy, "":
+		result.Mode = model.TextMatchModeFuzzy
+		if strings.TrimSpace(in.Ratio) != "" {
+			ratio, err := helper.ParseNumber(in.Ratio)
+			if err != nil || ratio <= 0 || ratio > 100 {
+				return result, errors.New("доля совпадения должна быть от 0 до 100%")
+			}
+			result.Ratio = structs.Pointer(ratio / 100)
+		} else if strings.TrimSpace(in.Distance) != "" {
+			distance, err := strconv.Atoi(strings.TrimSpace(in.Distance))
+			if err != nil || distance < 0 {
+				return result, errors.New("число опечаток должно быть целым неотрицательным числом")
+			}
+			result.Distance = &distance
+		}
+	default:
+		return result, fmt.Errorf("неизвестный режим проверки ответа %q", in.Mode)
+	}
+
+	return result, nil
+}
+
+// formatNumber дробная часть отделяется запятой, как принято в русском тексте
+func formatNumber(in float64) string {
+	return strings.ReplaceAll(strconv.FormatFloat(in, 'f', -1, 64), ".", ",")
+}
@@ -0,0 +1,30 @@
+package formats
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// LineError ошибка в конкретной строке импортируемого файла
+	LineError struct {
+		Line    int
+		Message string
+	}
+
+	// Report все ошибки файла сразу, чтобы автор мог исправить их за один раз
+	Report []LineError
+)
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("строка %d: %s", e.Line, e.Message)
+}
+
+func (r Report) Error() string {
+	lines := make([]string, 0, len(r))
+	for _, item := range r {
+		lines = append(lines, item.Error())
+	}
+
+	return strings.Join(lines, "; ")
+}
@@ -0,0 +1,69 @@
+package sheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+)
+
+// utf8BOM нужен, чтобы Excel открывал выгрузку в правильной кодировке
+var utf8BOM = []byte("\xEF\xBB\xBF")
+
+// ParseCSV разделитель определяется по заголовку: Excel с русской локалью сохраняет CSV через точку с запятой
+func ParseCSV(in io.Reader) ([]model.Question, error) {
+	data, err := formats.ReadAll(in, maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	rows := make([]row, 0, 16)
+	for {
+		cells, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, formats.Report{{Line: parseErr.Line, Message: parseErr.Err.Error()}}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, row{line: line, cells: cells})
+	}
+
+	return parseRows(rows)
+}
+
+func WriteCSV(out io.Writer, questions []model.Question) error {
+	if _, err := out.Write(utf8BOM); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(out)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for i := range questions {
+		if err := writer.Write(convertQuestion(&questions[i])); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
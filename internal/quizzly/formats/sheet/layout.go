@@ -0,0 +1,344 @@
+package sheet
+
+import (
+	"errors"
+	"fmt"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"quizzly/pkg/structs/collections/slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Первая строка таблицы - заголовок с названиями колонок, порядок колонок может быть любым.
+// Обязательны только type и text, остальные колонки можно не добавлять
+const (
+	columnType      = "type"
+	columnText      = "text"
+	columnOptions   = "options"
+	columnCorrect   = "correct"
+	columnImage     = "image"
+	columnPoints    = "points"
+	columnTimeLimit = "time_limit"
+	columnTag       = "tag"
+	columnTextMatch = "text_match"
+)
+
+const (
+	// optionSeparator варианты ответа записываются в одной ячейке, каждый с новой строки
+	optionSeparator = "\n"
+	// pairSeparator разделяет части пары в вопросах на сопоставление и подпись с областью в вопросах с областями
+	pairSeparator = "->"
+	// rangeSeparator разделяет границы допустимого значения в числовых вопросах
+	rangeSeparator = ".."
+	// fuzzySeparator отделяет от режима нечеткого совпадения число опечаток или долю совпадения в процентах
+	fuzzySeparator = ":"
+)
+
+var columns = []string{
+	columnType,
+	columnText,
+	columnOptions,
+	columnCorrect,
+	columnImage,
+	columnPoints,
+	columnTimeLimit,
+	columnTag,
+	columnTextMatch,
+}
+
+type (
+	row struct {
+		line  int
+		cells []string
+	}
+
+	// record ячейки строки по названиям колонок
+	record map[string]string
+)
+
+// parseRows ошибки собираются по всем строкам, вопросы возвращаются, только если ошибок нет
+func parseRows(rows []row) ([]model.Question, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := slices.SafeMap(rows[0].cells, func(cell string) string {
+		return strings.ToLower(strings.TrimSpace(cell))
+	})
+
+	var report formats.Report
+	for _, column := range []string{columnType, columnText} {
+		if !slices.ContainsValue(header, column) {
+			report = append(report, formats.LineError{Line: rows[0].line, Message: fmt.Sprintf("нет колонки %q", column)})
+		}
+	}
+	if len(report) > 0 {
+		return nil, report
+	}
+
+	result := make([]model.Question, 0, len(rows)-1)
+	for _, item := range rows[1:] {
+		in := make(record, len(header))
+		for i, cell := range item.cells {
+			if i < len(header) && header[i] != "" {
+				in[header[i]] = strings.TrimSpace(strings.ReplaceAll(cell, "\r\n", "\n"))
+			}
+		}
+		if in.isEmpty() {
+			continue
+		}
+
+		question, err := convertRecord(in)
+		if err != nil {
+			report = append(report, formats.LineError{Line: item.line, Message: err.Error()})
+			continue
+		}
+		result = append(result, *question)
+	}
+
+	if len(report) > 0 {
+		return nil, report
+	}
+
+	return result, nil
+}
+
+func (r record) isEmpty() bool {
+	for _, value := range r {
+		if value != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r record) options() []string {
+	result := make([]string, 0, 4)
+	for _, option := range strings.Split(r[columnOptions], optionSeparator) {
+		option = strings.TrimSpace(option)
+		if option != "" {
+			result = append(result, option)
+		}
+	}
+
+	return result
+}
+
+// convertRecord ячейки разбираются в общий вид, проверка та же, что и в форме создания вопроса
+func convertRecord(in record) (*model.Question, error) {
+	question := &formats.QuestionIn{
+		Type:      model.QuestionType(in[columnType]),
+		Text:      in[columnText],
+		Points:    in[columnPoints],
+		TimeLimit: in[columnTimeLimit],
+		Tag:       in[columnTag],
+		Options:   in.options(),
+	}
+	if in[columnImage] != "" {
+		question.ImageID = structs.Pointer(in[columnImage])
+	}
+
+	var err error
+	switch question.Type {
+	case model.QuestionTypeChoice, model.QuestionTypeOneOfChoice, model.QuestionTypeMultipleChoice:
+		question.Correct, err = parseCorrect(in[columnCorrect], len(question.Options))
+	case model.QuestionTypePoll, model.QuestionTypeMultiplePoll:
+		if in[columnCorrect] != "" {
+			err = errors.New("в опросе нет правильных ответов")
+		}
+	case model.QuestionTypeFillTheGap, model.QuestionTypeCloze:
+		question.TextMatch = parseTextMatch(in[columnTextMatch])
+	case model.QuestionTypeMatching:
+		question.Options, question.Pairs, err = parsePairs(question.Options)
+	case model.QuestionTypeNumeric:
+		question.Numeric, err = parseNumeric(question.Options)
+	case model.QuestionTypeHotspot:
+		question.Options, question.Regions = parseRegions(question.Options)
+	case model.QuestionTypeOpenText:
+		// образец ответа может занимать несколько строк ячейки
+		question.Options = []string{strings.Join(question.Options, optionSeparator)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return formats.ConvertQuestion(question)
+}
+
+// parseCorrect правильные варианты указываются номерами через запятую, нумерация с единицы
+func parseCorrect(in string, count int) ([]bool, error) {
+	result := make([]bool, count)
+	for _, value := range strings.FieldsFunc(in, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' '
+	}) {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 || number > count {
+			return nil, fmt.Errorf("нет варианта ответа с номером %q", value)
+		}
+		result[number-1] = true
+	}
+
+	return result, nil
+}
+
+func parsePairs(options []string) ([]string, []string, error) {
+	texts := make([]string, 0, len(options))
+	pairs := make([]string, 0, len(options))
+	for _, option := range options {
+		left, right, ok := cutPair(option)
+		if !ok {
+			return nil, nil, fmt.Errorf("пара %q должна быть записана как \"левая часть %s правая часть\"", option, pairSeparator)
+		}
+
+		texts = append(texts, left)
+		pairs = append(pairs, right)
+	}
+
+	return texts, pairs, nil
+}
+
+// parseNumeric допустимое значение записывается числом или диапазоном "min..max"
+func parseNumeric(options []string) (formats.NumericIn, error) {
+	if len(options) != 1 {
+		return formats.NumericIn{}, errors.New("в числовом вопросе должен быть один ответ")
+	}
+
+	if minValue, maxValue, isRange := strings.Cut(options[0], rangeSeparator); isRange {
+		return formats.NumericIn{Min: minValue, Max: maxValue}, nil
+	}
+
+	return formats.NumericIn{Value: options[0]}, nil
+}
+
+// parseRegions область записывается как "подпись -> x1 y1,x2 y2,...", координаты в долях от размеров изображения.
+// Без подписи область получит номер
+func parseRegions(options []string) ([]string, []string) {
+	labels := make([]string, 0, len(options))
+	regions := make([]string, 0, len(options))
+	for _, option := range options {
+		label, region, ok := cutPair(option)
+		if !ok {
+			label, region = "", option
+		}
+
+		labels = append(labels, label)
+		regions = append(regions, region)
+	}
+
+	return labels, regions
+}
+
+// parseTextMatch режим записывается как "fuzzy:2" (число опечаток) или "fuzzy:80%" (доля совпадения)
+func parseTextMatch(in string) formats.TextMatchIn {
+	mode, parameter, _ := strings.Cut(in, fuzzySeparator)
+	parameter = strings.TrimSpace(parameter)
+	if ratio, ok := strings.CutSuffix(parameter, "%"); ok {
+		return formats.TextMatchIn{Mode: mode, Ratio: ratio}
+	}
+
+	return formats.TextMatchIn{Mode: mode, Distance: parameter}
+}
+
+// convertQuestion обратное преобразование: строка, из которой convertRecord получит тот же вопрос.
+// Переходы между вопросами не сохраняются, так как при импорте у вопросов будут новые идентификаторы
+func convertQuestion(in *model.Question) []string {
+	out := record{
+		columnType:      string(in.Type),
+		columnText:      in.Text,
+		columnImage:     valueOrEmpty(in.ImageID),
+		columnPoints:    strconv.Itoa(in.Points),
+		columnTag:       valueOrEmpty(in.Tag),
+		columnTextMatch: formatTextMatch(in),
+	}
+	if in.TimeLimit != nil {
+		out[columnTimeLimit] = strconv.Itoa(int(*in.TimeLimit / time.Second))
+	}
+
+	options := make([]string, 0, len(in.AnswerOptions))
+	correct := make([]string, 0, 1)
+	switch in.Type {
+	case model.QuestionTypeCloze:
+	case model.QuestionTypeOrdering:
+		for _, answerOption := range in.GetOrderedAnswers() {
+			options = append(options, answerOption.Answer)
+		}
+	case model.QuestionTypeMatching:
+		for _, answerOption := range in.AnswerOptions {
+			options = append(options, fmt.Sprintf("%s %s %s", answerOption.Answer, pairSeparator, answerOption.GetPair()))
+		}
+	case model.QuestionTypeHotspot:
+		for _, answerOption := range in.AnswerOptions {
+			options = append(options, fmt.Sprintf("%s %s %s", answerOption.Answer, pairSeparator, valueOrEmpty(answerOption.Region)))
+		}
+	case model.QuestionTypeNumeric:
+		for _, answerOption := range in.AnswerOptions {
+			options = append(options, formatRange(answerOption))
+		}
+	default:
+		for i, answerOption := range in.AnswerOptions {
+			options = append(options, answerOption.Answer)
+			if answerOption.IsCorrect && (in.Type == model.QuestionTypeChoice ||
+				in.Type == model.QuestionTypeOneOfChoice ||
+				in.Type == model.QuestionTypeMultipleChoice) {
+				correct = append(correct, strconv.Itoa(i+1))
+			}
+		}
+	}
+	out[columnOptions] = strings.Join(options, optionSeparator)
+	out[columnCorrect] = strings.Join(correct, ", ")
+
+	result := make([]string, 0, len(columns))
+	for _, column := range columns {
+		result = append(result, out[column])
+	}
+
+	return result
+}
+
+func formatTextMatch(in *model.Question) string {
+	if in.Type != model.QuestionTypeFillTheGap && in.Type != model.QuestionTypeCloze {
+		return ""
+	}
+
+	switch {
+	case in.TextMatch.Mode == model.TextMatchModeFuzzy && in.TextMatch.Ratio != nil:
+		return fmt.Sprintf("%s%s%s%%", in.TextMatch.Mode, fuzzySeparator, strconv.FormatFloat(*in.TextMatch.Ratio*100, 'f', -1, 64))
+	case in.TextMatch.Mode == model.TextMatchModeFuzzy && in.TextMatch.Distance != nil:
+		return fmt.Sprintf("%s%s%d", in.TextMatch.Mode, fuzzySeparator, *in.TextMatch.Distance)
+	default:
+		return string(in.TextMatch.Mode)
+	}
+}
+
+func formatRange(in model.AnswerOption) string {
+	if in.MinValue == nil || in.MaxValue == nil {
+		return in.Answer
+	}
+	if *in.MinValue == *in.MaxValue {
+		return formatNumber(*in.MinValue)
+	}
+
+	return formatNumber(*in.MinValue) + rangeSeparator + formatNumber(*in.MaxValue)
+}
+
+func formatNumber(in float64) string {
+	return strconv.FormatFloat(in, 'f', -1, 64)
+}
+
+func cutPair(in string) (string, string, bool) {
+	left, right, ok := strings.Cut(in, pairSeparator)
+	left, right = strings.TrimSpace(left), strings.TrimSpace(right)
+	return left, right, ok && left != "" && right != ""
+}
+
+func valueOrEmpty(in *string) string {
+	if in == nil {
+		return ""
+	}
+
+	return *in
+}
@@ -0,0 +1,26 @@
+package sheet
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"quizzly/internal/quizzly/model"
+)
+
+// maxFileSize размер файла с таблицей
+const maxFileSize = 10 << 20
+
+// zipSignature с этих байт начинается xlsx, как и любой zip-архив
+var zipSignature = []byte("PK\x03\x04")
+
+// Parse разбирает таблицу с вопросами в формате xlsx или CSV, формат определяется по содержимому файла.
+// Если хотя бы одна строка не прошла проверку, возвращается formats.Report с ошибками всех строк
+func Parse(in io.Reader) ([]model.Question, error) {
+	reader := bufio.NewReader(in)
+	signature, _ := reader.Peek(len(zipSignature))
+	if bytes.Equal(signature, zipSignature) {
+		return ParseXLSX(reader)
+	}
+
+	return ParseCSV(reader)
+}
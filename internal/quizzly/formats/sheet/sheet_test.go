@@ -0,0 +1,380 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleCSV = `type,text,options,correct,image,points,time_limit,tag,text_match
+choice,"Столица Франции?","Париж
+Лион",1,,2,30,Города,
+multiple_choice,"Простые числа","2
+3
+4","1, 2",,,,,
+fill_the_gap,"Столица Италии?","Рим
+Roma",,,,,,fuzzy:80%
+cloze,"Столица {Франции} - {Париж|Paris}",,,,,,,exact
+ordering,"По возрастанию","1
+2
+3",,,,,,
+matching,"Сопоставьте","Франция -> Париж
+Италия -> Рим",,,,,,
+numeric,"Сколько?",1..3,,,,,,
+numeric,"Пи?","3,14",,,,,,
+poll,"Нравится?","Да
+Нет",,,,,,
+open_text,"Расскажите о себе","Образец
+ответа",,,,,,
+hotspot,"Где столица?","Москва -> 0.1 0.1,0.2 0.2
+0.3 0.3,0.4 0.4",,map.png,,,,
+`
+
+func TestParseCSV(t *testing.T) {
+	questions, err := Parse(strings.NewReader(sampleCSV))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(questions) != 11 {
+		t.Fatalf("expected 11 questions, got %d", len(questions))
+	}
+
+	tests := []struct {
+		name     string
+		question model.Question
+		answers  []string
+		correct  []bool
+	}{
+		{name: "choice", question: questions[0], answers: []string{"Париж", "Лион"}, correct: []bool{true, false}},
+		{name: "multiple choice", question: questions[1], answers: []string{"2", "3", "4"}, correct: []bool{true, true, false}},
+		{name: "fill the gap", question: questions[2], answers: []string{"Рим", "Roma"}, correct: []bool{true, true}},
+		{name: "cloze", question: questions[3], answers: []string{"Франции", "Париж", "Paris"}, correct: []bool{true, true, true}},
+		{name: "matching", question: questions[5], answers: []string{"Франция", "Италия"}, correct: []bool{true, true}},
+		{name: "numeric range", question: questions[6], answers: []string{"от 1 до 3"}, correct: []bool{true}},
+		{name: "numeric value", question: questions[7], answers: []string{"3,14"}, correct: []bool{true}},
+		{name: "poll", question: questions[8], answers: []string{"Да", "Нет"}, correct: []bool{false, false}},
+		{name: "open text", question: questions[9], answers: []string{"Образец\nответа"}, correct: []bool{false}},
+		{name: "hotspot", question: questions[10], answers: []string{"Москва", "Область 2"}, correct: []bool{true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := make([]string, 0, len(tt.question.AnswerOptions))
+			correct := make([]bool, 0, len(tt.question.AnswerOptions))
+			for _, item := range tt.question.AnswerOptions {
+				answers = append(answers, item.Answer)
+				correct = append(correct, item.IsCorrect)
+			}
+			if !reflect.DeepEqual(answers, tt.answers) {
+				t.Errorf("answers: expected %q, got %q", tt.answers, answers)
+			}
+			if !reflect.DeepEqual(correct, tt.correct) {
+				t.Errorf("correct: expected %v, got %v", tt.correct, correct)
+			}
+		})
+	}
+
+	if questions[0].Points != 2 || questions[0].TimeLimit == nil || questions[0].Tag == nil || *questions[0].Tag != "Города" {
+		t.Errorf("choice settings are not parsed: %+v", questions[0])
+	}
+	if ratio := questions[2].TextMatch.Ratio; questions[2].TextMatch.Mode != model.TextMatchModeFuzzy || ratio == nil || *ratio != 0.8 {
+		t.Errorf("fill the gap text match is not parsed: %+v", questions[2].TextMatch)
+	}
+	if pair := questions[5].AnswerOptions[1].GetPair(); pair != "Рим" {
+		t.Errorf("matching pair: expected %q, got %q", "Рим", pair)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	questions, err := ParseCSV(strings.NewReader(sampleCSV))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		write func(out *bytes.Buffer) error
+	}{
+		{name: "csv", write: func(out *bytes.Buffer) error { return WriteCSV(out, questions) }},
+		{name: "xlsx", write: func(out *bytes.Buffer) error { return WriteXLSX(out, questions) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.Buffer{}
+			if err := tt.write(&out); err != nil {
+				t.Fatalf("write error: %v", err)
+			}
+
+			result, err := Parse(&out)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			if !reflect.DeepEqual(result, questions) {
+				t.Errorf("expected %+v, got %+v", questions, result)
+			}
+		})
+	}
+}
+
+func TestParseCSVSeparators(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "comma", in: "type,text,options,correct\nchoice,\"Да, или нет?\",\"Да\nНет\",1\n"},
+		{name: "semicolon", in: "type;text;options;correct\nchoice;Да, или нет?;\"Да\nНет\";1\n"},
+		{name: "bom and crlf", in: "\xEF\xBB\xBFtype;text;options;correct\r\nchoice;Да, или нет?;\"Да\r\nНет\";1\r\n"},
+		{name: "header case and order", in: "Correct,Options,TEXT,Type\n1,\"Да\nНет\",\"Да, или нет?\",choice\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions, err := Parse(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(questions) != 1 {
+				t.Fatalf("expected 1 question, got %d", len(questions))
+			}
+			if questions[0].Text != "Да, или нет?" {
+				t.Errorf("text: expected %q, got %q", "Да, или нет?", questions[0].Text)
+			}
+			if len(questions[0].AnswerOptions) != 2 || questions[0].AnswerOptions[1].Answer != "Нет" {
+				t.Errorf("unexpected answer options %+v", questions[0].AnswerOptions)
+			}
+		})
+	}
+}
+
+func TestParseReport(t *testing.T) {
+	const header = "type,text,options,correct,image,points,time_limit,tag,text_match\n"
+	tests := []struct {
+		name     string
+		in       string
+		expected formats.Report
+	}{
+		{
+			name:     "missing columns",
+			in:       "text,options\nВопрос,a\n",
+			expected: formats.Report{{Line: 1, Message: `нет колонки "type"`}},
+		},
+		{
+			name:     "unknown type",
+			in:       header + "essay,Вопрос,,,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: `неизвестный тип вопроса "essay"`}},
+		},
+		{
+			name:     "empty text",
+			in:       header + "choice,,a,1,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "пустой текст вопроса"}},
+		},
+		{
+			name:     "invalid points",
+			in:       header + "choice,Вопрос,a,1,,-1,,,\n",
+			expected: formats.Report{{Line: 2, Message: "баллы должны быть целым положительным числом"}},
+		},
+		{
+			name:     "invalid time limit",
+			in:       header + "choice,Вопрос,a,1,,,abc,,\n",
+			expected: formats.Report{{Line: 2, Message: "время на ответ должно быть целым положительным числом секунд"}},
+		},
+		{
+			name:     "no options",
+			in:       header + "ordering,Вопрос,,,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "нет вариантов ответа"}},
+		},
+		{
+			name:     "unknown correct number",
+			in:       header + "choice,Вопрос,\"a\nb\",3,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: `нет варианта ответа с номером "3"`}},
+		},
+		{
+			name:     "no correct answer",
+			in:       header + "choice,Вопрос,\"a\nb\",,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "не указан правильный ответ"}},
+		},
+		{
+			name:     "several correct in choice",
+			in:       header + "choice,Вопрос,\"a\nb\",\"1,2\",,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "в вопросе с одним ответом может быть только один правильный вариант"}},
+		},
+		{
+			name:     "poll with correct answer",
+			in:       header + "poll,Вопрос,\"a\nb\",1,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "в опросе нет правильных ответов"}},
+		},
+		{
+			name:     "poll with one option",
+			in:       header + "poll,Вопрос,a,,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "в опросе должно быть не меньше двух вариантов ответа"}},
+		},
+		{
+			name:     "invalid pair",
+			in:       header + "matching,Вопрос,a b,,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: `пара "a b" должна быть записана как "левая часть -> правая часть"`}},
+		},
+		{
+			name:     "several numeric answers",
+			in:       header + "numeric,Вопрос,\"1\n2\",,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "в числовом вопросе должен быть один ответ"}},
+		},
+		{
+			name:     "invalid number",
+			in:       header + "numeric,Вопрос,abc,,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: `неверное число "abc"`}},
+		},
+		{
+			name:     "inverted range",
+			in:       header + "numeric,Вопрос,3..1,,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "нижняя граница диапазона больше верхней"}},
+		},
+		{
+			name:     "cloze without gaps",
+			in:       header + "cloze,Вопрос,,,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "в тексте вопроса нет пропусков в фигурных скобках"}},
+		},
+		{
+			name:     "hotspot without image",
+			in:       header + "hotspot,Вопрос,\"0.1 0.1,0.2 0.2\",,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: "для вопроса с областями нужно изображение"}},
+		},
+		{
+			name:     "invalid region",
+			in:       header + "hotspot,Вопрос,a -> b,,map.png,,,,\n",
+			expected: formats.Report{{Line: 2, Message: `неверная область "b"`}},
+		},
+		{
+			name:     "unknown text match mode",
+			in:       header + "fill_the_gap,Вопрос,a,,,,,,similar\n",
+			expected: formats.Report{{Line: 2, Message: `неизвестный режим проверки ответа "similar"`}},
+		},
+		{
+			name:     "invalid ratio",
+			in:       header + "fill_the_gap,Вопрос,a,,,,,,fuzzy:150%\n",
+			expected: formats.Report{{Line: 2, Message: "доля совпадения должна быть от 0 до 100%"}},
+		},
+		{
+			name:     "invalid regex",
+			in:       header + "fill_the_gap,Вопрос,a(,,,,,,regex\n",
+			expected: formats.Report{{Line: 2, Message: `неверное регулярное выражение "a("`}},
+		},
+		{
+			name: "all rows at once",
+			in:   header + "choice,Хороший,a,1,,,,,\n\nchoice,,a,1,,,,,\npoll,\"Многострочный\nтекст\",a,,,,,,\n",
+			expected: formats.Report{
+				{Line: 4, Message: "пустой текст вопроса"},
+				{Line: 5, Message: "в опросе должно быть не меньше двух вариантов ответа"},
+			},
+		},
+		{
+			name:     "broken quotes",
+			in:       header + "choice,\"Вопрос,a,1,,,,,\n",
+			expected: formats.Report{{Line: 2, Message: `extraneous or missing " in quoted-field`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.in))
+
+			var report formats.Report
+			if !errors.As(err, &report) {
+				t.Fatalf("expected report, got %v", err)
+			}
+			if !reflect.DeepEqual(report, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, report)
+			}
+		})
+	}
+}
+
+func TestParseXLSXColumnOutOfRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		expected formats.Report
+	}{
+		{
+			name:     "far column",
+			ref:      "ZZZZZZZ2",
+			expected: formats.Report{{Line: 2, Message: "ячейка ZZZZZZZ2 за пределами таблицы, колонок может быть не больше 9"}},
+		},
+		{
+			name:     "first column after template",
+			ref:      "J2",
+			expected: formats.Report{{Line: 2, Message: "ячейка J2 за пределами таблицы, колонок может быть не больше 9"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseXLSX(bytes.NewReader(newXLSX(t, tt.ref)))
+
+			var report formats.Report
+			if !errors.As(err, &report) {
+				t.Fatalf("expected report, got %v", err)
+			}
+			if !reflect.DeepEqual(report, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, report)
+			}
+		})
+	}
+}
+
+func TestParseTooLarge(t *testing.T) {
+	in := bytes.Repeat([]byte("a"), maxFileSize+1)
+	if _, err := Parse(bytes.NewReader(in)); !errors.Is(err, formats.ErrTooLarge) {
+		t.Errorf("expected %v, got %v", formats.ErrTooLarge, err)
+	}
+}
+
+// newXLSX книга с заголовком и одной ячейкой по адресу ref во второй строке
+func newXLSX(t *testing.T, ref string) []byte {
+	t.Helper()
+
+	out := bytes.Buffer{}
+	if err := WriteXLSX(&out, nil); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+	source, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+
+	result := bytes.Buffer{}
+	archive := zip.NewWriter(&result)
+	for _, file := range source.File {
+		content := bytes.Buffer{}
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("read error: %v", err)
+		}
+		_, err = content.ReadFrom(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("read error: %v", err)
+		}
+
+		data := content.Bytes()
+		if file.Name == xlsxSheetPath {
+			cell := `<row r="2"><c r="` + ref + `" t="inlineStr"><is><t>x</t></is></c></row></sheetData>`
+			data = bytes.Replace(data, []byte("</sheetData>"), []byte(cell), 1)
+		}
+
+		writer, err := archive.Create(file.Name)
+		if err != nil {
+			t.Fatalf("write error: %v", err)
+		}
+		if _, err = writer.Write(data); err != nil {
+			t.Fatalf("write error: %v", err)
+		}
+	}
+	if err = archive.Close(); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	return result.Bytes()
+}
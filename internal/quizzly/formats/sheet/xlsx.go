@@ -0,0 +1,294 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxWorkbookPath      = "xl/workbook.xml"
+	xlsxWorkbookRelsPath  = "xl/_rels/workbook.xml.rels"
+	xlsxSharedStringsPath = "xl/sharedStrings.xml"
+	xlsxSheetPath         = "xl/worksheets/sheet1.xml"
+
+	xlsxCellTypeShared = "s"
+	xlsxCellTypeInline = "inlineStr"
+
+	// maxXMLSize размер каждой распакованной части книги, сжатые данные могут быть во много раз меньше
+	maxXMLSize = 50 << 20
+
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Вопросы" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+)
+
+type (
+	xlsxWorkbookFile struct {
+		Sheets []struct {
+			RelationID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+
+	xlsxRelationsFile struct {
+		Relations []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	xlsxSharedStringsFile struct {
+		Items []xlsxText `xml:"si"`
+	}
+
+	// xlsxText строка целиком или набор фрагментов с разным форматированием
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+
+	xlsxSheetFile struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// ParseXLSX вопросы читаются с первого листа книги
+func ParseXLSX(in io.Reader) ([]model.Question, error) {
+	data, err := formats.ReadAll(in, maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	sheetPath, err := findFirstSheet(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings xlsxSharedStringsFile
+	if err = readXML(archive, xlsxSharedStringsPath, &sharedStrings); err != nil && !errors.Is(err, errFileNotFound) {
+		return nil, err
+	}
+
+	var sheet xlsxSheetFile
+	if err = readXML(archive, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	var report formats.Report
+	rows := make([]row, 0, len(sheet.Rows))
+	for i, sheetRow := range sheet.Rows {
+		line := sheetRow.Number
+		if line == 0 {
+			line = i + 1
+		}
+
+		cells := make([]string, 0, len(sheetRow.Cells))
+		for _, cell := range sheetRow.Cells {
+			// пустые ячейки в файле не хранятся, номер колонки берется из адреса ячейки
+			column, ok := columnIndex(cell.Ref)
+			if cell.Ref != "" && !ok {
+				report = append(report, formats.LineError{
+					Line:    line,
+					Message: fmt.Sprintf("ячейка %s за пределами таблицы, колонок может быть не больше %d", cell.Ref, len(columns)),
+				})
+				continue
+			}
+			if column >= len(cells) {
+				cells = append(cells, make([]string, column-len(cells))...)
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case xlsxCellTypeShared:
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("invalid shared string index %q", cell.Value)
+				}
+				value = sharedStrings.Items[index].String()
+			case xlsxCellTypeInline:
+				value = cell.Inline.String()
+			}
+			cells = append(cells, value)
+		}
+
+		rows = append(rows, row{line: line, cells: cells})
+	}
+	if len(report) > 0 {
+		return nil, report
+	}
+
+	return parseRows(rows)
+}
+
+// WriteXLSX книга с одним листом, строки записываются прямо в ячейки без таблицы общих строк
+func WriteXLSX(out io.Writer, questions []model.Question) error {
+	sheet := bytes.Buffer{}
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeRow := func(number int, cells []string) error {
+		fmt.Fprintf(&sheet, `<row r="%d">`, number)
+		for i, value := range cells {
+			if value == "" {
+				continue
+			}
+
+			fmt.Fprintf(&sheet, `<c r="%s%d" t="%s"><is><t xml:space="preserve">`, columnName(i), number, xlsxCellTypeInline)
+			if err := xml.EscapeText(&sheet, []byte(value)); err != nil {
+				return err
+			}
+			sheet.WriteString(`</t></is></c>`)
+		}
+		sheet.WriteString(`</row>`)
+		return nil
+	}
+
+	if err := writeRow(1, columns); err != nil {
+		return err
+	}
+	for i := range questions {
+		if err := writeRow(i+2, convertQuestion(&questions[i])); err != nil {
+			return err
+		}
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	archive := zip.NewWriter(out)
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{name: "[Content_Types].xml", content: []byte(xlsxContentTypes)},
+		{name: "_rels/.rels", content: []byte(xlsxRootRels)},
+		{name: xlsxWorkbookPath, content: []byte(xlsxWorkbook)},
+		{name: xlsxWorkbookRelsPath, content: []byte(xlsxWorkbookRels)},
+		{name: xlsxSheetPath, content: sheet.Bytes()},
+	} {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err = writer.Write(file.content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+var errFileNotFound = errors.New("file not found in xlsx")
+
+// findFirstSheet путь к первому листу берется из связей книги, имя файла листа может быть любым
+func findFirstSheet(archive *zip.Reader) (string, error) {
+	var workbook xlsxWorkbookFile
+	if err := readXML(archive, xlsxWorkbookPath, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("xlsx has no sheets")
+	}
+
+	var relations xlsxRelationsFile
+	if err := readXML(archive, xlsxWorkbookRelsPath, &relations); err != nil {
+		return "", err
+	}
+	for _, relation := range relations.Relations {
+		if relation.ID != workbook.Sheets[0].RelationID {
+			continue
+		}
+		if strings.HasPrefix(relation.Target, "/") {
+			return strings.TrimPrefix(relation.Target, "/"), nil
+		}
+
+		return path.Join(path.Dir(xlsxWorkbookPath), relation.Target), nil
+	}
+
+	return "", errors.New("xlsx sheet relation not found")
+}
+
+func readXML(archive *zip.Reader, name string, out any) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errFileNotFound, name)
+	}
+	defer file.Close()
+
+	return xml.NewDecoder(formats.LimitReader(file, maxXMLSize)).Decode(out)
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	builder := strings.Builder{}
+	for _, run := range t.Runs {
+		builder.WriteString(run.Text)
+	}
+
+	return builder.String()
+}
+
+// columnIndex номер колонки с нуля по адресу ячейки вида "AB12". Адрес берется из файла,
+// поэтому колонки дальше последней колонки шаблона не принимаются
+func columnIndex(ref string) (int, bool) {
+	result := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		result = result*26 + int(r-'A'+1)
+		if result > len(columns) {
+			return 0, false
+		}
+	}
+
+	return result - 1, result > 0
+}
+
+func columnName(index int) string {
+	result := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		result = string(rune('A'+(index-1)%26)) + result
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/logger"
+	"time"
+)
+
+const gameScheduleInterval = 10 * time.Second
+
+type GameScheduleJob struct {
+	games contracts.GameUsecase
+	log   logger.Logger
+}
+
+// NewGameScheduleJob запускает и завершает игры по времени, указанному автором
+func NewGameScheduleJob(games contracts.GameUsecase, log logger.Logger) *GameScheduleJob {
+	return &GameScheduleJob{games: games, log: log}
+}
+
+func (j *GameScheduleJob) Name() string {
+	return "game_schedule"
+}
+
+func (j *GameScheduleJob) Perform(ctx context.Context) error {
+	now := time.Now()
+	games, err := j.games.GetScheduled(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, game := range games {
+		// ошибка в одной игре не мешает остальным, она повторится на следующей итерации
+		if err := j.apply(ctx, &game, now); err != nil {
+			j.log.Error(
+				"failed to apply game schedule",
+				err,
+				logger.Field{
+					Key:   "game_id",
+					Value: game.ID.String(),
+				},
+			)
+		}
+	}
+
+	return nil
+}
+
+func (j *GameScheduleJob) DetermineInterval(_ context.Context) (*time.Duration, error) {
+	interval := gameScheduleInterval
+	return &interval, nil
+}
+
+// apply если время завершения тоже прошло, игра сразу завершается после запуска
+func (j *GameScheduleJob) apply(ctx context.Context, game *model.Game, now time.Time) error {
+	if game.Status == model.GameStatusCreated {
+		err := j.games.Start(ctx, game.ID)
+		if isPermanentStartError(err) {
+			// без правок автора игра не запустится, поэтому расписание снимается, чтобы не повторять попытки
+			game.OpensAt = nil
+			if updateErr := j.games.Update(ctx, game); updateErr != nil {
+				return errors.Join(err, updateErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if game.ClosesAt == nil || game.ClosesAt.After(now) {
+		return nil
+	}
+
+	return j.games.Finish(ctx, game.ID)
+}
+
+func isPermanentStartError(err error) bool {
+	return errors.Is(err, contracts.ErrEmptyQuestions) ||
+		errors.Is(err, contracts.ErrQuestionFlowCycle) ||
+		errors.Is(err, contracts.ErrQuestionFlowUnreachable) ||
+		errors.Is(err, contracts.ErrQuestionFlowNotSupported)
+}
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+	"quizzly/internal/quizzly/contracts"
+	"time"
+)
+
+const sessionSweeperInterval = time.Minute
+
+type SessionSweeperJob struct {
+	sessions contracts.SessionUsecase
+}
+
+// NewSessionSweeperJob закрывает прохождения, брошенные игроками, по таймауту из настроек игры
+func NewSessionSweeperJob(sessions contracts.SessionUsecase) *SessionSweeperJob {
+	return &SessionSweeperJob{sessions: sessions}
+}
+
+func (j *SessionSweeperJob) Name() string {
+	return "session_sweeper"
+}
+
+func (j *SessionSweeperJob) Perform(ctx context.Context) error {
+	return j.sessions.AbandonStale(ctx, time.Now())
+}
+
+func (j *SessionSweeperJob) DetermineInterval(_ context.Context) (*time.Duration, error) {
+	interval := sessionSweeperInterval
+	return &interval, nil
+}
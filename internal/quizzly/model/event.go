@@ -0,0 +1,27 @@
+package model
+
+import "github.com/google/uuid"
+
+const (
+	GameEventStarted            GameEventType = "game_started"
+	GameEventFinished           GameEventType = "game_finished"
+	GameEventPlayerJoined       GameEventType = "player_joined"
+	GameEventAnswerAccepted     GameEventType = "answer_accepted"
+	GameEventAnswerReviewed     GameEventType = "answer_reviewed"
+	GameEventSessionFinished    GameEventType = "session_finished"
+	GameEventSessionAbandoned   GameEventType = "session_abandoned"
+	GameEventLiveQuestionOpened GameEventType = "live_question_opened"
+	GameEventLiveQuestionClosed GameEventType = "live_question_closed"
+)
+
+type (
+	GameEventType string
+
+	GameEvent struct {
+		Type       GameEventType
+		GameID     uuid.UUID
+		PlayerID   *uuid.UUID
+		QuestionID *uuid.UUID
+		IsCorrect  *bool
+	}
+)
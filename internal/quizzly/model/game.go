@@ -14,11 +14,25 @@ const (
 
 const (
 	GameTypeAsync GameType = "async"
+	GameTypeLive  GameType = "live" // Ведущий сам переключает вопросы, все игроки видят один и тот же вопрос
 )
 
 const (
 	SessionStatusStarted  SessionStatus = "started"
 	SessionStatusFinished SessionStatus = "finished"
+	// SessionStatusAbandoned игрок давно не отвечал, прохождение закрыто фоновой задачей
+	SessionStatusAbandoned SessionStatus = "abandoned"
+)
+
+const (
+	LeaderboardOrderScore          LeaderboardOrder = "score"
+	LeaderboardOrderCorrectAnswers LeaderboardOrder = "correct_answers"
+)
+
+const (
+	AttemptPolicyLast  AttemptPolicy = "last"
+	AttemptPolicyBest  AttemptPolicy = "best"
+	AttemptPolicyFirst AttemptPolicy = "first"
 )
 
 type (
@@ -26,12 +40,15 @@ type (
 	GameType   string
 
 	Game struct {
-		ID        uuid.UUID
-		AuthorID  uuid.UUID
-		Status    GameStatus
-		Type      GameType
-		Title     *string
-		Settings  GameSettings
+		ID       uuid.UUID
+		AuthorID uuid.UUID
+		Status   GameStatus
+		Type     GameType
+		Title    *string
+		Settings GameSettings
+		// OpensAt и ClosesAt время автоматического запуска и завершения игры, nil - игрой управляет автор
+		OpensAt   *time.Time
+		ClosesAt  *time.Time
 		CreatedAt time.Time
 	}
 
@@ -41,6 +58,33 @@ type (
 		ShuffleAnswers   bool
 		ShowRightAnswers bool
 		InputCustomName  bool
+		// QuestionTimeLimit время на ответ по умолчанию для вопросов без собственного ограничения
+		QuestionTimeLimit *time.Duration
+		// SpeedBonus за быстрый ответ начисляются дополнительные баллы
+		SpeedBonus bool
+		// NegativeMarking за неправильный ответ баллы вычитаются
+		NegativeMarking bool
+		// PartialCredit в вопросах с несколькими ответами баллы начисляются за каждый правильный вариант
+		PartialCredit bool
+		// HideLeaderboard таблица лидеров не показывается игрокам
+		HideLeaderboard bool
+		// QuestionPoolSize сколько вопросов выпадает каждому игроку, nil - все вопросы игры
+		QuestionPoolSize *int
+		// StratifyPool вопросы выбираются из каждого тега пропорционально их количеству
+		StratifyPool bool
+		// MaxAttempts сколько раз игрок может пройти игру, nil - без ограничений
+		MaxAttempts *int
+		// AttemptPolicy какая из завершенных попыток учитывается в результатах и таблице лидеров
+		AttemptPolicy AttemptPolicy
+		// InactivityTimeout через сколько после последнего ответа прохождение считается брошенным, nil - никогда
+		InactivityTimeout *time.Duration
+	}
+
+	GameLiveState struct {
+		GameID            uuid.UUID
+		CurrentQuestionID *uuid.UUID
+		QuestionOpenedAt  *time.Time
+		QuestionClosedAt  *time.Time
 	}
 
 	GameStatistics struct {
@@ -50,12 +94,19 @@ type (
 	}
 
 	SessionStatus string
+	AttemptPolicy string
 
 	Session struct {
-		ID        int64
-		PlayerID  uuid.UUID
-		GameID    uuid.UUID
-		Status    SessionStatus
+		ID       int64
+		PlayerID uuid.UUID
+		GameID   uuid.UUID
+		Status   SessionStatus
+		// QuestionIDs вопросы, выпавшие игроку, nil - все вопросы игры
+		QuestionIDs []uuid.UUID
+		// ShuffleSeed основа для перемешивания вопросов и вариантов ответа, не меняется в течение прохождения
+		ShuffleSeed int64
+		// Attempt номер попытки, при перезапуске игры создается новая сессия
+		Attempt   int
 		CreatedAt time.Time
 	}
 
@@ -65,18 +116,49 @@ type (
 	}
 
 	SessionItem struct {
-		ID         int64
-		SessionID  int64
-		QuestionID uuid.UUID
-		Answers    []string
-		IsCorrect  *bool
-		AnsweredAt *time.Time
-		CreatedAt  time.Time
+		ID           int64
+		SessionID    int64
+		QuestionID   uuid.UUID
+		QuestionType QuestionType
+		Answers      []string
+		IsCorrect    *bool
+		AnsweredAt   *time.Time
+		CreatedAt    time.Time
+		// ReviewComment комментарий автора игры к ответу, проверяемому вручную
+		ReviewComment *string
+		// Score баллы за ответ, nil для опросов и непроверенных ответов
+		Score *float64
+	}
+
+	// ReviewItem ответ игрока, ожидающий проверки автором игры
+	ReviewItem struct {
+		SessionItem
+		PlayerID uuid.UUID
 	}
 
 	SessionStatistics struct {
 		QuestionsCount      int64
 		CorrectAnswersCount int64
+		// PendingReviewCount ответы, которые автор игры еще не проверил
+		PendingReviewCount int64
+		Score              float64
+		MaxScore           float64
+		// Attempt попытка, по которой посчитан результат
+		Attempt       int
+		AttemptsCount int
+		// AttemptsLeft сколько раз игрок еще может перезапустить игру, nil - без ограничений
+		AttemptsLeft *int
+	}
+
+	LeaderboardOrder string
+
+	// LeaderboardEntry результат игрока, завершившего игру
+	LeaderboardEntry struct {
+		PlayerID            uuid.UUID
+		Score               float64
+		CorrectAnswersCount int64
+		// Duration время от начала прохождения до последнего ответа
+		Duration time.Duration
 	}
 )
 
@@ -85,15 +167,57 @@ func (s *ExtendedSession) CompletionRate() int64 {
 		return 0
 	}
 
-	total := int64(len(s.Items))
+	total := int64(0)
 	correctAnswers := int64(0)
 	for _, item := range s.Items {
+		if !item.IsScored() {
+			continue
+		}
+
+		total++
 		if item.IsCorrect == nil || !*item.IsCorrect {
 			continue
 		}
 
 		correctAnswers++
 	}
+	if total == 0 {
+		return 0
+	}
 
 	return (correctAnswers * 100) / total
 }
+
+// Score сумма баллов за все ответы
+func (s *ExtendedSession) Score() float64 {
+	total := float64(0)
+	for _, item := range s.Items {
+		if item.Score != nil {
+			total += *item.Score
+		}
+	}
+
+	return total
+}
+
+// IsScored ответы на опросы не учитываются в статистике
+func (i SessionItem) IsScored() bool {
+	return !i.QuestionType.IsPoll()
+}
+
+// IsPendingReview ответ дан, но автор игры его еще не проверил
+func (i SessionItem) IsPendingReview() bool {
+	return i.QuestionType.IsManuallyGraded() && i.AnsweredAt != nil && i.IsCorrect == nil
+}
+
+// IsTimedOut игрок не успел ответить за отведенное время
+func (i SessionItem) IsTimedOut() bool {
+	return i.AnsweredAt != nil && len(i.Answers) == 0
+}
+
+func (s *GameLiveState) IsQuestionOpened(questionID uuid.UUID) bool {
+	return s.CurrentQuestionID != nil &&
+		*s.CurrentQuestionID == questionID &&
+		s.QuestionOpenedAt != nil &&
+		s.QuestionClosedAt == nil
+}
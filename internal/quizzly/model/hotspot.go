@@ -0,0 +1,105 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	hotspotPointSeparator      = ","
+	hotspotCoordinateSeparator = " "
+)
+
+type (
+	// HotspotPoint координаты точки на изображении в долях от ширины и высоты, от 0 до 1
+	HotspotPoint struct {
+		X float64
+		Y float64
+	}
+
+	// HotspotRegion область на изображении. Прямоугольник задается двумя противоположными углами,
+	// многоугольник - тремя и более вершинами
+	HotspotRegion []HotspotPoint
+)
+
+// IsRectangle прямоугольная область задается двумя точками
+func (r HotspotRegion) IsRectangle() bool {
+	return len(r) == 2
+}
+
+func (r HotspotRegion) Contains(point HotspotPoint) bool {
+	if r.IsRectangle() {
+		return point.X >= min(r[0].X, r[1].X) && point.X <= max(r[0].X, r[1].X) &&
+			point.Y >= min(r[0].Y, r[1].Y) && point.Y <= max(r[0].Y, r[1].Y)
+	}
+
+	// Проверка четности числа пересечений луча из точки с ребрами многоугольника
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		if (r[i].Y > point.Y) != (r[j].Y > point.Y) &&
+			point.X < (r[j].X-r[i].X)*(point.Y-r[i].Y)/(r[j].Y-r[i].Y)+r[i].X {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+func (r HotspotRegion) String() string {
+	points := make([]string, 0, len(r))
+	for _, point := range r {
+		points = append(points, point.String())
+	}
+
+	return strings.Join(points, hotspotPointSeparator)
+}
+
+func (p HotspotPoint) String() string {
+	return strconv.FormatFloat(p.X, 'f', 4, 64) + hotspotCoordinateSeparator + strconv.FormatFloat(p.Y, 'f', 4, 64)
+}
+
+// ParseHotspotPoint разбирает точку в формате "x y"
+func ParseHotspotPoint(in string) (HotspotPoint, error) {
+	coordinates := strings.Fields(in)
+	if len(coordinates) != 2 {
+		return HotspotPoint{}, fmt.Errorf("invalid hotspot point: %s", in)
+	}
+
+	x, err := strconv.ParseFloat(coordinates[0], 64)
+	if err != nil {
+		return HotspotPoint{}, err
+	}
+
+	y, err := strconv.ParseFloat(coordinates[1], 64)
+	if err != nil {
+		return HotspotPoint{}, err
+	}
+
+	if x < 0 || x > 1 || y < 0 || y > 1 {
+		return HotspotPoint{}, fmt.Errorf("hotspot point is out of image: %s", in)
+	}
+
+	return HotspotPoint{X: x, Y: y}, nil
+}
+
+// ParseHotspotRegion разбирает область в формате "x1 y1,x2 y2,..."
+func ParseHotspotRegion(in string) (HotspotRegion, error) {
+	points := strings.Split(in, hotspotPointSeparator)
+	if len(points) < 2 {
+		return nil, errors.New("hotspot region must contain at least two points")
+	}
+
+	result := make(HotspotRegion, 0, len(points))
+	for _, point := range points {
+		parsed, err := ParseHotspotPoint(point)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, parsed)
+	}
+
+	return result, nil
+}
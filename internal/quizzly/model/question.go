@@ -1,7 +1,12 @@
 package model
 
 import (
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -10,11 +15,39 @@ const (
 	QuestionTypeOneOfChoice    QuestionType = "one_of_choice"   // Может быть выбран любой правильный вариант ответа
 	QuestionTypeMultipleChoice QuestionType = "multiple_choice" // Должны быть выбраны все правильные ответы
 	QuestionTypeFillTheGap     QuestionType = "fill_the_gap"    // Нужно ввести правильный ответ
+	QuestionTypeOrdering       QuestionType = "ordering"        // Нужно расставить варианты ответа в правильном порядке
+	QuestionTypeMatching       QuestionType = "matching"        // Нужно сопоставить левую и правую части вариантов ответа
+	QuestionTypeNumeric        QuestionType = "numeric"         // Нужно ввести число, попадающее в допустимый диапазон
+	QuestionTypeCloze          QuestionType = "cloze"           // Нужно заполнить несколько пропусков в тексте вопроса
+	QuestionTypePoll           QuestionType = "poll"            // Опрос с выбором одного варианта, правильного ответа нет
+	QuestionTypeMultiplePoll   QuestionType = "multiple_poll"   // Опрос с выбором нескольких вариантов, правильного ответа нет
+	QuestionTypeOpenText       QuestionType = "open_text"       // Развернутый ответ, который проверяет автор игры
+	QuestionTypeHotspot        QuestionType = "hotspot"         // Нужно указать точку на изображении внутри правильной области
+)
+
+const (
+	TextMatchModeExact           TextMatchMode = "exact"            // Точное совпадение
+	TextMatchModeCaseInsensitive TextMatchMode = "case_insensitive" // Совпадение без учета регистра
+	TextMatchModeFuzzy           TextMatchMode = "fuzzy"            // Нечеткое совпадение по расстоянию Левенштейна
+	TextMatchModeRegex           TextMatchMode = "regex"            // Совпадение с регулярным выражением
+)
+
+// DefaultQuestionPoints баллы за правильный ответ, если автор игры их не указал
+const DefaultQuestionPoints = 1
+
+const (
+	matchingAnswerSeparator = ":"
+
+	clozeGapStart            = "{"
+	clozeGapEnd              = "}"
+	clozeGapSynonymSeparator = "|"
+	clozeGapPlaceholder      = "___"
 )
 
 type (
 	QuestionType   string
 	AnswerOptionID int64
+	TextMatchMode  string
 
 	Question struct {
 		ID            uuid.UUID
@@ -23,17 +56,84 @@ type (
 		Type          QuestionType
 		ImageID       *string
 		AnswerOptions []AnswerOption
-		CreatedAt     time.Time
+		TextMatch     TextMatchSettings
+		// TimeLimit время на ответ, если не задано, используется значение из настроек игры
+		TimeLimit *time.Duration
+		// Points баллы за правильный ответ
+		Points int
+		// NextQuestionID вопрос, который будет показан после ответа, если не задан, показывается следующий по порядку
+		NextQuestionID *uuid.UUID
+		// Tag тема или сложность вопроса, используется при выборе вопросов из пула
+		Tag       *string
+		CreatedAt time.Time
+	}
+
+	// ClozePart часть текста вопроса с пропусками: обычный текст или пропуск с допустимыми ответами
+	ClozePart struct {
+		Text    string
+		Answers []string
+	}
+
+	// AnswerVotes число ответов игроков, выбравших вариант ответа
+	AnswerVotes struct {
+		AnswerOption AnswerOption
+		Count        int64
+	}
+
+	// TextMatchSettings правила проверки ответа в вопросах с вводом слова
+	TextMatchSettings struct {
+		Mode TextMatchMode
+		// Distance максимальное расстояние Левенштейна для нечеткого совпадения
+		Distance *int
+		// Ratio минимальная доля совпадения от 0 до 1 для нечеткого совпадения
+		Ratio *float64
 	}
 
 	AnswerOption struct {
 		ID        AnswerOptionID
 		Answer    string
 		IsCorrect bool
+		// Position правильная позиция варианта ответа, используется в вопросах на упорядочивание
+		Position *int
+		// Pair правая часть пары, используется в вопросах на сопоставление
+		Pair *string
+		// MinValue и MaxValue границы допустимого значения, используются в числовых вопросах
+		MinValue *float64
+		MaxValue *float64
+		// Region область на изображении в формате ParseHotspotRegion, используется в вопросах с областями
+		Region *string
+		// NextQuestionID вопрос, который будет показан, если игрок выбрал этот вариант ответа
+		NextQuestionID *uuid.UUID
 	}
 )
 
+// IsManuallyGraded ответ проверяет автор игры, до проверки результат ответа неизвестен
+func (t QuestionType) IsManuallyGraded() bool {
+	return t == QuestionTypeOpenText
+}
+
+// IsPoll в опросах нет правильного ответа, такие ответы не учитываются в статистике
+func (t QuestionType) IsPoll() bool {
+	return t == QuestionTypePoll || t == QuestionTypeMultiplePoll
+}
+
+// GetTimeLimit время на ответ с учетом настроек игры, nil если время не ограничено
+func (q Question) GetTimeLimit(settings GameSettings) *time.Duration {
+	if q.TimeLimit != nil {
+		return q.TimeLimit
+	}
+
+	return settings.QuestionTimeLimit
+}
+
 func (q Question) GetCorrectAnswers() []AnswerOption {
+	switch q.Type {
+	case QuestionTypeOrdering:
+		return q.GetOrderedAnswers()
+	case QuestionTypeCloze:
+		return q.getClozeAnswers()
+	}
+
 	result := make([]AnswerOption, 0, len(q.AnswerOptions))
 	for _, answer := range q.AnswerOptions {
 		answer := answer
@@ -46,3 +146,139 @@ func (q Question) GetCorrectAnswers() []AnswerOption {
 
 	return result
 }
+
+// GetOrderedAnswers варианты ответа в правильном порядке. Варианты без позиции идут в конце
+func (q Question) GetOrderedAnswers() []AnswerOption {
+	result := make([]AnswerOption, len(q.AnswerOptions))
+	copy(result, q.AnswerOptions)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Position == nil {
+			return false
+		}
+		if result[j].Position == nil {
+			return true
+		}
+
+		return *result[i].Position < *result[j].Position
+	})
+
+	return result
+}
+
+// GetPair правая часть пары, пустая строка если пары нет
+func (a AnswerOption) GetPair() string {
+	if a.Pair == nil {
+		return ""
+	}
+
+	return *a.Pair
+}
+
+// GetRegion область варианта ответа, у вариантов без области возвращается nil
+func (a AnswerOption) GetRegion() (HotspotRegion, error) {
+	if a.Region == nil {
+		return nil, nil
+	}
+
+	return ParseHotspotRegion(*a.Region)
+}
+
+// MatchingAnswer ответ на вопрос на сопоставление: вариант из левой колонки и выбранная в пару правая часть.
+// Правая часть передается текстом, а не идентификатором варианта, иначе совпадение идентификаторов выдает ответ
+func MatchingAnswer(leftID AnswerOptionID, pair string) string {
+	return fmt.Sprintf("%d%s%s", leftID, matchingAnswerSeparator, pair)
+}
+
+func ParseMatchingAnswer(answer string) (AnswerOptionID, string, error) {
+	left, pair, ok := strings.Cut(answer, matchingAnswerSeparator)
+	if !ok {
+		return 0, "", errors.New("invalid matching answer")
+	}
+
+	leftID, err := strconv.ParseInt(left, 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return AnswerOptionID(leftID), pair, nil
+}
+
+// getClozeAnswers первый допустимый ответ каждого пропуска в порядке пропусков
+func (q Question) getClozeAnswers() []AnswerOption {
+	result := make([]AnswerOption, 0, len(q.AnswerOptions))
+	for _, answer := range q.GetOrderedAnswers() {
+		if answer.Position == nil {
+			continue
+		}
+		if len(result) > 0 && *result[len(result)-1].Position == *answer.Position {
+			continue
+		}
+
+		result = append(result, answer)
+	}
+
+	return result
+}
+
+func (p ClozePart) IsGap() bool {
+	return len(p.Answers) > 0
+}
+
+// ParseCloze разбирает текст вида "Столица {Франции} - {Париж|Paris}" на части.
+// Варианты ответа в пропуске разделяются символом "|"
+func ParseCloze(text string) []ClozePart {
+	result := make([]ClozePart, 0, 4)
+	for text != "" {
+		start := strings.Index(text, clozeGapStart)
+		end := -1
+		if start >= 0 {
+			end = strings.Index(text[start:], clozeGapEnd)
+		}
+		if start < 0 || end < 0 {
+			result = append(result, ClozePart{Text: text})
+			break
+		}
+		end += start
+
+		if start > 0 {
+			result = append(result, ClozePart{Text: text[:start]})
+		}
+
+		answers := make([]string, 0, 1)
+		for _, answer := range strings.Split(text[start+len(clozeGapStart):end], clozeGapSynonymSeparator) {
+			answer = strings.TrimSpace(answer)
+			if answer != "" {
+				answers = append(answers, answer)
+			}
+		}
+		if len(answers) > 0 {
+			result = append(result, ClozePart{Text: text[start : end+len(clozeGapEnd)], Answers: answers})
+		} else {
+			result = append(result, ClozePart{Text: text[start : end+len(clozeGapEnd)]})
+		}
+
+		text = text[end+len(clozeGapEnd):]
+	}
+
+	return result
+}
+
+// GetDisplayText текст вопроса без правильных ответов
+func (q Question) GetDisplayText() string {
+	if q.Type != QuestionTypeCloze {
+		return q.Text
+	}
+
+	builder := strings.Builder{}
+	for _, part := range ParseCloze(q.Text) {
+		if part.IsGap() {
+			builder.WriteString(clozeGapPlaceholder)
+			continue
+		}
+
+		builder.WriteString(part.Text)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// IsBranching в игре с ветвлением следующий вопрос зависит от переходов, заданных автором игры
+func IsBranching(questions []Question) bool {
+	for _, question := range questions {
+		if question.NextQuestionID != nil {
+			return true
+		}
+		for _, answerOption := range question.AnswerOptions {
+			if answerOption.NextQuestionID != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasAnswerBranching переход по варианту ответа возможен, только если игрок выбирает один вариант
+func (t QuestionType) HasAnswerBranching() bool {
+	return t == QuestionTypeChoice || t == QuestionTypeOneOfChoice || t == QuestionTypePoll
+}
+
+// GetNextQuestionID переход по выбранному варианту ответа важнее перехода по вопросу.
+// Возвращает nil, если переход не задан
+func (q Question) GetNextQuestionID(answers []string) *uuid.UUID {
+	if q.Type.HasAnswerBranching() {
+		for _, answerOption := range q.AnswerOptions {
+			if answerOption.NextQuestionID != nil && slices.Contains(answers, strconv.FormatInt(int64(answerOption.ID), 10)) {
+				return answerOption.NextQuestionID
+			}
+		}
+	}
+
+	return q.NextQuestionID
+}
+
+// NextQuestionIndex индекс вопроса, который будет показан после ответа на вопрос index, -1 если вопросы закончились
+func NextQuestionIndex(questions []Question, index int, answers []string) int {
+	return nextQuestionIndex(questions, index, questions[index].GetNextQuestionID(answers))
+}
+
+// NextQuestionIndexes индексы всех вопросов, на которые можно перейти из вопроса index, -1 означает конец игры
+func NextQuestionIndexes(questions []Question, index int) []int {
+	question := questions[index]
+	if !question.Type.HasAnswerBranching() {
+		return []int{nextQuestionIndex(questions, index, question.NextQuestionID)}
+	}
+
+	result := make([]int, 0, len(question.AnswerOptions))
+	for _, answerOption := range question.AnswerOptions {
+		nextQuestionID := answerOption.NextQuestionID
+		if nextQuestionID == nil {
+			nextQuestionID = question.NextQuestionID
+		}
+
+		next := nextQuestionIndex(questions, index, nextQuestionID)
+		if !slices.Contains(result, next) {
+			result = append(result, next)
+		}
+	}
+
+	return result
+}
+
+// CountQuestionPath число вопросов до конца игры, начиная с вопроса index, если игрок не выберет вариант ответа с переходом
+func CountQuestionPath(questions []Question, index int) int {
+	visited := make(map[int]bool, len(questions))
+	for index >= 0 && !visited[index] {
+		visited[index] = true
+		index = nextQuestionIndex(questions, index, questions[index].NextQuestionID)
+	}
+
+	return len(visited)
+}
+
+func nextQuestionIndex(questions []Question, index int, nextQuestionID *uuid.UUID) int {
+	if nextQuestionID == nil {
+		if index+1 < len(questions) {
+			return index + 1
+		}
+
+		return -1
+	}
+
+	return slices.IndexFunc(questions, func(question Question) bool {
+		return question.ID == *nextQuestionID
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"quizzly/internal/quizzly/model"
+	"time"
 )
 
 type (
@@ -13,6 +14,8 @@ type (
 		IsPrivate *bool
 		Limit     int64
 		Statuses  []model.GameStatus
+		// ScheduledBefore игры, которые по расписанию пора запустить или завершить к этому моменту
+		ScheduledBefore *time.Time
 	}
 
 	QuestionsSpec struct {
@@ -32,6 +35,11 @@ type (
 		InsertQuestion(ctx context.Context, in *model.Question) error
 		UpdateQuestion(ctx context.Context, in *model.Question) error
 		DeleteQuestion(ctx context.Context, id uuid.UUID) error
+		UpdateQuestionNextQuestion(ctx context.Context, gameID uuid.UUID, id uuid.UUID, nextQuestionID *uuid.UUID) error
+		UpdateAnswerOptionNextQuestion(ctx context.Context, questionID uuid.UUID, id model.AnswerOptionID, nextQuestionID *uuid.UUID) error
 		GetQuestionsBySpec(ctx context.Context, spec *QuestionsSpec) ([]model.Question, error)
+
+		UpsertLiveState(ctx context.Context, in *model.GameLiveState) error
+		GetLiveState(ctx context.Context, gameID uuid.UUID) (*model.GameLiveState, error)
 	}
 )
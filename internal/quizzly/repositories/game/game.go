@@ -11,26 +11,42 @@ import (
 
 type (
 	sqlxGame struct {
-		ID                       uuid.UUID `db:"id"`
-		AuthorID                 uuid.UUID `db:"author_id"`
-		Status                   string    `db:"status"`
-		Type                     string    `db:"type"`
-		Title                    *string   `db:"title"`
-		SettingsIsPrivate        bool      `db:"settings_is_private"`
-		SettingsShuffleQuestions bool      `db:"settings_shuffle_questions"`
-		SettingsShuffleAnswers   bool      `db:"settings_shuffle_answers"`
-		SettingsShowRightAnswers bool      `db:"settings_show_right_answers"`
-		SettingsInputCustomName  bool      `db:"settings_input_custom_name"`
-		CreatedAt                time.Time `db:"created_at"`
+		ID                       uuid.UUID  `db:"id"`
+		AuthorID                 uuid.UUID  `db:"author_id"`
+		Status                   string     `db:"status"`
+		Type                     string     `db:"type"`
+		Title                    *string    `db:"title"`
+		OpensAt                  *time.Time `db:"opens_at"`
+		ClosesAt                 *time.Time `db:"closes_at"`
+		SettingsIsPrivate        bool       `db:"settings_is_private"`
+		SettingsShuffleQuestions bool       `db:"settings_shuffle_questions"`
+		SettingsShuffleAnswers   bool       `db:"settings_shuffle_answers"`
+		SettingsShowRightAnswers bool       `db:"settings_show_right_answers"`
+		SettingsInputCustomName  bool       `db:"settings_input_custom_name"`
+		// SettingsQuestionTimeLimit время на ответ в секундах
+		SettingsQuestionTimeLimit *int   `db:"settings_question_time_limit"`
+		SettingsSpeedBonus        bool   `db:"settings_speed_bonus"`
+		SettingsNegativeMarking   bool   `db:"settings_negative_marking"`
+		SettingsPartialCredit     bool   `db:"settings_partial_credit"`
+		SettingsHideLeaderboard   bool   `db:"settings_hide_leaderboard"`
+		SettingsQuestionPoolSize  *int   `db:"settings_question_pool_size"`
+		SettingsStratifyPool      bool   `db:"settings_stratify_pool"`
+		SettingsMaxAttempts       *int   `db:"settings_max_attempts"`
+		SettingsAttemptPolicy     string `db:"settings_attempt_policy"`
+		// SettingsInactivityTimeout время бездействия в секундах
+		SettingsInactivityTimeout *int      `db:"settings_inactivity_timeout"`
+		CreatedAt                 time.Time `db:"created_at"`
 	}
 )
 
 func (r *DefaultRepository) Upsert(ctx context.Context, in *model.Game) error {
 	const query = `
-		insert into game (id, status, "type", author_id, title) values ($1, $2, $3, $4, $5)
+		insert into game (id, status, "type", author_id, title, opens_at, closes_at) values ($1, $2, $3, $4, $5, $6, $7)
 		on conflict (id) do update set
 			status = excluded.status,
-			title = excluded.title
+			title = excluded.title,
+			opens_at = excluded.opens_at,
+			closes_at = excluded.closes_at
 	`
 
 	var title *string
@@ -38,7 +54,7 @@ func (r *DefaultRepository) Upsert(ctx context.Context, in *model.Game) error {
 		title = in.Title
 	}
 
-	_, err := r.db(ctx).ExecContext(ctx, query, in.ID, in.Status, in.Type, in.AuthorID, title)
+	_, err := r.db(ctx).ExecContext(ctx, query, in.ID, in.Status, in.Type, in.AuthorID, title, in.OpensAt, in.ClosesAt)
 	if err != nil {
 		return err
 	}
@@ -50,14 +66,34 @@ func (r *DefaultRepository) Upsert(ctx context.Context, in *model.Game) error {
 		    shuffle_questions, 
 		    shuffle_answers,
 		    show_right_answers,
-		    input_custom_name
-		) values ($1, $2, $3, $4, $5, $6) 
+		    input_custom_name,
+		    question_time_limit,
+		    speed_bonus,
+		    negative_marking,
+		    partial_credit,
+		    hide_leaderboard,
+		    question_pool_size,
+		    stratify_pool,
+		    max_attempts,
+		    attempt_policy,
+		    inactivity_timeout
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) 
 		on conflict (game_id) do update set
 			is_private = excluded.is_private,
 			shuffle_questions = excluded.shuffle_questions,
 			shuffle_answers = excluded.shuffle_answers,
 			show_right_answers = excluded.show_right_answers,
-			input_custom_name = excluded.input_custom_name
+			input_custom_name = excluded.input_custom_name,
+			question_time_limit = excluded.question_time_limit,
+			speed_bonus = excluded.speed_bonus,
+			negative_marking = excluded.negative_marking,
+			partial_credit = excluded.partial_credit,
+			hide_leaderboard = excluded.hide_leaderboard,
+			question_pool_size = excluded.question_pool_size,
+			stratify_pool = excluded.stratify_pool,
+			max_attempts = excluded.max_attempts,
+			attempt_policy = excluded.attempt_policy,
+			inactivity_timeout = excluded.inactivity_timeout
 	`
 
 	_, err = r.db(ctx).ExecContext(
@@ -69,6 +105,16 @@ func (r *DefaultRepository) Upsert(ctx context.Context, in *model.Game) error {
 		in.Settings.ShuffleAnswers,
 		in.Settings.ShowRightAnswers,
 		in.Settings.InputCustomName,
+		durationToSeconds(in.Settings.QuestionTimeLimit),
+		in.Settings.SpeedBonus,
+		in.Settings.NegativeMarking,
+		in.Settings.PartialCredit,
+		in.Settings.HideLeaderboard,
+		in.Settings.QuestionPoolSize,
+		in.Settings.StratifyPool,
+		in.Settings.MaxAttempts,
+		attemptPolicyOrDefault(in.Settings.AttemptPolicy),
+		durationToSeconds(in.Settings.InactivityTimeout),
 	)
 	return err
 }
@@ -82,17 +128,33 @@ func (r *DefaultRepository) GetBySpec(ctx context.Context, spec *Spec) ([]model.
 			g.author_id,
 			g.created_at,
 			g.title,
+			g.opens_at,
+			g.closes_at,
 			gs.is_private as settings_is_private, 
 			gs.shuffle_questions as settings_shuffle_questions,
 			gs.shuffle_answers as settings_shuffle_answers,
 			gs.show_right_answers as settings_show_right_answers,
-		    gs.input_custom_name as settings_input_custom_name
+		    gs.input_custom_name as settings_input_custom_name,
+		    gs.question_time_limit as settings_question_time_limit,
+		    gs.speed_bonus as settings_speed_bonus,
+		    gs.negative_marking as settings_negative_marking,
+		    gs.partial_credit as settings_partial_credit,
+		    gs.hide_leaderboard as settings_hide_leaderboard,
+		    gs.question_pool_size as settings_question_pool_size,
+		    gs.stratify_pool as settings_stratify_pool,
+		    gs.max_attempts as settings_max_attempts,
+		    gs.attempt_policy as settings_attempt_policy,
+		    gs.inactivity_timeout as settings_inactivity_timeout
 		from game as g
 		inner join game_settings as gs on gs.game_id = g.id
 		where ($1::UUID[] is null or cardinality($1::UUID[]) = 0 or g.id = any($1))
 		  and ($2::UUID is null or g.author_id = $2)
 		  and ($3::bool is null or gs.is_private = $3)
 		  and ($4::text[] is null or cardinality($4::text[]) = 0 or g.status = any($4))
+		  and ($6::timestamptz is null or (
+		      g.status = $7 and g.opens_at <= $6 or
+		      g.status = $8 and g.closes_at <= $6
+		  ))
 		order by g.created_at desc
 		limit $5
 	`
@@ -112,6 +174,9 @@ func (r *DefaultRepository) GetBySpec(ctx context.Context, spec *Spec) ([]model.
 		spec.IsPrivate,
 		pq.Array(spec.Statuses),
 		limit,
+		spec.ScheduledBefore,
+		model.GameStatusCreated,
+		model.GameStatusStarted,
 	); err != nil {
 		return nil, err
 	}
@@ -128,13 +193,52 @@ func convertToGame(in sqlxGame) model.Game {
 		Status:   model.GameStatus(in.Status),
 		AuthorID: in.AuthorID,
 		Title:    in.Title,
+		OpensAt:  in.OpensAt,
+		ClosesAt: in.ClosesAt,
 		Settings: model.GameSettings{
-			IsPrivate:        in.SettingsIsPrivate,
-			ShuffleQuestions: in.SettingsShuffleQuestions,
-			ShuffleAnswers:   in.SettingsShuffleAnswers,
-			ShowRightAnswers: in.SettingsShowRightAnswers,
-			InputCustomName:  in.SettingsInputCustomName,
+			IsPrivate:         in.SettingsIsPrivate,
+			ShuffleQuestions:  in.SettingsShuffleQuestions,
+			ShuffleAnswers:    in.SettingsShuffleAnswers,
+			ShowRightAnswers:  in.SettingsShowRightAnswers,
+			InputCustomName:   in.SettingsInputCustomName,
+			QuestionTimeLimit: secondsToDuration(in.SettingsQuestionTimeLimit),
+			SpeedBonus:        in.SettingsSpeedBonus,
+			NegativeMarking:   in.SettingsNegativeMarking,
+			PartialCredit:     in.SettingsPartialCredit,
+			HideLeaderboard:   in.SettingsHideLeaderboard,
+			QuestionPoolSize:  in.SettingsQuestionPoolSize,
+			StratifyPool:      in.SettingsStratifyPool,
+			MaxAttempts:       in.SettingsMaxAttempts,
+			AttemptPolicy:     model.AttemptPolicy(in.SettingsAttemptPolicy),
+			InactivityTimeout: secondsToDuration(in.SettingsInactivityTimeout),
 		},
 		CreatedAt: in.CreatedAt,
 	}
 }
+
+// attemptPolicyOrDefault по умолчанию учитывается последняя попытка
+func attemptPolicyOrDefault(in model.AttemptPolicy) model.AttemptPolicy {
+	if in == "" {
+		return model.AttemptPolicyLast
+	}
+
+	return in
+}
+
+func durationToSeconds(in *time.Duration) *int {
+	if in == nil {
+		return nil
+	}
+
+	seconds := int(in.Seconds())
+	return &seconds
+}
+
+func secondsToDuration(in *int) *time.Duration {
+	if in == nil {
+		return nil
+	}
+
+	duration := time.Duration(*in) * time.Second
+	return &duration
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"quizzly/internal/quizzly/model"
+	"time"
+)
+
+type (
+	sqlxGameLiveState struct {
+		GameID            uuid.UUID  `db:"game_id"`
+		CurrentQuestionID *uuid.UUID `db:"current_question_id"`
+		QuestionOpenedAt  *time.Time `db:"question_opened_at"`
+		QuestionClosedAt  *time.Time `db:"question_closed_at"`
+	}
+)
+
+func (r *DefaultRepository) UpsertLiveState(ctx context.Context, in *model.GameLiveState) error {
+	const query = `
+		insert into game_live_state (game_id, current_question_id, question_opened_at, question_closed_at) 
+		values ($1, $2, $3, $4)
+		on conflict (game_id) do update set
+			current_question_id = excluded.current_question_id,
+			question_opened_at = excluded.question_opened_at,
+			question_closed_at = excluded.question_closed_at,
+			updated_at = now()
+	`
+
+	_, err := r.db(ctx).ExecContext(
+		ctx,
+		query,
+		in.GameID,
+		in.CurrentQuestionID,
+		in.QuestionOpenedAt,
+		in.QuestionClosedAt,
+	)
+	return err
+}
+
+func (r *DefaultRepository) GetLiveState(ctx context.Context, gameID uuid.UUID) (*model.GameLiveState, error) {
+	const query = `
+		select game_id, current_question_id, question_opened_at, question_closed_at
+		from game_live_state
+		where game_id = $1
+	`
+
+	var result sqlxGameLiveState
+	if err := r.db(ctx).GetContext(ctx, &result, query, gameID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.GameLiveState{GameID: gameID}, nil
+		}
+
+		return nil, err
+	}
+
+	return &model.GameLiveState{
+		GameID:            result.GameID,
+		CurrentQuestionID: result.CurrentQuestionID,
+		QuestionOpenedAt:  result.QuestionOpenedAt,
+		QuestionClosedAt:  result.QuestionClosedAt,
+	}, nil
+}
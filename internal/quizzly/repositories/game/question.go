@@ -6,7 +6,9 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"math/rand/v2"
 	"quizzly/internal/quizzly/model"
+	"slices"
 	"time"
 )
 
@@ -16,10 +18,23 @@ type (
 		ImageID               *string              `db:"image_id"`
 		Text                  string               `db:"text"`
 		Type                  string               `db:"type"`
+		TextMatchMode         *string              `db:"text_match_mode"`
+		TextMatchDistance     *int                 `db:"text_match_distance"`
+		TextMatchRatio        *float64             `db:"text_match_ratio"`
 		CreatedAt             time.Time            `db:"created_at"`
 		AnswerOptionID        model.AnswerOptionID `db:"answer_option_id"`
 		AnswerOptionAnswer    string               `db:"answer_option_answer"`
 		AnswerOptionIsCorrect bool                 `db:"answer_option_is_correct"`
+		AnswerOptionPosition  *int                 `db:"answer_option_position"`
+		AnswerOptionPair      *string              `db:"answer_option_pair"`
+		AnswerOptionMinValue  *float64             `db:"answer_option_min_value"`
+		AnswerOptionMaxValue  *float64             `db:"answer_option_max_value"`
+		AnswerOptionRegion    *string              `db:"answer_option_region"`
+		AnswerOptionNextID    *uuid.UUID           `db:"answer_option_next_question_id"`
+		TimeLimit             *int                 `db:"time_limit"`
+		Points                int                  `db:"points"`
+		NextQuestionID        *uuid.UUID           `db:"next_question_id"`
+		Tag                   *string              `db:"tag"`
 	}
 )
 
@@ -38,15 +53,37 @@ func (r *DefaultRepository) InsertQuestion(ctx context.Context, in *model.Questi
 		        $2 as text, 
 		        $3 as type ,
 		        $4::uuid as game_id,
-		        $5 as image_id
+		        $5 as image_id,
+		        $6::text as text_match_mode,
+		        $7::integer as text_match_distance,
+		        $8::numeric as text_match_ratio,
+		        $9::integer as time_limit,
+		        $10::integer as points,
+		        $11::uuid as next_question_id,
+		        $12::text as tag
 		)
-		insert into question (id, "text", "type", "game_id", "image_id", "sort")
-		select d.id, d.text, d.type, d.game_id, d.image_id, coalesce(lqs.sort, 0) + 1 as sort
+		insert into question (id, "text", "type", "game_id", "image_id", "sort", text_match_mode, text_match_distance, text_match_ratio, time_limit, points, next_question_id, tag)
+		select d.id, d.text, d.type, d.game_id, d.image_id, coalesce(lqs.sort, 0) + 1 as sort, d.text_match_mode, d.text_match_distance, d.text_match_ratio, d.time_limit, d.points, d.next_question_id, d.tag
 		from data as d
 		left join last_question_sort lqs on lqs.game_id = d.game_id
 	`
 
-	_, err := r.db(ctx).ExecContext(ctx, query, in.ID, in.Text, in.Type, in.GameID, in.ImageID)
+	_, err := r.db(ctx).ExecContext(
+		ctx,
+		query,
+		in.ID,
+		in.Text,
+		in.Type,
+		in.GameID,
+		in.ImageID,
+		textMatchMode(in.TextMatch.Mode),
+		in.TextMatch.Distance,
+		in.TextMatch.Ratio,
+		durationToSeconds(in.TimeLimit),
+		in.Points,
+		in.NextQuestionID,
+		in.Tag,
+	)
 	if err != nil {
 		return err
 	}
@@ -58,10 +95,30 @@ func (r *DefaultRepository) UpdateQuestion(ctx context.Context, in *model.Questi
 	const query = `
 		update question set 
 			"text" = $2,
-			image_id = $3     
+			image_id = $3,
+			time_limit = $4,
+			points = $5,
+			next_question_id = $6,
+			tag = $7,
+			text_match_mode = $8::text,
+			text_match_distance = $9::integer,
+			text_match_ratio = $10::numeric
 		where id = $1
 	`
-	_, err := r.db(ctx).ExecContext(ctx, query, in.ID, in.Text, in.ImageID)
+	_, err := r.db(ctx).ExecContext(
+		ctx,
+		query,
+		in.ID,
+		in.Text,
+		in.ImageID,
+		durationToSeconds(in.TimeLimit),
+		in.Points,
+		in.NextQuestionID,
+		in.Tag,
+		textMatchMode(in.TextMatch.Mode),
+		in.TextMatch.Distance,
+		in.TextMatch.Ratio,
+	)
 	if err != nil {
 		return err
 	}
@@ -69,8 +126,17 @@ func (r *DefaultRepository) UpdateQuestion(ctx context.Context, in *model.Questi
 	return r.upsertAnswerOption(ctx, in)
 }
 
+// DeleteQuestion переходы на удаленный вопрос сбрасываются
 func (r *DefaultRepository) DeleteQuestion(ctx context.Context, id uuid.UUID) error {
-	const query = `update question set deleted_at = now() where id = $1`
+	const query = `
+		with deleted_question as (
+		    update question set deleted_at = now() where id = $1
+		),
+		question_links as (
+		    update question set next_question_id = null where next_question_id = $1
+		)
+		update question_answer_option set next_question_id = null where next_question_id = $1
+	`
 
 	_, err := r.db(ctx).ExecContext(ctx, query, id)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -79,6 +145,20 @@ func (r *DefaultRepository) DeleteQuestion(ctx context.Context, id uuid.UUID) er
 	return err
 }
 
+func (r *DefaultRepository) UpdateQuestionNextQuestion(ctx context.Context, gameID uuid.UUID, id uuid.UUID, nextQuestionID *uuid.UUID) error {
+	const query = `update question set next_question_id = $2 where id = $1 and game_id = $3`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, id, nextQuestionID, gameID)
+	return err
+}
+
+func (r *DefaultRepository) UpdateAnswerOptionNextQuestion(ctx context.Context, questionID uuid.UUID, id model.AnswerOptionID, nextQuestionID *uuid.UUID) error {
+	const query = `update question_answer_option set next_question_id = $2 where id = $1 and question_id = $3`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, id, nextQuestionID, questionID)
+	return err
+}
+
 func (r *DefaultRepository) upsertAnswerOption(ctx context.Context, in *model.Question) error {
 	const answerOptionsQueryDelete = `delete from question_answer_option where question_id = $1`
 	_, err := r.db(ctx).ExecContext(ctx, answerOptionsQueryDelete, in.ID)
@@ -87,21 +167,101 @@ func (r *DefaultRepository) upsertAnswerOption(ctx context.Context, in *model.Qu
 	}
 
 	const answerOptionsQueryInsert = ` 
-		insert into question_answer_option (question_id, answer, is_correct)
-		select $1, unnest($2::text[]), unnest($3::boolean[])
+		insert into question_answer_option (question_id, answer, is_correct, position, pair, min_value, max_value, region, next_question_id)
+		select $1,
+		       unnest($2::text[]),
+		       unnest($3::boolean[]),
+		       unnest($4::integer[]),
+		       unnest($5::text[]),
+		       unnest($6::numeric[]),
+		       unnest($7::numeric[]),
+		       unnest($8::text[]),
+		       unnest($9::uuid[])
 	`
 
-	answer := make([]string, 0, len(in.AnswerOptions))
-	isCorrect := make([]bool, 0, len(in.AnswerOptions))
-	for _, item := range in.AnswerOptions {
+	answerOptions := in.AnswerOptions
+	// Варианты на упорядочивание сохраняются в случайном порядке: идентификаторы видны игроку,
+	// и при вставке в правильном порядке их сортировка выдала бы ответ. Порядок задает только position
+	if in.Type == model.QuestionTypeOrdering {
+		answerOptions = slices.Clone(in.AnswerOptions)
+		rand.Shuffle(len(answerOptions), func(i, j int) {
+			answerOptions[i], answerOptions[j] = answerOptions[j], answerOptions[i]
+		})
+	}
+
+	answer := make([]string, 0, len(answerOptions))
+	isCorrect := make([]bool, 0, len(answerOptions))
+	position := make([]sql.NullInt64, 0, len(answerOptions))
+	pair := make([]sql.NullString, 0, len(answerOptions))
+	minValue := make([]sql.NullFloat64, 0, len(answerOptions))
+	maxValue := make([]sql.NullFloat64, 0, len(answerOptions))
+	region := make([]sql.NullString, 0, len(answerOptions))
+	nextQuestionID := make([]uuid.NullUUID, 0, len(answerOptions))
+	for _, item := range answerOptions {
 		answer = append(answer, item.Answer)
 		isCorrect = append(isCorrect, item.IsCorrect)
+
+		itemPosition := sql.NullInt64{}
+		if item.Position != nil {
+			itemPosition = sql.NullInt64{Int64: int64(*item.Position), Valid: true}
+		}
+		position = append(position, itemPosition)
+
+		itemPair := sql.NullString{}
+		if item.Pair != nil {
+			itemPair = sql.NullString{String: *item.Pair, Valid: true}
+		}
+		pair = append(pair, itemPair)
+
+		minValue = append(minValue, nullFloat64(item.MinValue))
+		maxValue = append(maxValue, nullFloat64(item.MaxValue))
+
+		itemRegion := sql.NullString{}
+		if item.Region != nil {
+			itemRegion = sql.NullString{String: *item.Region, Valid: true}
+		}
+		region = append(region, itemRegion)
+
+		itemNextQuestionID := uuid.NullUUID{}
+		if item.NextQuestionID != nil {
+			itemNextQuestionID = uuid.NullUUID{UUID: *item.NextQuestionID, Valid: true}
+		}
+		nextQuestionID = append(nextQuestionID, itemNextQuestionID)
 	}
 
-	_, err = r.db(ctx).ExecContext(ctx, answerOptionsQueryInsert, in.ID, pq.Array(answer), pq.Array(isCorrect))
+	_, err = r.db(ctx).ExecContext(
+		ctx,
+		answerOptionsQueryInsert,
+		in.ID,
+		pq.Array(answer),
+		pq.Array(isCorrect),
+		pq.Array(position),
+		pq.Array(pair),
+		pq.Array(minValue),
+		pq.Array(maxValue),
+		pq.Array(region),
+		pq.Array(nextQuestionID),
+	)
 	return err
 }
 
+// textMatchMode пустой режим хранится как null
+func textMatchMode(mode model.TextMatchMode) *string {
+	if mode == "" {
+		return nil
+	}
+
+	return (*string)(&mode)
+}
+
+func nullFloat64(in *float64) sql.NullFloat64 {
+	if in == nil {
+		return sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: *in, Valid: true}
+}
+
 func (r *DefaultRepository) GetQuestionsBySpec(ctx context.Context, spec *QuestionsSpec) ([]model.Question, error) {
 	const query = `
        select 
@@ -110,15 +270,28 @@ func (r *DefaultRepository) GetQuestionsBySpec(ctx context.Context, spec *Questi
            q.type, 
            q.image_id, 
            q.created_at,
+           q.text_match_mode,
+           q.text_match_distance,
+           q.text_match_ratio,
+           q.time_limit,
+           q.points,
+           q.next_question_id,
+           q.tag,
            qao.id as answer_option_id, 
            qao.answer as answer_option_answer, 
-           qao.is_correct as answer_option_is_correct
+           qao.is_correct as answer_option_is_correct,
+           qao.position as answer_option_position,
+           qao.pair as answer_option_pair,
+           qao.min_value as answer_option_min_value,
+           qao.max_value as answer_option_max_value,
+           qao.region as answer_option_region,
+           qao.next_question_id as answer_option_next_question_id
 		from question as q
 		inner join question_answer_option as qao on qao.question_id = q.id
         where ($1::UUID[] is null or cardinality($1::UUID[]) = 0 or q.id = ANY($1::UUID[]))
 		  and ($2::UUID is null or game_id = $2::UUID)
 		  and deleted_at is null
-       order by q.sort, q.created_at
+       order by q.sort, q.created_at, qao.id
 	`
 
 	var result []sqlxQuestion
@@ -141,22 +314,41 @@ func convertQuestions(in []sqlxQuestion) []model.Question {
 	for _, item := range in {
 		index, ok := indexMap[item.ID]
 		if !ok {
+			textMatch := model.TextMatchSettings{
+				Distance: item.TextMatchDistance,
+				Ratio:    item.TextMatchRatio,
+			}
+			if item.TextMatchMode != nil {
+				textMatch.Mode = model.TextMatchMode(*item.TextMatchMode)
+			}
+
 			out = append(out, model.Question{
-				ID:            item.ID,
-				Text:          item.Text,
-				Type:          model.QuestionType(item.Type),
-				ImageID:       item.ImageID,
-				AnswerOptions: make([]model.AnswerOption, 0, 4),
-				CreatedAt:     item.CreatedAt,
+				ID:             item.ID,
+				Text:           item.Text,
+				Type:           model.QuestionType(item.Type),
+				ImageID:        item.ImageID,
+				AnswerOptions:  make([]model.AnswerOption, 0, 4),
+				TextMatch:      textMatch,
+				TimeLimit:      secondsToDuration(item.TimeLimit),
+				Points:         item.Points,
+				NextQuestionID: item.NextQuestionID,
+				Tag:            item.Tag,
+				CreatedAt:      item.CreatedAt,
 			})
 			index = len(out) - 1
 			indexMap[item.ID] = index
 		}
 
 		out[index].AnswerOptions = append(out[index].AnswerOptions, model.AnswerOption{
-			ID:        item.AnswerOptionID,
-			Answer:    item.AnswerOptionAnswer,
-			IsCorrect: item.AnswerOptionIsCorrect,
+			ID:             item.AnswerOptionID,
+			Answer:         item.AnswerOptionAnswer,
+			IsCorrect:      item.AnswerOptionIsCorrect,
+			Position:       item.AnswerOptionPosition,
+			Pair:           item.AnswerOptionPair,
+			MinValue:       item.AnswerOptionMinValue,
+			MaxValue:       item.AnswerOptionMaxValue,
+			Region:         item.AnswerOptionRegion,
+			NextQuestionID: item.AnswerOptionNextID,
 		})
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"quizzly/internal/quizzly/model"
+	"time"
 )
 
 type (
@@ -16,16 +17,37 @@ type (
 		PlayerID   uuid.UUID
 		GameID     uuid.UUID
 		QuestionID *uuid.UUID
+		// AllAttempts по умолчанию возвращаются только ответы последней попытки
+		AllAttempts bool
 	}
 
 	GetExtendedSessionSpec struct {
 		GameID uuid.UUID
 		Page   *Page
+		// AllAttempts по умолчанию от каждого игрока возвращается только последняя попытка
+		AllAttempts bool
 	}
 
 	GetExtendedSessionsBySpecOut struct {
-		Result     []model.ExtendedSession
-		TotalCount int64
+		Result         []model.ExtendedSession
+		TotalCount     int64
+		FinishedCount  int64
+		AbandonedCount int64
+	}
+
+	LeaderboardSpec struct {
+		GameID uuid.UUID
+		Order  model.LeaderboardOrder
+		Limit  int64
+		// AttemptPolicy какую из попыток игрока учитывать
+		AttemptPolicy model.AttemptPolicy
+	}
+
+	UpdateReviewIn struct {
+		ItemID    int64
+		GameID    uuid.UUID
+		IsCorrect bool
+		Comment   *string
 	}
 
 	Page struct {
@@ -36,11 +58,20 @@ type (
 	Repository interface {
 		Insert(ctx context.Context, in *model.Session) error
 		Update(ctx context.Context, in *model.Session) error
+		UpdateStatusByGameID(ctx context.Context, gameID uuid.UUID, from model.SessionStatus, to model.SessionStatus) error
 		GetBySpec(ctx context.Context, spec *Spec) (*model.Session, error)
+		GetAttemptsBySpec(ctx context.Context, spec *Spec) ([]model.Session, error)
+		AbandonStale(ctx context.Context, now time.Time) ([]model.Session, error)
 
 		InsertSessionItem(ctx context.Context, in *model.SessionItem) error
+		UpdateSessionItem(ctx context.Context, in *model.SessionItem) error
 		DeleteSessionItemsBySessionID(ctx context.Context, sessionID int64) error
 		GetSessionBySpec(ctx context.Context, spec *ItemSpec) ([]model.SessionItem, error)
+		GetPendingReviewItems(ctx context.Context, gameID uuid.UUID) ([]model.ReviewItem, error)
+		UpdateSessionItemReview(ctx context.Context, in *UpdateReviewIn) (*model.ReviewItem, error)
+		UpdateSessionItemScore(ctx context.Context, itemID int64, score *float64) error
+		GetAnswerVotes(ctx context.Context, questionID uuid.UUID) (map[string]int64, error)
+		GetLeaderboard(ctx context.Context, spec *LeaderboardSpec) ([]model.LeaderboardEntry, error)
 		GetExtendedSessionsBySpec(ctx context.Context, spec *GetExtendedSessionSpec) (*GetExtendedSessionsBySpecOut, error)
 	}
 )
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/google/uuid"
 )
@@ -23,37 +24,77 @@ const (
 
 type (
 	sqlxSession struct {
-		ID        int64     `db:"id"`
-		PlayerID  uuid.UUID `db:"player_id"`
-		GameID    uuid.UUID `db:"game_id"`
-		Status    string    `db:"status"`
-		CreatedAt time.Time `db:"created_at"`
+		ID          int64          `db:"id"`
+		PlayerID    uuid.UUID      `db:"player_id"`
+		GameID      uuid.UUID      `db:"game_id"`
+		Status      string         `db:"status"`
+		QuestionIDs pq.StringArray `db:"question_ids"`
+		ShuffleSeed int64          `db:"shuffle_seed"`
+		Attempt     int            `db:"attempt"`
+		CreatedAt   time.Time      `db:"created_at"`
 	}
 
 	sqlxSessionExtended struct {
-		ID             int64      `db:"id"`
-		PlayerID       uuid.UUID  `db:"player_id"`
-		GameID         uuid.UUID  `db:"game_id"`
-		Status         string     `db:"status"`
-		CreatedAt      time.Time  `db:"created_at"`
-		ItemID         *int64     `db:"item_id"`
-		ItemQuestionID *uuid.UUID `db:"item_question_id"`
-		ItemAnswers    []byte     `db:"item_answers"`
-		ItemIsCorrect  *bool      `db:"item_is_correct"`
-		ItemAnsweredAt *time.Time `db:"item_answered_at"`
-		ItemCreatedAt  *time.Time `db:"item_created_at"`
+		ID               int64          `db:"id"`
+		PlayerID         uuid.UUID      `db:"player_id"`
+		GameID           uuid.UUID      `db:"game_id"`
+		Status           string         `db:"status"`
+		QuestionIDs      pq.StringArray `db:"question_ids"`
+		Attempt          int            `db:"attempt"`
+		CreatedAt        time.Time      `db:"created_at"`
+		ItemID           *int64         `db:"item_id"`
+		ItemQuestionID   *uuid.UUID     `db:"item_question_id"`
+		ItemQuestionType *string        `db:"item_question_type"`
+		ItemAnswers      []byte         `db:"item_answers"`
+		ItemIsCorrect    *bool          `db:"item_is_correct"`
+		ItemAnsweredAt   *time.Time     `db:"item_answered_at"`
+		ItemCreatedAt    *time.Time     `db:"item_created_at"`
+		ItemScore        *float64       `db:"item_score"`
 	}
 
 	sqlxSessionItem struct {
-		ID         int64      `db:"id"`
-		SessionID  int64      `db:"session_id"`
+		ID            int64      `db:"id"`
+		SessionID     int64      `db:"session_id"`
+		QuestionID    uuid.UUID  `db:"question_id"`
+		QuestionType  string     `db:"question_type"`
+		Answers       []byte     `db:"answers"`
+		IsCorrect     *bool      `db:"is_correct"`
+		AnsweredAt    *time.Time `db:"answered_at"`
+		CreatedAt     time.Time  `db:"created_at"`
+		ReviewComment *string    `db:"review_comment"`
+		Score         *float64   `db:"score"`
+	}
+
+	sqlxReviewItem struct {
+		sqlxSessionItem
+		PlayerID uuid.UUID `db:"player_id"`
+	}
+
+	sqlxReviewedItem struct {
 		QuestionID uuid.UUID  `db:"question_id"`
-		Answers    []byte     `db:"answers"`
-		IsCorrect  *bool      `db:"is_correct"`
+		PlayerID   uuid.UUID  `db:"player_id"`
 		AnsweredAt *time.Time `db:"answered_at"`
 		CreatedAt  time.Time  `db:"created_at"`
 	}
 
+	sqlxLeaderboardEntry struct {
+		PlayerID            uuid.UUID `db:"player_id"`
+		Score               float64   `db:"score"`
+		CorrectAnswersCount int64     `db:"correct_answers_count"`
+		DurationMs          int64     `db:"duration_ms"`
+	}
+
+	sqlxSessionCounts struct {
+		TotalCount     int64 `db:"total_count"`
+		FinishedCount  int64 `db:"finished_count"`
+		AbandonedCount int64 `db:"abandoned_count"`
+	}
+
+	sqlxAnswerVotes struct {
+		Answer string `db:"answer"`
+		Count  int64  `db:"count"`
+	}
+
 	DefaultRepository struct {
 		sqlx *sqlx.DB
 		tx   *trmsqlx.CtxGetter
@@ -70,10 +111,10 @@ func (r *DefaultRepository) db(ctx context.Context) trmsqlx.Tr {
 
 func (r *DefaultRepository) Insert(ctx context.Context, in *model.Session) error {
 	const query = `
-		insert into player_session (game_id, player_id, status) values ($1, $2, $3)
+		insert into player_session (game_id, player_id, status, question_ids, shuffle_seed, attempt) values ($1, $2, $3, $4, $5, $6)
 	`
 
-	_, err := r.db(ctx).ExecContext(ctx, query, in.GameID, in.PlayerID, in.Status)
+	_, err := r.db(ctx).ExecContext(ctx, query, in.GameID, in.PlayerID, in.Status, marshalQuestionIDs(in.QuestionIDs), in.ShuffleSeed, in.Attempt)
 	return err
 }
 
@@ -89,11 +130,25 @@ func (r *DefaultRepository) Update(ctx context.Context, in *model.Session) error
 	return err
 }
 
+func (r *DefaultRepository) UpdateStatusByGameID(ctx context.Context, gameID uuid.UUID, from model.SessionStatus, to model.SessionStatus) error {
+	const query = `
+		update player_session set 
+			status = $3,
+			updated_at = now()
+		where game_id = $1 and status = $2
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, gameID, from, to)
+	return err
+}
+
+// GetBySpec возвращает последнюю попытку игрока
 func (r *DefaultRepository) GetBySpec(ctx context.Context, spec *Spec) (*model.Session, error) {
 	const query = `
-		select id, game_id, player_id, status, created_at
+		select id, game_id, player_id, status, question_ids, shuffle_seed, attempt, created_at
 		from player_session 
 		where player_id = $1 and game_id = $2
+		order by attempt desc
 		limit 1
 	`
 
@@ -106,22 +161,80 @@ func (r *DefaultRepository) GetBySpec(ctx context.Context, spec *Spec) (*model.S
 		return nil, err
 	}
 
-	return &model.Session{
-		ID:        result.ID,
-		GameID:    result.GameID,
-		PlayerID:  result.PlayerID,
-		Status:    model.SessionStatus(result.Status),
-		CreatedAt: result.CreatedAt,
-	}, nil
+	return convertSession(&result)
+}
+
+func (r *DefaultRepository) GetAttemptsBySpec(ctx context.Context, spec *Spec) ([]model.Session, error) {
+	const query = `
+		select id, game_id, player_id, status, question_ids, shuffle_seed, attempt, created_at
+		from player_session 
+		where player_id = $1 and game_id = $2
+		order by attempt
+	`
+
+	var result []sqlxSession
+	if err := r.db(ctx).SelectContext(ctx, &result, query, spec.PlayerID, spec.GameID); err != nil {
+		return nil, err
+	}
+
+	return slices.Map(result, func(in sqlxSession) (model.Session, error) {
+		out, err := convertSession(&in)
+		if err != nil {
+			return model.Session{}, err
+		}
+		return *out, nil
+	})
+}
+
+// AbandonStale закрывает прохождения, в которых игрок не отвечал дольше, чем разрешено настройками игры.
+// В live-игре игроки ждут ведущего, поэтому ее прохождения не трогаются
+func (r *DefaultRepository) AbandonStale(ctx context.Context, now time.Time) ([]model.Session, error) {
+	const query = `
+		update player_session as ps set
+			status = $2,
+			updated_at = now()
+		from game_settings as gs, game as g
+		where gs.game_id = ps.game_id
+		  and g.id = ps.game_id
+		  and g."type" <> $4
+		  and ps.status = $3
+		  and gs.inactivity_timeout is not null
+		  and coalesce(
+		      (select max(psi.answered_at) from player_session_item as psi where psi.session_id = ps.id),
+		      ps.created_at
+		  ) < $1::timestamptz - make_interval(secs => gs.inactivity_timeout)
+		returning ps.id, ps.game_id, ps.player_id, ps.status, ps.question_ids, ps.shuffle_seed, ps.attempt, ps.created_at
+	`
+
+	var result []sqlxSession
+	if err := r.db(ctx).SelectContext(
+		ctx,
+		&result,
+		query,
+		now,
+		model.SessionStatusAbandoned,
+		model.SessionStatusStarted,
+		model.GameTypeLive,
+	); err != nil {
+		return nil, err
+	}
+
+	return slices.Map(result, func(in sqlxSession) (model.Session, error) {
+		out, err := convertSession(&in)
+		if err != nil {
+			return model.Session{}, err
+		}
+		return *out, nil
+	})
 }
 
 func (r *DefaultRepository) InsertSessionItem(ctx context.Context, in *model.SessionItem) error {
 	const query = `
-		insert into player_session_item (session_id, question_id, answers, is_correct, answered_at) 
-		values ($1, $2, $3, $4, $5)
+		insert into player_session_item (session_id, question_id, answers, is_correct, answered_at, score) 
+		values ($1, $2, $3, $4, $5, $6)
 	`
 
-	answers, err := json.Marshal(in.Answers)
+	answers, err := marshalAnswers(in.Answers)
 	if err != nil {
 		return err
 	}
@@ -134,10 +247,31 @@ func (r *DefaultRepository) InsertSessionItem(ctx context.Context, in *model.Ses
 		answers,
 		in.IsCorrect,
 		in.AnsweredAt,
+		in.Score,
 	)
 	return err
 }
 
+func (r *DefaultRepository) UpdateSessionItem(ctx context.Context, in *model.SessionItem) error {
+	const query = `
+		update player_session_item set
+			answers = $2,
+			is_correct = $3,
+			answered_at = $4,
+			score = $5,
+			updated_at = now()
+		where id = $1
+	`
+
+	answers, err := marshalAnswers(in.Answers)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db(ctx).ExecContext(ctx, query, in.ID, answers, in.IsCorrect, in.AnsweredAt, in.Score)
+	return err
+}
+
 func (r *DefaultRepository) DeleteSessionItemsBySessionID(ctx context.Context, sessionID int64) error {
 	const query = `
 		delete from player_session_item where session_id = $1
@@ -149,16 +283,20 @@ func (r *DefaultRepository) DeleteSessionItemsBySessionID(ctx context.Context, s
 
 func (r *DefaultRepository) GetSessionBySpec(ctx context.Context, spec *ItemSpec) ([]model.SessionItem, error) {
 	const query = `
-		select psi.id, psi.session_id, psi.question_id, psi.answers, psi.is_correct, psi.answered_at, psi.created_at
+		select psi.id, psi.session_id, psi.question_id, q.type as question_type, psi.answers, psi.is_correct, psi.answered_at, psi.created_at, psi.review_comment, psi.score
 		from player_session_item as psi
 		inner join player_session as ps on ps.id = psi.session_id
+		inner join question as q on q.id = psi.question_id
 		where ps.player_id = $1 
 	      and ps.game_id = $2
 	      and ($3::UUID is null or psi.question_id = $3::UUID)
+	      and ($4::bool or ps.attempt = (
+	          select max(attempt) from player_session where player_id = $1 and game_id = $2
+	      ))
 	`
 
 	var result []sqlxSessionItem
-	if err := r.db(ctx).SelectContext(ctx, &result, query, spec.PlayerID, spec.GameID, spec.QuestionID); err != nil {
+	if err := r.db(ctx).SelectContext(ctx, &result, query, spec.PlayerID, spec.GameID, spec.QuestionID, spec.AllAttempts); err != nil {
 		return nil, err
 	}
 
@@ -171,8 +309,175 @@ func (r *DefaultRepository) GetSessionBySpec(ctx context.Context, spec *ItemSpec
 	})
 }
 
+func (r *DefaultRepository) GetPendingReviewItems(ctx context.Context, gameID uuid.UUID) ([]model.ReviewItem, error) {
+	const query = `
+		select psi.id, psi.session_id, psi.question_id, q.type as question_type, psi.answers, psi.is_correct, psi.answered_at, psi.created_at, psi.review_comment, psi.score, ps.player_id
+		from player_session_item as psi
+		inner join player_session as ps on ps.id = psi.session_id
+		inner join question as q on q.id = psi.question_id
+		where ps.game_id = $1
+		  and q.type = $2
+		  and psi.answered_at is not null
+		  and psi.is_correct is null
+		order by psi.answered_at
+	`
+
+	var result []sqlxReviewItem
+	if err := r.db(ctx).SelectContext(ctx, &result, query, gameID, model.QuestionTypeOpenText); err != nil {
+		return nil, err
+	}
+
+	return slices.Map(result, func(in sqlxReviewItem) (model.ReviewItem, error) {
+		item, err := convertSessionItem(&in.sqlxSessionItem)
+		if err != nil {
+			return model.ReviewItem{}, err
+		}
+
+		return model.ReviewItem{
+			SessionItem: *item,
+			PlayerID:    in.PlayerID,
+		}, nil
+	})
+}
+
+func (r *DefaultRepository) UpdateSessionItemReview(ctx context.Context, in *UpdateReviewIn) (*model.ReviewItem, error) {
+	const query = `
+		update player_session_item as psi set
+			is_correct = $3,
+			review_comment = $4,
+			reviewed_at = now(),
+			updated_at = now()
+		from player_session as ps, question as q
+		where ps.id = psi.session_id
+		  and q.id = psi.question_id
+		  and psi.id = $1
+		  and ps.game_id = $2
+		  and q.type = $5
+		  and psi.answered_at is not null
+		returning psi.question_id, ps.player_id, psi.answered_at, psi.created_at
+	`
+
+	// проверить вручную можно только данный развернутый ответ, результат остальных вопросов считается автоматически
+	var result sqlxReviewedItem
+	if err := r.db(ctx).GetContext(ctx, &result, query, in.ItemID, in.GameID, in.IsCorrect, in.Comment, model.QuestionTypeOpenText); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, contracts.ErrSessionItemNotFound
+		}
+
+		return nil, err
+	}
+
+	return &model.ReviewItem{
+		SessionItem: model.SessionItem{
+			ID:            in.ItemID,
+			QuestionID:    result.QuestionID,
+			IsCorrect:     &in.IsCorrect,
+			AnsweredAt:    result.AnsweredAt,
+			CreatedAt:     result.CreatedAt,
+			ReviewComment: in.Comment,
+		},
+		PlayerID: result.PlayerID,
+	}, nil
+}
+
+func (r *DefaultRepository) UpdateSessionItemScore(ctx context.Context, itemID int64, score *float64) error {
+	const query = `
+		update player_session_item set
+			score = $2,
+			updated_at = now()
+		where id = $1
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, itemID, score)
+	return err
+}
+
+func (r *DefaultRepository) GetAnswerVotes(ctx context.Context, questionID uuid.UUID) (map[string]int64, error) {
+	const query = `
+		select answer, count(*) as count
+		from player_session_item as psi
+		cross join jsonb_array_elements_text(psi.answers) as answer
+		where psi.question_id = $1
+		  and psi.answered_at is not null
+		group by answer
+	`
+
+	var result []sqlxAnswerVotes
+	if err := r.db(ctx).SelectContext(ctx, &result, query, questionID); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]int64, len(result))
+	for _, item := range result {
+		out[item.Answer] = item.Count
+	}
+
+	return out, nil
+}
+
+// GetLeaderboard при равенстве результатов выше тот, кто прошел игру быстрее.
+// От каждого игрока в таблицу попадает одна попытка, выбранная по AttemptPolicy, лучшая попытка выбирается по тому же показателю, что и порядок таблицы
+func (r *DefaultRepository) GetLeaderboard(ctx context.Context, spec *LeaderboardSpec) ([]model.LeaderboardEntry, error) {
+	const query = `
+		with attempts as (
+			select ps.player_id,
+			       ps.attempt,
+			       coalesce(sum(psi.score), 0) as score,
+			       count(psi.id) filter (where psi.is_correct) as correct_answers_count,
+			       coalesce(extract(epoch from max(psi.answered_at) - ps.created_at) * 1000, 0)::bigint as duration_ms
+			from player_session as ps
+			left join player_session_item as psi on psi.session_id = ps.id
+			where ps.game_id = $1
+			  and ps.status = $2
+			group by ps.id, ps.player_id, ps.attempt
+		),
+		results as (
+			select *, row_number() over (
+				partition by player_id
+				order by case when $6::text = $7::text then attempt end,
+				         case when $6::text = $8::text then attempt end desc,
+				         case when $3::text = $4::text then correct_answers_count::numeric else score end desc,
+				         case when $3::text = $4::text then score else correct_answers_count::numeric end desc,
+				         attempt
+			) as attempt_rank
+			from attempts
+		)
+		select player_id, score, correct_answers_count, duration_ms
+		from results
+		where attempt_rank = 1
+		order by case when $3::text = $4::text then correct_answers_count::numeric else score end desc, duration_ms
+		limit $5
+	`
+
+	var result []sqlxLeaderboardEntry
+	if err := r.db(ctx).SelectContext(
+		ctx,
+		&result,
+		query,
+		spec.GameID,
+		model.SessionStatusFinished,
+		spec.Order,
+		model.LeaderboardOrderCorrectAnswers,
+		spec.Limit,
+		spec.AttemptPolicy,
+		model.AttemptPolicyFirst,
+		model.AttemptPolicyLast,
+	); err != nil {
+		return nil, err
+	}
+
+	return slices.SafeMap(result, func(in sqlxLeaderboardEntry) model.LeaderboardEntry {
+		return model.LeaderboardEntry{
+			PlayerID:            in.PlayerID,
+			Score:               in.Score,
+			CorrectAnswersCount: in.CorrectAnswersCount,
+			Duration:            time.Duration(in.DurationMs) * time.Millisecond,
+		}
+	}), nil
+}
+
 func (r *DefaultRepository) GetExtendedSessionsBySpec(ctx context.Context, spec *GetExtendedSessionSpec) (*GetExtendedSessionsBySpecOut, error) {
-	total, err := r.getBySpecTotalCount(ctx, spec)
+	counts, err := r.getBySpecCounts(ctx, spec)
 	if err != nil {
 		return nil, err
 	}
@@ -183,13 +488,15 @@ func (r *DefaultRepository) GetExtendedSessionsBySpec(ctx context.Context, spec
 	}
 
 	return &GetExtendedSessionsBySpecOut{
-		Result:     out,
-		TotalCount: total,
+		Result:         out,
+		TotalCount:     counts.TotalCount,
+		FinishedCount:  counts.FinishedCount,
+		AbandonedCount: counts.AbandonedCount,
 	}, nil
 }
 
 func (r *DefaultRepository) getExtendedSessionsBySpec(ctx context.Context, spec *GetExtendedSessionSpec) ([]model.ExtendedSession, error) {
-	query := buildBaseGetExtendedSessionsBySpecQuery("ps.id, ps.game_id, ps.player_id, ps.status, ps.created_at, psi.id as item_id, psi.question_id as item_question_id, psi.answers as item_answers, psi.is_correct as item_is_correct, psi.answered_at as item_answered_at, psi.created_at as item_created_at")
+	query := buildBaseGetExtendedSessionsBySpecQuery("ps.id, ps.game_id, ps.player_id, ps.status, ps.question_ids, ps.attempt, ps.created_at, psi.id as item_id, psi.question_id as item_question_id, q.type as item_question_type, psi.answers as item_answers, psi.is_correct as item_is_correct, psi.answered_at as item_answered_at, psi.created_at as item_created_at, psi.score as item_score")
 
 	limit := defaultLimit
 	offset := int64(0)
@@ -199,7 +506,7 @@ func (r *DefaultRepository) getExtendedSessionsBySpec(ctx context.Context, spec
 	}
 
 	var result []sqlxSessionExtended
-	if err := r.db(ctx).SelectContext(ctx, &result, query, spec.GameID, limit, offset); err != nil {
+	if err := r.db(ctx).SelectContext(ctx, &result, query, spec.GameID, limit, offset, spec.AllAttempts); err != nil {
 		return nil, err
 	}
 
@@ -207,13 +514,20 @@ func (r *DefaultRepository) getExtendedSessionsBySpec(ctx context.Context, spec
 	for _, item := range result {
 		session, ok := resultMap[item.ID]
 		if !ok {
+			questionIDs, err := unmarshalQuestionIDs(item.QuestionIDs)
+			if err != nil {
+				return nil, err
+			}
+
 			session = model.ExtendedSession{
 				Session: model.Session{
-					ID:        item.ID,
-					GameID:    item.GameID,
-					PlayerID:  item.PlayerID,
-					Status:    model.SessionStatus(item.Status),
-					CreatedAt: item.CreatedAt,
+					ID:          item.ID,
+					GameID:      item.GameID,
+					PlayerID:    item.PlayerID,
+					Status:      model.SessionStatus(item.Status),
+					QuestionIDs: questionIDs,
+					Attempt:     item.Attempt,
+					CreatedAt:   item.CreatedAt,
 				},
 				Items: make([]model.SessionItem, 0, 1),
 			}
@@ -221,13 +535,18 @@ func (r *DefaultRepository) getExtendedSessionsBySpec(ctx context.Context, spec
 
 		if item.ItemID != nil {
 			sessionItems := resultMap[item.ID].Items
-			sessionItems = append(sessionItems, model.SessionItem{
+			sessionItem := model.SessionItem{
 				ID:         *item.ItemID,
 				QuestionID: *item.ItemQuestionID,
 				IsCorrect:  item.ItemIsCorrect,
 				AnsweredAt: item.ItemAnsweredAt,
 				CreatedAt:  *item.ItemCreatedAt,
-			})
+				Score:      item.ItemScore,
+			}
+			if item.ItemQuestionType != nil {
+				sessionItem.QuestionType = model.QuestionType(*item.ItemQuestionType)
+			}
+			sessionItems = append(sessionItems, sessionItem)
 			session.Items = sessionItems
 		}
 
@@ -243,10 +562,16 @@ func (r *DefaultRepository) getExtendedSessionsBySpec(ctx context.Context, spec
 	return out, nil
 }
 
-func (r *DefaultRepository) getBySpecTotalCount(ctx context.Context, spec *GetExtendedSessionSpec) (int64, error) {
-	query := buildBaseGetExtendedSessionsBySpecQuery("count(distinct(ps.id))")
+func (r *DefaultRepository) getBySpecCounts(ctx context.Context, spec *GetExtendedSessionSpec) (*sqlxSessionCounts, error) {
+	query := buildBaseGetExtendedSessionsBySpecQuery(fmt.Sprintf(
+		`count(distinct(ps.id)) as total_count,
+		count(distinct(ps.id)) filter (where ps.status = '%s') as finished_count,
+		count(distinct(ps.id)) filter (where ps.status = '%s') as abandoned_count`,
+		model.SessionStatusFinished,
+		model.SessionStatusAbandoned,
+	))
 
-	var result int64
+	var result sqlxSessionCounts
 	if err := r.db(ctx).GetContext(
 		ctx,
 		&result,
@@ -254,18 +579,25 @@ func (r *DefaultRepository) getBySpecTotalCount(ctx context.Context, spec *GetEx
 		spec.GameID,
 		defaultLimit,
 		0,
+		spec.AllAttempts,
 	); err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return result, nil
+	return &result, nil
 }
 
 func buildBaseGetExtendedSessionsBySpecQuery(fields string) string {
 	return fmt.Sprintf(` 
         with session_ids as (
-    		select id from player_session
+    		select id from player_session as last
 			where game_id = $1
+			  and ($4::bool or not exists (
+			      select 1 from player_session as next
+			      where next.game_id = last.game_id
+			        and next.player_id = last.player_id
+			        and next.attempt > last.attempt
+			  ))
 			order by created_at desc
     		limit $2 
 	    	offset $3
@@ -274,9 +606,61 @@ func buildBaseGetExtendedSessionsBySpecQuery(fields string) string {
 		from player_session ps
 		inner join session_ids on ps.id = session_ids.id
 		left join player_session_item as psi on psi.session_id = ps.id
+		left join question as q on q.id = psi.question_id
 	`, fields)
 }
 
+// marshalQuestionIDs если игроку доступны все вопросы игры, сохраняется null
+func marshalQuestionIDs(in []uuid.UUID) pq.StringArray {
+	if in == nil {
+		return nil
+	}
+
+	return slices.SafeMap(in, func(id uuid.UUID) string {
+		return id.String()
+	})
+}
+
+func unmarshalQuestionIDs(in pq.StringArray) ([]uuid.UUID, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	return slices.Map(in, uuid.Parse)
+}
+
+// marshalAnswers для вопроса без ответа сохраняется null, а не пустой массив
+func marshalAnswers(in []string) (sql.Null[[]byte], error) {
+	if in == nil {
+		return sql.Null[[]byte]{}, nil
+	}
+
+	out, err := json.Marshal(in)
+	if err != nil {
+		return sql.Null[[]byte]{}, err
+	}
+
+	return sql.Null[[]byte]{V: out, Valid: true}, nil
+}
+
+func convertSession(in *sqlxSession) (*model.Session, error) {
+	questionIDs, err := unmarshalQuestionIDs(in.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Session{
+		ID:          in.ID,
+		GameID:      in.GameID,
+		PlayerID:    in.PlayerID,
+		Status:      model.SessionStatus(in.Status),
+		QuestionIDs: questionIDs,
+		ShuffleSeed: in.ShuffleSeed,
+		Attempt:     in.Attempt,
+		CreatedAt:   in.CreatedAt,
+	}, nil
+}
+
 func convertSessionItem(in *sqlxSessionItem) (*model.SessionItem, error) {
 	var answers []string
 	if in.Answers != nil {
@@ -286,11 +670,15 @@ func convertSessionItem(in *sqlxSessionItem) (*model.SessionItem, error) {
 	}
 
 	return &model.SessionItem{
-		ID:         in.ID,
-		SessionID:  in.SessionID,
-		QuestionID: in.QuestionID,
-		Answers:    answers,
-		IsCorrect:  in.IsCorrect,
-		AnsweredAt: in.AnsweredAt,
+		ID:            in.ID,
+		SessionID:     in.SessionID,
+		QuestionID:    in.QuestionID,
+		QuestionType:  model.QuestionType(in.QuestionType),
+		Answers:       answers,
+		IsCorrect:     in.IsCorrect,
+		AnsweredAt:    in.AnsweredAt,
+		CreatedAt:     in.CreatedAt,
+		ReviewComment: in.ReviewComment,
+		Score:         in.Score,
 	}, nil
 }
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/internal/quizzly/repositories/game"
+	"quizzly/pkg/structs"
+	"time"
+)
+
+func (u *Usecase) GetLiveState(ctx context.Context, gameID uuid.UUID) (*model.GameLiveState, error) {
+	if _, err := u.getLiveGame(ctx, gameID); err != nil {
+		return nil, err
+	}
+
+	return u.games.GetLiveState(ctx, gameID)
+}
+
+func (u *Usecase) OpenLiveQuestion(ctx context.Context, gameID uuid.UUID, questionID uuid.UUID) error {
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		if _, err := u.getStartedLiveGame(ctx, gameID); err != nil {
+			return err
+		}
+
+		questions, err := u.games.GetQuestionsBySpec(ctx, &game.QuestionsSpec{
+			IDs:    []uuid.UUID{questionID},
+			GameID: &gameID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(questions) == 0 {
+			return contracts.ErrEmptyQuestions
+		}
+
+		return u.games.UpsertLiveState(ctx, &model.GameLiveState{
+			GameID:            gameID,
+			CurrentQuestionID: &questionID,
+			QuestionOpenedAt:  structs.Pointer(time.Now()),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	u.publish(model.GameEvent{
+		Type:       model.GameEventLiveQuestionOpened,
+		GameID:     gameID,
+		QuestionID: &questionID,
+	})
+	return nil
+}
+
+func (u *Usecase) CloseLiveQuestion(ctx context.Context, gameID uuid.UUID) error {
+	var closedQuestionID *uuid.UUID
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		if _, err := u.getStartedLiveGame(ctx, gameID); err != nil {
+			return err
+		}
+
+		state, err := u.games.GetLiveState(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if state.CurrentQuestionID == nil || state.QuestionClosedAt != nil {
+			return nil
+		}
+
+		state.QuestionClosedAt = structs.Pointer(time.Now())
+		closedQuestionID = state.CurrentQuestionID
+		return u.games.UpsertLiveState(ctx, state)
+	})
+	if err != nil || closedQuestionID == nil {
+		return err
+	}
+
+	u.publish(model.GameEvent{
+		Type:       model.GameEventLiveQuestionClosed,
+		GameID:     gameID,
+		QuestionID: closedQuestionID,
+	})
+	return nil
+}
+
+func (u *Usecase) NextLiveQuestion(ctx context.Context, gameID uuid.UUID) error {
+	var openedQuestionID *uuid.UUID
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		if _, err := u.getStartedLiveGame(ctx, gameID); err != nil {
+			return err
+		}
+
+		state, err := u.games.GetLiveState(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		questions, err := u.games.GetQuestionsBySpec(ctx, &game.QuestionsSpec{GameID: &gameID})
+		if err != nil {
+			return err
+		}
+		if len(questions) == 0 {
+			return contracts.ErrEmptyQuestions
+		}
+
+		nextIndex := 0
+		if state.CurrentQuestionID != nil {
+			nextIndex = len(questions)
+			for i, question := range questions {
+				if question.ID == *state.CurrentQuestionID {
+					nextIndex = i + 1
+					break
+				}
+			}
+		}
+		if nextIndex >= len(questions) {
+			return contracts.ErrQuestionQueueIsEmpty
+		}
+
+		openedQuestionID = &questions[nextIndex].ID
+		return u.games.UpsertLiveState(ctx, &model.GameLiveState{
+			GameID:            gameID,
+			CurrentQuestionID: openedQuestionID,
+			QuestionOpenedAt:  structs.Pointer(time.Now()),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	u.publish(model.GameEvent{
+		Type:       model.GameEventLiveQuestionOpened,
+		GameID:     gameID,
+		QuestionID: openedQuestionID,
+	})
+	return nil
+}
+
+func (u *Usecase) getLiveGame(ctx context.Context, gameID uuid.UUID) (*model.Game, error) {
+	specificGame, err := u.Get(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if specificGame.Type != model.GameTypeLive {
+		return nil, contracts.ErrGameIsNotLive
+	}
+
+	return specificGame, nil
+}
+
+func (u *Usecase) getStartedLiveGame(ctx context.Context, gameID uuid.UUID) (*model.Game, error) {
+	specificGame, err := u.getLiveGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if specificGame.Status != model.GameStatusStarted {
+		return nil, contracts.ErrGameNotStarted
+	}
+
+	return specificGame, nil
+}
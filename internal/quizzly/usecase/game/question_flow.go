@@ -0,0 +1,60 @@
+package game
+
+import (
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+)
+
+// validateQuestionFlow при ветвлении каждый вопрос должен быть достижим из первого, а маршрут не должен зацикливаться
+func validateQuestionFlow(specificGame *model.Game, questions []model.Question) error {
+	if !model.IsBranching(questions) {
+		return nil
+	}
+
+	// Порядок вопросов в live-игре задает ведущий, а при перемешивании и выборе из пула маршрут случайный
+	if specificGame.Type == model.GameTypeLive || specificGame.Settings.ShuffleQuestions || specificGame.Settings.QuestionPoolSize != nil {
+		return contracts.ErrQuestionFlowNotSupported
+	}
+
+	const (
+		notVisited = iota
+		inProgress
+		visited
+	)
+
+	states := make([]int, len(questions))
+	var visit func(index int) error
+	visit = func(index int) error {
+		switch states[index] {
+		case inProgress:
+			return contracts.ErrQuestionFlowCycle
+		case visited:
+			return nil
+		}
+
+		states[index] = inProgress
+		for _, next := range model.NextQuestionIndexes(questions, index) {
+			if next < 0 {
+				continue
+			}
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		states[index] = visited
+
+		return nil
+	}
+
+	if err := visit(0); err != nil {
+		return err
+	}
+
+	for _, state := range states {
+		if state != visited {
+			return contracts.ErrQuestionFlowUnreachable
+		}
+	}
+
+	return nil
+}
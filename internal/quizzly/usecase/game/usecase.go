@@ -8,23 +8,29 @@ import (
 	"quizzly/internal/quizzly/model"
 	"quizzly/internal/quizzly/repositories/game"
 	"quizzly/internal/quizzly/repositories/session"
+	"quizzly/pkg/broker"
 	"quizzly/pkg/structs"
+	"slices"
+	"time"
 )
 
 type Usecase struct {
 	games    game.Repository
 	sessions session.Repository
+	events   broker.Broker[model.GameEvent]
 	trm      trm.Manager
 }
 
 func NewUsecase(
 	games game.Repository,
 	sessions session.Repository,
+	events broker.Broker[model.GameEvent],
 	trm trm.Manager,
 ) contracts.GameUsecase {
 	return &Usecase{
 		games:    games,
 		sessions: sessions,
+		events:   events,
 		trm:      trm,
 	}
 }
@@ -32,13 +38,18 @@ func NewUsecase(
 func (u *Usecase) Create(ctx context.Context, in *contracts.CreateGameIn) (uuid.UUID, error) {
 	id := uuid.New()
 
+	gameType := in.Type
+	if gameType == "" {
+		gameType = model.GameTypeAsync
+	}
+
 	return id, u.games.Upsert(
 		ctx,
 		&model.Game{
 			ID:       id,
 			AuthorID: in.AuthorID,
 			Status:   model.GameStatusCreated,
-			Type:     model.GameTypeAsync,
+			Type:     gameType,
 			Title:    in.Title,
 			Settings: in.Settings,
 		},
@@ -46,12 +57,50 @@ func (u *Usecase) Create(ctx context.Context, in *contracts.CreateGameIn) (uuid.
 
 }
 
+func (u *Usecase) ImportGame(ctx context.Context, in *contracts.CreateGameIn, questions []model.Question) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = u.Create(ctx, in)
+		if err != nil {
+			return err
+		}
+
+		// переход может вести на вопрос, который еще не создан, поэтому переходы сохраняются после всех вопросов
+		for i := range questions {
+			questions[i].GameID = id
+			question := withoutNextQuestions(questions[i])
+			if err = u.CreateQuestion(ctx, &question); err != nil {
+				return &contracts.QuestionError{Number: i + 1, Err: err}
+			}
+			questions[i].ID = question.ID
+			questions[i].Points = question.Points
+		}
+
+		for i := range questions {
+			if !hasNextQuestions(&questions[i]) {
+				continue
+			}
+			if err = u.games.UpdateQuestion(ctx, &questions[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
 func (u *Usecase) Update(ctx context.Context, in *model.Game) error {
 	return u.games.Upsert(ctx, in)
 }
 
 func (u *Usecase) Start(ctx context.Context, id uuid.UUID) error {
-	return u.trm.Do(ctx, func(ctx context.Context) error {
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
 		specificGames, err := u.games.GetBySpec(ctx, &game.Spec{
 			IDs: []uuid.UUID{id},
 		})
@@ -71,14 +120,23 @@ func (u *Usecase) Start(ctx context.Context, id uuid.UUID) error {
 		if len(questions) == 0 {
 			return contracts.ErrEmptyQuestions
 		}
+		if err = validateQuestionFlow(&specificGame, questions); err != nil {
+			return err
+		}
 
 		specificGame.Status = model.GameStatusStarted
 		return u.games.Upsert(ctx, &specificGame)
 	})
+	if err != nil {
+		return err
+	}
+
+	u.publish(model.GameEvent{Type: model.GameEventStarted, GameID: id})
+	return nil
 }
 
 func (u *Usecase) Finish(ctx context.Context, id uuid.UUID) error {
-	return u.trm.Do(ctx, func(ctx context.Context) error {
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
 		specificGames, err := u.games.GetBySpec(ctx, &game.Spec{
 			IDs: []uuid.UUID{id},
 		})
@@ -91,8 +149,23 @@ func (u *Usecase) Finish(ctx context.Context, id uuid.UUID) error {
 
 		specificGame := specificGames[0]
 		specificGame.Status = model.GameStatusFinished
-		return u.games.Upsert(ctx, &specificGame)
+		if err := u.games.Upsert(ctx, &specificGame); err != nil {
+			return err
+		}
+
+		if specificGame.Type != model.GameTypeLive {
+			return nil
+		}
+
+		// в live-игре прохождение заканчивается вместе с игрой
+		return u.sessions.UpdateStatusByGameID(ctx, specificGame.ID, model.SessionStatusStarted, model.SessionStatusFinished)
 	})
+	if err != nil {
+		return err
+	}
+
+	u.publish(model.GameEvent{Type: model.GameEventFinished, GameID: id})
+	return nil
 }
 
 func (u *Usecase) Get(ctx context.Context, id uuid.UUID) (*model.Game, error) {
@@ -123,18 +196,58 @@ func (u *Usecase) GetPublic(ctx context.Context) ([]model.Game, error) {
 	})
 }
 
+func (u *Usecase) GetScheduled(ctx context.Context, at time.Time) ([]model.Game, error) {
+	return u.games.GetBySpec(ctx, &game.Spec{
+		ScheduledBefore: &at,
+	})
+}
+
 func (u *Usecase) CreateQuestion(ctx context.Context, in *model.Question) error {
-	if len(in.AnswerOptions) == 0 {
-		return contracts.ErrEmptyAnswerOptions
+	if err := validateAnswerOptions(in); err != nil {
+		return err
 	}
 
 	if in.ID == uuid.Nil {
 		in.ID = uuid.New()
 	}
+	if in.Points <= 0 {
+		in.Points = model.DefaultQuestionPoints
+	}
 
 	return u.games.InsertQuestion(ctx, in)
 }
 
+func (u *Usecase) ImportQuestions(ctx context.Context, gameID uuid.UUID, title *string, questions []model.Question) error {
+	return u.trm.Do(ctx, func(ctx context.Context) error {
+		specificGames, err := u.games.GetBySpec(ctx, &game.Spec{
+			IDs: []uuid.UUID{gameID},
+		})
+		if err != nil {
+			return err
+		}
+		if len(specificGames) == 0 {
+			return contracts.ErrGameNotFound
+		}
+		if specificGames[0].Status != model.GameStatusCreated {
+			return contracts.ErrGameAlreadyStarted
+		}
+
+		for i := range questions {
+			questions[i].GameID = gameID
+			if err := u.CreateQuestion(ctx, &questions[i]); err != nil {
+				return &contracts.QuestionError{Number: i + 1, Err: err}
+			}
+		}
+
+		if title == nil {
+			return nil
+		}
+
+		specificGames[0].Title = title
+		return u.games.Upsert(ctx, &specificGames[0])
+	})
+}
+
 func (u *Usecase) UpdateQuestion(ctx context.Context, in *model.Question) error {
 	return u.games.UpdateQuestion(ctx, in)
 }
@@ -143,6 +256,53 @@ func (u *Usecase) DeleteQuestion(ctx context.Context, id uuid.UUID) error {
 	return u.games.DeleteQuestion(ctx, id)
 }
 
+func (u *Usecase) SetNextQuestion(ctx context.Context, in *contracts.SetNextQuestionIn) error {
+	if in.NextQuestionID != nil && *in.NextQuestionID == in.QuestionID {
+		return contracts.ErrQuestionFlowCycle
+	}
+
+	return u.trm.Do(ctx, func(ctx context.Context) error {
+		specificGame, err := u.Get(ctx, in.GameID)
+		if err != nil {
+			return err
+		}
+		// переходы проверяются только при запуске игры, поэтому после запуска их менять нельзя
+		if specificGame.Status != model.GameStatusCreated {
+			return contracts.ErrGameAlreadyStarted
+		}
+
+		questions, err := u.games.GetQuestionsBySpec(ctx, &game.QuestionsSpec{GameID: &in.GameID})
+		if err != nil {
+			return err
+		}
+
+		index := slices.IndexFunc(questions, func(question model.Question) bool {
+			return question.ID == in.QuestionID
+		})
+		if index < 0 {
+			return contracts.ErrQuestionNotFound
+		}
+		if in.NextQuestionID != nil && !slices.ContainsFunc(questions, func(question model.Question) bool {
+			return question.ID == *in.NextQuestionID
+		}) {
+			return contracts.ErrQuestionNotFound
+		}
+
+		if in.AnswerOptionID == nil {
+			return u.games.UpdateQuestionNextQuestion(ctx, in.GameID, in.QuestionID, in.NextQuestionID)
+		}
+
+		question := questions[index]
+		if !question.Type.HasAnswerBranching() || !slices.ContainsFunc(question.AnswerOptions, func(answerOption model.AnswerOption) bool {
+			return answerOption.ID == *in.AnswerOptionID
+		}) {
+			return contracts.ErrInvalidAnswerOptions
+		}
+
+		return u.games.UpdateAnswerOptionNextQuestion(ctx, in.QuestionID, *in.AnswerOptionID, in.NextQuestionID)
+	})
+}
+
 func (u *Usecase) GetQuestions(ctx context.Context, gameID uuid.UUID) ([]model.Question, error) {
 	result, err := u.games.GetQuestionsBySpec(ctx, &game.QuestionsSpec{
 		GameID: &gameID,
@@ -153,3 +313,93 @@ func (u *Usecase) GetQuestions(ctx context.Context, gameID uuid.UUID) ([]model.Q
 
 	return result, nil
 }
+
+func withoutNextQuestions(in model.Question) model.Question {
+	in.NextQuestionID = nil
+	in.AnswerOptions = slices.Clone(in.AnswerOptions)
+	for i := range in.AnswerOptions {
+		in.AnswerOptions[i].NextQuestionID = nil
+	}
+
+	return in
+}
+
+func hasNextQuestions(in *model.Question) bool {
+	if in.NextQuestionID != nil {
+		return true
+	}
+	for _, answerOption := range in.AnswerOptions {
+		if answerOption.NextQuestionID != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateAnswerOptions требования к вариантам ответа зависят от типа вопроса
+func validateAnswerOptions(in *model.Question) error {
+	if len(in.AnswerOptions) == 0 {
+		return contracts.ErrEmptyAnswerOptions
+	}
+
+	switch in.Type {
+	case model.QuestionTypePoll, model.QuestionTypeMultiplePoll:
+		if len(in.AnswerOptions) < 2 {
+			return contracts.ErrInvalidAnswerOptions
+		}
+		for _, answerOption := range in.AnswerOptions {
+			if answerOption.IsCorrect {
+				return contracts.ErrInvalidAnswerOptions
+			}
+		}
+		return nil
+	case model.QuestionTypeOpenText:
+		// единственный вариант хранит образец ответа для проверяющего
+		if len(in.AnswerOptions) != 1 || in.AnswerOptions[0].IsCorrect {
+			return contracts.ErrInvalidAnswerOptions
+		}
+		return nil
+	case model.QuestionTypeMatching:
+		for _, answerOption := range in.AnswerOptions {
+			if answerOption.Pair == nil {
+				return contracts.ErrInvalidAnswerOptions
+			}
+		}
+	case model.QuestionTypeOrdering, model.QuestionTypeCloze:
+		for _, answerOption := range in.AnswerOptions {
+			if answerOption.Position == nil {
+				return contracts.ErrInvalidAnswerOptions
+			}
+		}
+	case model.QuestionTypeHotspot:
+		if in.ImageID == nil {
+			return contracts.ErrInvalidAnswerOptions
+		}
+		for _, answerOption := range in.AnswerOptions {
+			if _, err := answerOption.GetRegion(); answerOption.Region == nil || err != nil {
+				return contracts.ErrInvalidAnswerOptions
+			}
+		}
+	case model.QuestionTypeNumeric:
+		for _, answerOption := range in.AnswerOptions {
+			if answerOption.MinValue == nil || answerOption.MaxValue == nil {
+				return contracts.ErrInvalidAnswerOptions
+			}
+		}
+	case model.QuestionTypeChoice, model.QuestionTypeOneOfChoice, model.QuestionTypeMultipleChoice, model.QuestionTypeFillTheGap:
+	default:
+		return contracts.ErrInvalidAnswerOptions
+	}
+
+	if len(in.GetCorrectAnswers()) == 0 {
+		return contracts.ErrNoCorrectAnswerOptions
+	}
+
+	return nil
+}
+
+// publish события отправляются только после успешного завершения транзакции
+func (u *Usecase) publish(event model.GameEvent) {
+	u.events.Publish(event.GameID.String(), event)
+}
@@ -0,0 +1,25 @@
+package session
+
+import (
+	"context"
+	"quizzly/internal/quizzly/model"
+	"time"
+)
+
+// AbandonStale брошенные прохождения не учитываются в результатах, вернувшись в игру, игрок продолжит с того же места
+func (u *Usecase) AbandonStale(ctx context.Context, at time.Time) error {
+	sessions, err := u.sessions.AbandonStale(ctx, at)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range sessions {
+		u.publish(model.GameEvent{
+			Type:     model.GameEventSessionAbandoned,
+			GameID:   item.GameID,
+			PlayerID: &item.PlayerID,
+		})
+	}
+
+	return nil
+}
@@ -6,18 +6,33 @@ import (
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
 	"quizzly/internal/quizzly/repositories/game"
+	"quizzly/internal/quizzly/repositories/session"
 	"quizzly/pkg/structs"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 func (u *Usecase) AcceptAnswers(ctx context.Context, in *contracts.AcceptAnswersIn) (*contracts.AcceptAnswersOut, error) {
-	var result *contracts.AcceptAnswersOut
-	return result, u.trm.Do(ctx, func(ctx context.Context) error {
-		if _, err := u.getActiveGame(ctx, in.GameID); err != nil {
+	var (
+		result    *contracts.AcceptAnswersOut
+		isCorrect *bool
+	)
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		specificGame, err := u.getActiveGame(ctx, in.GameID)
+		if err != nil {
 			return err
 		}
+		if specificGame.Type == model.GameTypeLive {
+			state, err := u.games.GetLiveState(ctx, specificGame.ID)
+			if err != nil {
+				return err
+			}
+			if !state.IsQuestionOpened(in.QuestionID) {
+				return contracts.ErrLiveQuestionClosed
+			}
+		}
 
 		specificSession, err := u.getSession(ctx, in.PlayerID, in.GameID)
 		if err != nil {
@@ -37,23 +52,121 @@ func (u *Usecase) AcceptAnswers(ctx context.Context, in *contracts.AcceptAnswers
 			return errors.New("question not found")
 		}
 
-		result, err = u.acceptAnswers(&specificQuestions[0], in.Answers)
-		result.RightAnswers = specificQuestions[0].GetCorrectAnswers()
+		question := &specificQuestions[0]
+		shownItem, err := u.getShownSessionItem(ctx, in.PlayerID, question)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		timeLimit := question.GetTimeLimit(specificGame.Settings)
+		if shownItem != nil && timeLimit != nil && now.After(shownItem.CreatedAt.Add(*timeLimit+contracts.AnswerGracePeriod)) {
+			result = &contracts.AcceptAnswersOut{
+				IsTimedOut: true,
+			}
+		} else {
+			result, err = u.acceptAnswers(question, in.Answers)
+			if err != nil {
+				return err
+			}
+		}
+		result.RightAnswers = question.GetCorrectAnswers()
+
+		// У опросов нет правильного ответа, а развернутый ответ проверяет автор игры,
+		// поэтому результат для них не сохраняется
+		if !question.Type.IsPoll() && !result.IsPendingReview {
+			isCorrect = structs.Pointer(result.IsCorrect)
+		}
+
+		var elapsed time.Duration
+		if shownItem != nil {
+			elapsed = now.Sub(shownItem.CreatedAt)
+		}
+
+		item := &model.SessionItem{
+			SessionID:  specificSession.ID,
+			QuestionID: in.QuestionID,
+			IsCorrect:  isCorrect,
+			Answers:    in.Answers,
+			AnsweredAt: structs.Pointer(now),
+			Score: calculateScore(specificGame.Settings, question, &scoreIn{
+				isCorrect:  isCorrect,
+				isTimedOut: result.IsTimedOut,
+				details:    result.Details,
+				elapsed:    elapsed,
+			}),
+		}
+		if result.IsTimedOut {
+			item.Answers = nil
+		}
+		if shownItem != nil {
+			item.ID = shownItem.ID
+			err = u.sessions.UpdateSessionItem(ctx, item)
+		} else {
+			err = u.sessions.InsertSessionItem(ctx, item)
+		}
 		if err != nil {
 			return err
 		}
 
-		return u.sessions.InsertSessionItem(
-			ctx,
-			&model.SessionItem{
-				SessionID:  specificSession.ID,
-				QuestionID: in.QuestionID,
-				IsCorrect:  structs.Pointer(result.IsCorrect),
-				Answers:    in.Answers,
-				AnsweredAt: structs.Pointer(time.Now()),
-			},
-		)
+		if question.Type.IsPoll() {
+			result.Votes, err = u.getAnswerVotes(ctx, question)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.publish(model.GameEvent{
+		Type:       model.GameEventAnswerAccepted,
+		GameID:     in.GameID,
+		PlayerID:   &in.PlayerID,
+		QuestionID: &in.QuestionID,
+		IsCorrect:  isCorrect,
+	})
+	return result, nil
+}
+
+// getShownSessionItem показанный игроку вопрос, на который еще нет ответа
+func (u *Usecase) getShownSessionItem(ctx context.Context, playerID uuid.UUID, question *model.Question) (*model.SessionItem, error) {
+	sessionItems, err := u.sessions.GetSessionBySpec(ctx, &session.ItemSpec{
+		PlayerID:   playerID,
+		GameID:     question.GameID,
+		QuestionID: &question.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range sessionItems {
+		if item.AnsweredAt == nil {
+			return &item, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (u *Usecase) getAnswerVotes(ctx context.Context, question *model.Question) ([]model.AnswerVotes, error) {
+	votes, err := u.sessions.GetAnswerVotes(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.AnswerVotes, 0, len(question.AnswerOptions))
+	for _, answerOption := range question.AnswerOptions {
+		result = append(result, model.AnswerVotes{
+			AnswerOption: answerOption,
+			Count:        votes[strconv.FormatInt(int64(answerOption.ID), 10)],
+		})
+	}
+
+	return result, nil
 }
 
 func (u *Usecase) acceptAnswers(question *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
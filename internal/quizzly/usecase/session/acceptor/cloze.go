@@ -0,0 +1,56 @@
+package acceptor
+
+import (
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+)
+
+type ClozeAcceptor struct{}
+
+func NewClozeAcceptor() *ClozeAcceptor {
+	return &ClozeAcceptor{}
+}
+
+func (a *ClozeAcceptor) Accept(question *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
+	gaps := make(map[int][]model.AnswerOption, len(question.AnswerOptions))
+	for _, answerOption := range question.AnswerOptions {
+		if answerOption.Position == nil {
+			continue
+		}
+
+		gaps[*answerOption.Position] = append(gaps[*answerOption.Position], answerOption)
+	}
+	if len(gaps) == 0 {
+		return nil, errors.New("no gaps defined")
+	}
+
+	result := &contracts.AcceptAnswersOut{
+		IsCorrect: len(answers) == len(gaps),
+		Details:   make([]contracts.AnswerResult, 0, len(answers)),
+	}
+	for i, answer := range answers {
+		// Пропуски нумеруются с единицы, ответы приходят в порядке пропусков
+		isCorrect := false
+		for _, answerOption := range gaps[i+1] {
+			ok, err := matchText(question.TextMatch, answerOption.Answer, answer)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				isCorrect = true
+				break
+			}
+		}
+		if !isCorrect {
+			result.IsCorrect = false
+		}
+
+		result.Details = append(result.Details, contracts.AnswerResult{
+			Answer:    answer,
+			IsCorrect: isCorrect,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package acceptor
+
+import (
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"testing"
+)
+
+func TestClozeAcceptor(t *testing.T) {
+	// у второго пропуска два допустимых ответа
+	question := &model.Question{
+		Type:      model.QuestionTypeCloze,
+		Text:      "{} - столица {}",
+		TextMatch: model.TextMatchSettings{Mode: model.TextMatchModeCaseInsensitive},
+		AnswerOptions: []model.AnswerOption{
+			{Answer: "Париж", IsCorrect: true, Position: structs.Pointer(1)},
+			{Answer: "Франции", IsCorrect: true, Position: structs.Pointer(2)},
+			{Answer: "Франция", IsCorrect: true, Position: structs.Pointer(2)},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		answers   []string
+		isCorrect bool
+		details   []bool
+	}{
+		{name: "all gaps", answers: []string{"париж", "Франции"}, isCorrect: true, details: []bool{true, true}},
+		{name: "synonym", answers: []string{"Париж", "франция"}, isCorrect: true, details: []bool{true, true}},
+		{name: "one gap wrong", answers: []string{"Париж", "Италии"}, details: []bool{true, false}},
+		{name: "gaps swapped", answers: []string{"Франции", "Париж"}, details: []bool{false, false}},
+		{name: "empty gap", answers: []string{"Париж", ""}, details: []bool{true, false}},
+		{name: "missing gap", answers: []string{"Париж"}, details: []bool{true}},
+		{name: "extra gap", answers: []string{"Париж", "Франции", "Париж"}, details: []bool{true, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewClozeAcceptor().Accept(question, tt.answers)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsCorrect != tt.isCorrect {
+				t.Errorf("expected is correct %v, got %v", tt.isCorrect, result.IsCorrect)
+			}
+			if len(result.Details) != len(tt.details) {
+				t.Fatalf("expected %d details, got %d", len(tt.details), len(result.Details))
+			}
+			for i, detail := range result.Details {
+				if detail.Answer != tt.answers[i] || detail.IsCorrect != tt.details[i] {
+					t.Errorf("gap %d: expected %v, got %+v", i+1, tt.details[i], detail)
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,15 @@ import (
 	"errors"
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
+	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// defaultFillTheGapDistance используется, если для вопроса не заданы правила проверки
+	defaultFillTheGapDistance = 2
 )
 
 type FillTheGapAcceptor struct{}
@@ -23,10 +31,18 @@ func (a *FillTheGapAcceptor) Accept(question *model.Question, answers []string)
 		return nil, errors.New("no correct answers defined")
 	}
 
-	distance := levenshteinDistance(correctAnswers[0].Answer, answers[0])
-	// Consider the answer correct if the Levenshtein distance is less than 2
-	// You can adjust this threshold based on your needs
-	isCorrect := distance <= 2
+	// Каждый правильный вариант ответа считается допустимым синонимом
+	isCorrect := false
+	for _, correctAnswer := range correctAnswers {
+		ok, err := matchText(question.TextMatch, correctAnswer.Answer, answers[0])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			isCorrect = true
+			break
+		}
+	}
 
 	return &contracts.AcceptAnswersOut{
 		IsCorrect: isCorrect,
@@ -39,33 +55,91 @@ func (a *FillTheGapAcceptor) Accept(question *model.Question, answers []string)
 	}, nil
 }
 
+// matchText сравнивает ответ игрока с правильным ответом по правилам вопроса.
+// Для регулярного выражения правильный ответ является шаблоном, который должен совпасть с ответом целиком
+func matchText(settings model.TextMatchSettings, expected string, actual string) (bool, error) {
+	if settings.Mode == model.TextMatchModeRegex {
+		re, err := regexp.Compile("^(?:" + expected + ")$")
+		if err != nil {
+			return false, err
+		}
+
+		return re.MatchString(strings.Join(strings.Fields(actual), " ")), nil
+	}
+
+	expected = normalizeText(expected)
+	actual = normalizeText(actual)
+	if actual == "" {
+		return false, nil
+	}
+
+	switch settings.Mode {
+	case model.TextMatchModeExact:
+		return expected == actual, nil
+	case model.TextMatchModeCaseInsensitive:
+		return strings.ToLower(expected) == strings.ToLower(actual), nil
+	}
+
+	expected = strings.ToLower(expected)
+	actual = strings.ToLower(actual)
+	distance := levenshteinDistance(expected, actual)
+	if settings.Ratio != nil {
+		maxLength := max(utf8.RuneCountInString(expected), utf8.RuneCountInString(actual))
+		return 1-float64(distance)/float64(maxLength) >= *settings.Ratio, nil
+	}
+
+	maxDistance := defaultFillTheGapDistance
+	if settings.Distance != nil {
+		maxDistance = *settings.Distance
+	}
+
+	return distance <= maxDistance, nil
+}
+
+// normalizeText убирает знаки препинания и лишние пробелы, заменяет ё на е
+func normalizeText(in string) string {
+	in = strings.Map(func(r rune) rune {
+		switch {
+		case r == 'ё':
+			return 'е'
+		case r == 'Ё':
+			return 'Е'
+		case unicode.IsPunct(r):
+			return -1
+		}
+		return r
+	}, in)
+
+	return strings.Join(strings.Fields(in), " ")
+}
+
 func levenshteinDistance(s1, s2 string) int {
-	s1 = strings.ToLower(s1)
-	s2 = strings.ToLower(s2)
+	r1 := []rune(s1)
+	r2 := []rune(s2)
 
-	if len(s1) == 0 {
-		return len(s2)
+	if len(r1) == 0 {
+		return len(r2)
 	}
-	if len(s2) == 0 {
-		return len(s1)
+	if len(r2) == 0 {
+		return len(r1)
 	}
 
-	matrix := make([][]int, len(s1)+1)
+	matrix := make([][]int, len(r1)+1)
 	for i := range matrix {
-		matrix[i] = make([]int, len(s2)+1)
+		matrix[i] = make([]int, len(r2)+1)
 	}
 
-	for i := 0; i <= len(s1); i++ {
+	for i := 0; i <= len(r1); i++ {
 		matrix[i][0] = i
 	}
-	for j := 0; j <= len(s2); j++ {
+	for j := 0; j <= len(r2); j++ {
 		matrix[0][j] = j
 	}
 
-	for i := 1; i <= len(s1); i++ {
-		for j := 1; j <= len(s2); j++ {
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j <= len(r2); j++ {
 			cost := 1
-			if s1[i-1] == s2[j-1] {
+			if r1[i-1] == r2[j-1] {
 				cost = 0
 			}
 			matrix[i][j] = min(
@@ -75,16 +149,5 @@ func levenshteinDistance(s1, s2 string) int {
 			)
 		}
 	}
-	return matrix[len(s1)][len(s2)]
-}
-
-func min(a, b, c int) int {
-	minResult := a
-	if b < minResult {
-		minResult = b
-	}
-	if c < minResult {
-		return c
-	}
-	return minResult
+	return matrix[len(r1)][len(r2)]
 }
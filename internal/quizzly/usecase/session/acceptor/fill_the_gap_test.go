@@ -0,0 +1,99 @@
+package acceptor
+
+import (
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"testing"
+)
+
+func TestFillTheGapAcceptor(t *testing.T) {
+	question := func(textMatch model.TextMatchSettings, answers ...string) *model.Question {
+		result := &model.Question{
+			Type:      model.QuestionTypeFillTheGap,
+			TextMatch: textMatch,
+		}
+		for _, answer := range answers {
+			result.AnswerOptions = append(result.AnswerOptions, model.AnswerOption{Answer: answer, IsCorrect: true})
+		}
+
+		return result
+	}
+	fuzzyDistance := model.TextMatchSettings{Mode: model.TextMatchModeFuzzy, Distance: structs.Pointer(1)}
+	fuzzyRatio := model.TextMatchSettings{Mode: model.TextMatchModeFuzzy, Ratio: structs.Pointer(0.8)}
+	regex := model.TextMatchSettings{Mode: model.TextMatchModeRegex}
+
+	tests := []struct {
+		name     string
+		question *model.Question
+		answer   string
+		expected bool
+	}{
+		{name: "default distance", question: question(model.TextMatchSettings{}, "Париж"), answer: "Парижж", expected: true},
+		{name: "default distance exceeded", question: question(model.TextMatchSettings{}, "Париж"), answer: "Па"},
+		{name: "default distance in runes", question: question(model.TextMatchSettings{}, "Москва"), answer: "Масквы", expected: true},
+		{name: "cyrillic counted in runes", question: question(fuzzyDistance, "Москва"), answer: "Масква", expected: true},
+		{name: "cyrillic distance exceeded", question: question(fuzzyDistance, "Москва"), answer: "Масквы"},
+		{name: "fuzzy ignores case", question: question(fuzzyDistance, "Москва"), answer: "МОСКВА", expected: true},
+		{name: "ratio", question: question(fuzzyRatio, "Владивосток"), answer: "Владивастог", expected: true},
+		{name: "ratio exceeded", question: question(fuzzyRatio, "Рим"), answer: "Ром"},
+		{name: "exact", question: question(model.TextMatchSettings{Mode: model.TextMatchModeExact}, "Рим"), answer: "Рим", expected: true},
+		{name: "exact is case sensitive", question: question(model.TextMatchSettings{Mode: model.TextMatchModeExact}, "Рим"), answer: "рим"},
+		{name: "exact without typos", question: question(model.TextMatchSettings{Mode: model.TextMatchModeExact}, "Рим"), answer: "Рима"},
+		{name: "case insensitive", question: question(model.TextMatchSettings{Mode: model.TextMatchModeCaseInsensitive}, "Рим"), answer: "РИМ", expected: true},
+		{name: "yo and punctuation", question: question(model.TextMatchSettings{Mode: model.TextMatchModeExact}, "Ёлка"), answer: "  Елка! ", expected: true},
+		{name: "spaces collapsed", question: question(model.TextMatchSettings{Mode: model.TextMatchModeExact}, "Нижний Новгород"), answer: "Нижний   Новгород", expected: true},
+		{name: "synonym", question: question(model.TextMatchSettings{Mode: model.TextMatchModeExact}, "Рим", "Roma"), answer: "Roma", expected: true},
+		{name: "empty answer", question: question(model.TextMatchSettings{}, "Рим"), answer: "  "},
+		{name: "only punctuation", question: question(model.TextMatchSettings{}, "Рим"), answer: "!?"},
+		{name: "regex", question: question(regex, `\d+ лет`), answer: "25 лет", expected: true},
+		{name: "regex spaces collapsed", question: question(regex, `\d+ лет`), answer: " 25   лет ", expected: true},
+		{name: "regex anchored at start", question: question(regex, `\d+ лет`), answer: "около 25 лет"},
+		{name: "regex anchored at end", question: question(regex, `\d+ лет`), answer: "25 лет назад"},
+		{name: "regex alternation anchored", question: question(regex, `да|нет`), answer: "данет"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewFillTheGapAcceptor().Accept(tt.question, []string{tt.answer})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsCorrect != tt.expected || len(result.Details) != 1 || result.Details[0].IsCorrect != tt.expected {
+				t.Errorf("expected %v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFillTheGapAcceptorInvalidRegex(t *testing.T) {
+	question := &model.Question{
+		Type:          model.QuestionTypeFillTheGap,
+		TextMatch:     model.TextMatchSettings{Mode: model.TextMatchModeRegex},
+		AnswerOptions: []model.AnswerOption{{Answer: "(", IsCorrect: true}},
+	}
+
+	if _, err := NewFillTheGapAcceptor().Accept(question, []string{"("}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		s1       string
+		s2       string
+		expected int
+	}{
+		{s1: "", s2: "", expected: 0},
+		{s1: "", s2: "абв", expected: 3},
+		{s1: "kitten", s2: "sitting", expected: 3},
+		{s1: "москва", s2: "масква", expected: 1},
+		{s1: "ёж", s2: "уж", expected: 1},
+		{s1: "кот", s2: "ток", expected: 2},
+	}
+
+	for _, tt := range tests {
+		if result := levenshteinDistance(tt.s1, tt.s2); result != tt.expected {
+			t.Errorf("%q, %q: expected %d, got %d", tt.s1, tt.s2, tt.expected, result)
+		}
+	}
+}
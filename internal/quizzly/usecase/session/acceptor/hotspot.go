@@ -0,0 +1,52 @@
+package acceptor
+
+import (
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+)
+
+type HotspotAcceptor struct{}
+
+func NewHotspotAcceptor() *HotspotAcceptor {
+	return &HotspotAcceptor{}
+}
+
+func (a *HotspotAcceptor) Accept(question *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
+	if len(answers) > 1 {
+		return nil, errors.New("hotspot can't have multiple answers")
+	}
+
+	correctAnswers := question.GetCorrectAnswers()
+	if len(correctAnswers) == 0 {
+		return nil, errors.New("no correct answers defined")
+	}
+
+	point, err := model.ParseHotspotPoint(answers[0])
+	if err != nil {
+		return nil, err
+	}
+
+	isCorrect := false
+	for _, answer := range correctAnswers {
+		region, err := answer.GetRegion()
+		if err != nil {
+			return nil, err
+		}
+
+		if region != nil && region.Contains(point) {
+			isCorrect = true
+			break
+		}
+	}
+
+	return &contracts.AcceptAnswersOut{
+		IsCorrect: isCorrect,
+		Details: []contracts.AnswerResult{
+			{
+				Answer:    answers[0],
+				IsCorrect: isCorrect,
+			},
+		},
+	}, nil
+}
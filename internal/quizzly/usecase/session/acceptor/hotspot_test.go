@@ -0,0 +1,59 @@
+package acceptor
+
+import (
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"testing"
+)
+
+func TestHotspotAcceptor(t *testing.T) {
+	// прямоугольник задан в обратном порядке углов, треугольник - вершинами
+	question := &model.Question{
+		Type:    model.QuestionTypeHotspot,
+		ImageID: structs.Pointer("map.png"),
+		AnswerOptions: []model.AnswerOption{
+			{Answer: "Прямоугольник", IsCorrect: true, Region: structs.Pointer("0.4 0.4,0.1 0.1")},
+			{Answer: "Треугольник", IsCorrect: true, Region: structs.Pointer("0.6 0.6,0.9 0.6,0.6 0.9")},
+			{Answer: "Неправильная область", Region: structs.Pointer("0 0.8,0.2 0.8,0.2 1,0 1")},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		answer   string
+		expected bool
+	}{
+		{name: "inside rectangle", answer: "0.2 0.3", expected: true},
+		{name: "rectangle border", answer: "0.1 0.4", expected: true},
+		{name: "outside rectangle", answer: "0.45 0.3"},
+		{name: "inside triangle", answer: "0.65 0.65", expected: true},
+		{name: "outside triangle near hypotenuse", answer: "0.8 0.8"},
+		{name: "inside incorrect region", answer: "0.1 0.9"},
+		{name: "outside regions", answer: "0.5 0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewHotspotAcceptor().Accept(question, []string{tt.answer})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsCorrect != tt.expected || len(result.Details) != 1 || result.Details[0].IsCorrect != tt.expected {
+				t.Errorf("expected %v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestHotspotAcceptorInvalidPoint(t *testing.T) {
+	question := &model.Question{
+		Type:          model.QuestionTypeHotspot,
+		AnswerOptions: []model.AnswerOption{{IsCorrect: true, Region: structs.Pointer("0 0,1 1")}},
+	}
+
+	for _, answer := range []string{"0.5", "1.5 0.5", "x y"} {
+		if _, err := NewHotspotAcceptor().Accept(question, []string{answer}); err == nil {
+			t.Errorf("%q: expected error", answer)
+		}
+	}
+}
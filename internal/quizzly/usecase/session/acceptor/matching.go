@@ -0,0 +1,55 @@
+package acceptor
+
+import (
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+)
+
+type MatchingAcceptor struct{}
+
+func NewMatchingAcceptor() *MatchingAcceptor {
+	return &MatchingAcceptor{}
+}
+
+func (a *MatchingAcceptor) Accept(question *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
+	if len(question.AnswerOptions) == 0 {
+		return nil, errors.New("no answer options defined")
+	}
+
+	unmatched := make(map[model.AnswerOptionID]model.AnswerOption, len(question.AnswerOptions))
+	for _, answerOption := range question.AnswerOptions {
+		unmatched[answerOption.ID] = answerOption
+	}
+
+	result := &contracts.AcceptAnswersOut{
+		IsCorrect: true,
+		Details:   make([]contracts.AnswerResult, 0, len(answers)),
+	}
+	for _, answer := range answers {
+		leftID, pair, err := model.ParseMatchingAnswer(answer)
+		if err != nil {
+			return nil, err
+		}
+
+		// пары сравниваются по тексту правой части, поэтому одинаковые правые части взаимозаменяемы
+		left, ok := unmatched[leftID]
+		isCorrect := ok && left.GetPair() == pair
+		if isCorrect {
+			delete(unmatched, leftID)
+		} else {
+			result.IsCorrect = false
+		}
+
+		result.Details = append(result.Details, contracts.AnswerResult{
+			Answer:    answer,
+			IsCorrect: isCorrect,
+		})
+	}
+
+	if len(unmatched) > 0 {
+		result.IsCorrect = false
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package acceptor
+
+import (
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"testing"
+)
+
+func TestMatchingAcceptor(t *testing.T) {
+	// у двух вариантов одинаковая правая часть
+	question := &model.Question{
+		Type: model.QuestionTypeMatching,
+		AnswerOptions: []model.AnswerOption{
+			{ID: 1, Answer: "Франция", Pair: structs.Pointer("Европа")},
+			{ID: 2, Answer: "Италия", Pair: structs.Pointer("Европа")},
+			{ID: 3, Answer: "Япония", Pair: structs.Pointer("Азия")},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		answers   []string
+		isCorrect bool
+		details   []bool
+	}{
+		{
+			name:      "all pairs",
+			answers:   []string{model.MatchingAnswer(3, "Азия"), model.MatchingAnswer(1, "Европа"), model.MatchingAnswer(2, "Европа")},
+			isCorrect: true,
+			details:   []bool{true, true, true},
+		},
+		{
+			name:    "wrong pair",
+			answers: []string{model.MatchingAnswer(1, "Азия"), model.MatchingAnswer(2, "Европа"), model.MatchingAnswer(3, "Европа")},
+			details: []bool{false, true, false},
+		},
+		{
+			name:    "missing pair",
+			answers: []string{model.MatchingAnswer(1, "Европа"), model.MatchingAnswer(3, "Азия")},
+			details: []bool{true, true},
+		},
+		{
+			name:    "repeated left side",
+			answers: []string{model.MatchingAnswer(1, "Европа"), model.MatchingAnswer(1, "Европа"), model.MatchingAnswer(3, "Азия")},
+			details: []bool{true, false, true},
+		},
+		{
+			name:    "unknown left side",
+			answers: []string{model.MatchingAnswer(1, "Европа"), model.MatchingAnswer(2, "Европа"), model.MatchingAnswer(3, "Азия"), model.MatchingAnswer(4, "Азия")},
+			details: []bool{true, true, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewMatchingAcceptor().Accept(question, tt.answers)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsCorrect != tt.isCorrect {
+				t.Errorf("expected is correct %v, got %v", tt.isCorrect, result.IsCorrect)
+			}
+			if len(result.Details) != len(tt.details) {
+				t.Fatalf("expected %d details, got %d", len(tt.details), len(result.Details))
+			}
+			for i, detail := range result.Details {
+				if detail.Answer != tt.answers[i] || detail.IsCorrect != tt.details[i] {
+					t.Errorf("answer %d: expected %v, got %+v", i, tt.details[i], detail)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchingAcceptorInvalidAnswer(t *testing.T) {
+	question := &model.Question{
+		Type:          model.QuestionTypeMatching,
+		AnswerOptions: []model.AnswerOption{{ID: 1, Answer: "Франция", Pair: structs.Pointer("Европа")}},
+	}
+
+	if _, err := NewMatchingAcceptor().Accept(question, []string{"Европа"}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,61 @@
+package acceptor
+
+import (
+	"errors"
+	"math"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/helper"
+)
+
+const (
+	// numericEpsilon погрешность сравнения, чтобы граничные значения не отсекались из-за ошибок округления
+	numericEpsilon = 1e-9
+)
+
+type NumericAcceptor struct{}
+
+func NewNumericAcceptor() *NumericAcceptor {
+	return &NumericAcceptor{}
+}
+
+func (a *NumericAcceptor) Accept(question *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
+	if len(answers) > 1 {
+		return nil, errors.New("numeric question can't have multiple answers")
+	}
+
+	correctAnswers := question.GetCorrectAnswers()
+	if len(correctAnswers) == 0 {
+		return nil, errors.New("no correct answers defined")
+	}
+
+	isCorrect := false
+	value, err := helper.ParseNumber(answers[0])
+	if err == nil {
+		for _, answer := range correctAnswers {
+			if inRange(value, answer.MinValue, answer.MaxValue) {
+				isCorrect = true
+				break
+			}
+		}
+	}
+
+	return &contracts.AcceptAnswersOut{
+		IsCorrect: isCorrect,
+		Details: []contracts.AnswerResult{
+			{
+				Answer:    answers[0],
+				IsCorrect: isCorrect,
+			},
+		},
+	}, nil
+}
+
+func inRange(value float64, minValue *float64, maxValue *float64) bool {
+	if minValue == nil || maxValue == nil {
+		return false
+	}
+
+	epsilon := numericEpsilon * math.Max(1, math.Abs(value))
+	return value >= *minValue-epsilon && value <= *maxValue+epsilon
+}
@@ -0,0 +1,52 @@
+package acceptor
+
+import (
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"testing"
+)
+
+func TestNumericAcceptor(t *testing.T) {
+	numeric := func(minValue, maxValue float64) *model.Question {
+		return &model.Question{
+			Type: model.QuestionTypeNumeric,
+			AnswerOptions: []model.AnswerOption{
+				{Answer: "ответ", IsCorrect: true, MinValue: structs.Pointer(minValue), MaxValue: structs.Pointer(maxValue)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		question *model.Question
+		answer   string
+		expected bool
+	}{
+		{name: "exact value", question: numeric(100, 100), answer: "100", expected: true},
+		{name: "extra digit", question: numeric(100, 100), answer: "1000"},
+		{name: "missing digit", question: numeric(100, 100), answer: "10"},
+		{name: "trailing zeros", question: numeric(100, 100), answer: "100,00", expected: true},
+		{name: "comma", question: numeric(3.13, 3.15), answer: "3,14", expected: true},
+		{name: "dot", question: numeric(3.13, 3.15), answer: "3.14", expected: true},
+		{name: "out of range", question: numeric(3.13, 3.15), answer: "3,16"},
+		{name: "lower bound", question: numeric(3.13, 3.15), answer: "3,13", expected: true},
+		{name: "upper bound", question: numeric(3.13, 3.15), answer: "3.15", expected: true},
+		{name: "rounding error at bound", question: numeric(0.1+0.2, 0.1+0.2), answer: "0,3", expected: true},
+		{name: "thousands separator", question: numeric(1000, 1000), answer: "1 000", expected: true},
+		{name: "negative", question: numeric(-5, -5), answer: "−5", expected: true},
+		{name: "not a number", question: numeric(100, 100), answer: "сто"},
+		{name: "empty", question: numeric(100, 100), answer: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewNumericAcceptor().Accept(tt.question, []string{tt.answer})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsCorrect != tt.expected || len(result.Details) != 1 || result.Details[0].IsCorrect != tt.expected {
+				t.Errorf("expected %v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package acceptor
+
+import (
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"strings"
+)
+
+// OpenTextAcceptor развернутый ответ проверяет автор игры, поэтому ответ только сохраняется
+type OpenTextAcceptor struct{}
+
+func NewOpenTextAcceptor() *OpenTextAcceptor {
+	return &OpenTextAcceptor{}
+}
+
+func (a *OpenTextAcceptor) Accept(_ *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
+	if len(answers) > 1 {
+		return nil, errors.New("open text can't have multiple answers")
+	}
+
+	if strings.TrimSpace(answers[0]) == "" {
+		return nil, errors.New("answer is empty")
+	}
+
+	return &contracts.AcceptAnswersOut{
+		IsPendingReview: true,
+		Details: []contracts.AnswerResult{
+			{
+				Answer: answers[0],
+			},
+		},
+	}, nil
+}
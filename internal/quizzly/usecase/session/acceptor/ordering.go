@@ -0,0 +1,39 @@
+package acceptor
+
+import (
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"strconv"
+)
+
+type OrderingAcceptor struct{}
+
+func NewOrderingAcceptor() *OrderingAcceptor {
+	return &OrderingAcceptor{}
+}
+
+func (a *OrderingAcceptor) Accept(question *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
+	orderedAnswers := question.GetOrderedAnswers()
+	if len(orderedAnswers) == 0 {
+		return nil, errors.New("no answer options defined")
+	}
+
+	result := &contracts.AcceptAnswersOut{
+		IsCorrect: len(answers) == len(orderedAnswers),
+		Details:   make([]contracts.AnswerResult, 0, len(answers)),
+	}
+	for i, answer := range answers {
+		isCorrect := i < len(orderedAnswers) && strconv.FormatInt(int64(orderedAnswers[i].ID), 10) == answer
+		if !isCorrect {
+			result.IsCorrect = false
+		}
+
+		result.Details = append(result.Details, contracts.AnswerResult{
+			Answer:    answer,
+			IsCorrect: isCorrect,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package acceptor
+
+import (
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"reflect"
+	"testing"
+)
+
+func TestOrderingAcceptor(t *testing.T) {
+	// варианты хранятся не в том порядке, в котором их нужно расставить
+	question := &model.Question{
+		Type: model.QuestionTypeOrdering,
+		AnswerOptions: []model.AnswerOption{
+			{ID: 1, Answer: "Второй", Position: structs.Pointer(2)},
+			{ID: 2, Answer: "Третий", Position: structs.Pointer(3)},
+			{ID: 3, Answer: "Первый", Position: structs.Pointer(1)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		answers  []string
+		expected *contracts.AcceptAnswersOut
+	}{
+		{
+			name:    "right order",
+			answers: []string{"3", "1", "2"},
+			expected: &contracts.AcceptAnswersOut{
+				IsCorrect: true,
+				Details: []contracts.AnswerResult{
+					{Answer: "3", IsCorrect: true},
+					{Answer: "1", IsCorrect: true},
+					{Answer: "2", IsCorrect: true},
+				},
+			},
+		},
+		{
+			name:    "stored order",
+			answers: []string{"1", "2", "3"},
+			expected: &contracts.AcceptAnswersOut{
+				Details: []contracts.AnswerResult{
+					{Answer: "1"},
+					{Answer: "2"},
+					{Answer: "3"},
+				},
+			},
+		},
+		{
+			name:    "one swap",
+			answers: []string{"3", "2", "1"},
+			expected: &contracts.AcceptAnswersOut{
+				Details: []contracts.AnswerResult{
+					{Answer: "3", IsCorrect: true},
+					{Answer: "2"},
+					{Answer: "1"},
+				},
+			},
+		},
+		{
+			name:    "missing item",
+			answers: []string{"3", "1"},
+			expected: &contracts.AcceptAnswersOut{
+				Details: []contracts.AnswerResult{
+					{Answer: "3", IsCorrect: true},
+					{Answer: "1", IsCorrect: true},
+				},
+			},
+		},
+		{
+			name:    "extra item",
+			answers: []string{"3", "1", "2", "2"},
+			expected: &contracts.AcceptAnswersOut{
+				Details: []contracts.AnswerResult{
+					{Answer: "3", IsCorrect: true},
+					{Answer: "1", IsCorrect: true},
+					{Answer: "2", IsCorrect: true},
+					{Answer: "2"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewOrderingAcceptor().Accept(question, tt.answers)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package acceptor
+
+import (
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"strconv"
+)
+
+// PollAcceptor в опросе нет правильного ответа, проверяется только то, что выбраны существующие варианты
+type PollAcceptor struct{}
+
+func NewPollAcceptor() *PollAcceptor {
+	return &PollAcceptor{}
+}
+
+func (a *PollAcceptor) Accept(question *model.Question, answers []string) (*contracts.AcceptAnswersOut, error) {
+	if question.Type == model.QuestionTypePoll && len(answers) > 1 {
+		return nil, errors.New("poll can't have multiple answers")
+	}
+
+	answerOptions := make(map[string]struct{}, len(question.AnswerOptions))
+	for _, answerOption := range question.AnswerOptions {
+		answerOptions[strconv.FormatInt(int64(answerOption.ID), 10)] = struct{}{}
+	}
+
+	result := &contracts.AcceptAnswersOut{
+		Details: make([]contracts.AnswerResult, 0, len(answers)),
+	}
+	for _, answer := range answers {
+		if _, ok := answerOptions[answer]; !ok {
+			return nil, errors.New("unknown answer option")
+		}
+
+		result.Details = append(result.Details, contracts.AnswerResult{
+			Answer: answer,
+		})
+	}
+
+	return result, nil
+}
@@ -3,12 +3,13 @@ package session
 import (
 	"context"
 	"github.com/google/uuid"
-	"math/rand/v2"
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
 	"quizzly/internal/quizzly/repositories/game"
 	"quizzly/internal/quizzly/repositories/session"
+	"quizzly/pkg/structs"
 	"quizzly/pkg/structs/collections/slices"
+	"time"
 )
 
 func (u *Usecase) GetCurrentState(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*contracts.SessionState, error) {
@@ -36,6 +37,7 @@ func (u *Usecase) GetCurrentState(ctx context.Context, gameID uuid.UUID, playerI
 		if err != nil {
 			return err
 		}
+		questionList = filterDrawnQuestions(questionList, specificSession)
 
 		sessionItems, err := u.sessions.GetSessionBySpec(ctx, &session.ItemSpec{
 			PlayerID: playerID,
@@ -45,88 +47,259 @@ func (u *Usecase) GetCurrentState(ctx context.Context, gameID uuid.UUID, playerI
 			return err
 		}
 
-		currentQuestion, err := findUnansweredQuestion(specificGame, questionList, sessionItems)
+		now := time.Now()
+		if err = u.expireSessionItems(ctx, specificGame, questionList, sessionItems, now); err != nil {
+			return err
+		}
+
+		progress := contracts.Progress{
+			Total: int64(len(questionList)),
+			Answered: int64(len(
+				slices.Filter(sessionItems, func(item model.SessionItem) bool {
+					return item.AnsweredAt != nil
+				}),
+			)),
+		}
+
+		var currentQuestion *model.Question
+		if specificGame.Type == model.GameTypeLive {
+			currentQuestion, err = u.findLiveQuestion(ctx, gameID, questionList, sessionItems)
+		} else {
+			currentQuestion, err = findUnansweredQuestion(specificGame, specificSession, questionList, sessionItems)
+		}
 		if err != nil {
 			return err
 		}
+		if model.IsBranching(questionList) {
+			progress.Total = progress.Answered + int64(countRemainingQuestions(questionList, currentQuestion))
+		}
+		if currentQuestion == nil {
+			result = &contracts.SessionState{
+				Status:         specificSession.Status,
+				Progress:       progress,
+				WaitingForHost: true,
+			}
+			return nil
+		}
 
-		if specificGame.Settings.ShuffleAnswers {
-			tempAnswerOptions := currentQuestion.AnswerOptions
-			rand.Shuffle(len(tempAnswerOptions), func(i, j int) {
-				tempAnswerOptions[i], tempAnswerOptions[j] = tempAnswerOptions[j], tempAnswerOptions[i]
-			})
-			currentQuestion.AnswerOptions = tempAnswerOptions
+		// В вопросах на упорядочивание варианты перемешиваются всегда, иначе ответ виден сразу
+		if specificGame.Settings.ShuffleAnswers || currentQuestion.Type == model.QuestionTypeOrdering {
+			shuffleAnswerOptions(specificSession, currentQuestion)
+		}
+
+		var pairs []string
+		if currentQuestion.Type == model.QuestionTypeMatching {
+			pairs = getPairs(specificSession, currentQuestion)
+		}
+
+		deadline, err := u.showQuestion(ctx, specificGame, specificSession, currentQuestion, sessionItems, now)
+		if err != nil {
+			return err
 		}
 
 		result = &contracts.SessionState{
 			CurrentQuestion: currentQuestion,
-			Progress: contracts.Progress{
-				Total: int64(len(questionList)),
-				Answered: int64(len(
-					slices.Filter(sessionItems, func(item model.SessionItem) bool {
-						return item.AnsweredAt != nil
-					}),
-				)),
-			},
+			Progress:        progress,
+			Deadline:        deadline,
+			Pairs:           pairs,
 		}
 		return nil
 	})
 }
 
-func findUnansweredQuestion(specificGame *model.Game, questions []model.Question, sessionItems []model.SessionItem) (*model.Question, error) {
+// expireSessionItems засчитывает неправильными вопросы, на которые игрок не ответил за отведенное время
+func (u *Usecase) expireSessionItems(ctx context.Context, specificGame *model.Game, questions []model.Question, sessionItems []model.SessionItem, now time.Time) error {
+	for i := range sessionItems {
+		item := &sessionItems[i]
+		if item.AnsweredAt != nil {
+			continue
+		}
+
+		question, err := slices.Single(questions, func(question model.Question) bool {
+			return question.ID == item.QuestionID
+		})
+		if err != nil {
+			continue
+		}
+
+		timeLimit := question.GetTimeLimit(specificGame.Settings)
+		if timeLimit == nil || now.Before(item.CreatedAt.Add(*timeLimit+contracts.AnswerGracePeriod)) {
+			continue
+		}
+
+		item.AnsweredAt = structs.Pointer(now)
+		if !question.Type.IsPoll() {
+			item.IsCorrect = structs.Pointer(false)
+		}
+		item.Score = calculateScore(specificGame.Settings, &question, &scoreIn{
+			isCorrect:  item.IsCorrect,
+			isTimedOut: true,
+		})
+		if err = u.sessions.UpdateSessionItem(ctx, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// showQuestion запоминает момент показа вопроса, если от него зависит результат: ограничение по времени или бонус за скорость.
+// Возвращает время окончания ответа, nil если время не ограничено
+func (u *Usecase) showQuestion(
+	ctx context.Context,
+	specificGame *model.Game,
+	specificSession *model.Session,
+	question *model.Question,
+	sessionItems []model.SessionItem,
+	now time.Time,
+) (*time.Time, error) {
+	timeLimit := question.GetTimeLimit(specificGame.Settings)
+	if timeLimit == nil && !specificGame.Settings.SpeedBonus {
+		return nil, nil
+	}
+
+	shownAt := now
+	shownItem, err := slices.Single(sessionItems, func(item model.SessionItem) bool {
+		return item.QuestionID == question.ID && item.AnsweredAt == nil
+	})
+	if err == nil {
+		shownAt = shownItem.CreatedAt
+	} else {
+		err = u.sessions.InsertSessionItem(ctx, &model.SessionItem{
+			SessionID:  specificSession.ID,
+			QuestionID: question.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if timeLimit == nil {
+		return nil, nil
+	}
+
+	return structs.Pointer(shownAt.Add(*timeLimit)), nil
+}
+
+// findLiveQuestion в live-игре вопрос выбирает ведущий.
+// Возвращает nil, если вопрос еще не открыт, уже закрыт или игрок на него уже ответил
+func (u *Usecase) findLiveQuestion(ctx context.Context, gameID uuid.UUID, questions []model.Question, sessionItems []model.SessionItem) (*model.Question, error) {
+	state, err := u.games.GetLiveState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if state.CurrentQuestionID == nil || !state.IsQuestionOpened(*state.CurrentQuestionID) {
+		return nil, nil
+	}
+
+	if slices.Contains(sessionItems, func(item model.SessionItem) bool {
+		return item.QuestionID == *state.CurrentQuestionID && item.AnsweredAt != nil
+	}) {
+		return nil, nil
+	}
+
+	currentQuestion, err := slices.Single(questions, func(question model.Question) bool {
+		return question.ID == *state.CurrentQuestionID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &currentQuestion, nil
+}
+
+// findUnansweredQuestion при перемешивании порядок вопросов определяется прохождением и не меняется между запросами
+func findUnansweredQuestion(specificGame *model.Game, specificSession *model.Session, questions []model.Question, sessionItems []model.SessionItem) (*model.Question, error) {
 	if len(questions) == 0 {
 		return nil, contracts.ErrEmptyQuestions
 	}
 
+	// При ветвлении порядок вопросов задают переходы, поэтому перемешивание не применяется
+	if specificGame.Settings.ShuffleQuestions && !model.IsBranching(questions) {
+		questions = shuffleQuestions(specificSession, questions)
+		return findFirstUnansweredQuestion(questions, sessionItems)
+	}
+
 	if len(sessionItems) == 0 {
-		index := 0
-		if specificGame.Settings.ShuffleQuestions {
-			index = rand.IntN(len(questions))
-		}
+		return &questions[0], nil
+	}
 
-		return &questions[index], nil
+	// Показанный, но еще не отвеченный вопрос выдается повторно
+	if question := findShownQuestion(questions, sessionItems); question != nil {
+		return question, nil
 	}
 
 	var latestSessionItem model.SessionItem
-	answeredMap := make(map[uuid.UUID]bool, len(sessionItems))
 	for _, item := range sessionItems {
-		answeredMap[item.QuestionID] = true
-
 		if latestSessionItem.AnsweredAt == nil ||
 			(item.AnsweredAt != nil && item.AnsweredAt.After(*latestSessionItem.AnsweredAt)) {
 			latestSessionItem = item
 		}
 	}
 
-	questionIndexMap := make(map[uuid.UUID]int, len(questions))
-	unansweredQuestions := make([]uuid.UUID, 0, len(questions))
-	for i, item := range questions {
-		questionIndexMap[item.ID] = i
-
-		if answeredMap[item.ID] {
-			continue
+	lastQuestionIndex := -1
+	for i := range questions {
+		if questions[i].ID == latestSessionItem.QuestionID {
+			lastQuestionIndex = i
 		}
-		unansweredQuestions = append(unansweredQuestions, item.ID)
+	}
+	if lastQuestionIndex < 0 {
+		return nil, contracts.ErrQuestionQueueIsEmpty
 	}
 
-	if len(unansweredQuestions) == 0 {
+	nextQuestionIndex := model.NextQuestionIndex(questions, lastQuestionIndex, latestSessionItem.Answers)
+	if nextQuestionIndex < 0 {
 		return nil, contracts.ErrQuestionQueueIsEmpty
 	}
 
-	if specificGame.Settings.ShuffleQuestions {
-		nextQuestionIndex, ok := questionIndexMap[unansweredQuestions[rand.IntN(len(unansweredQuestions))]]
-		if !ok {
-			return nil, contracts.ErrQuestionQueueIsEmpty
+	nextQuestion := questions[nextQuestionIndex]
+	return &nextQuestion, nil
+}
+
+func findFirstUnansweredQuestion(questions []model.Question, sessionItems []model.SessionItem) (*model.Question, error) {
+	if question := findShownQuestion(questions, sessionItems); question != nil {
+		return question, nil
+	}
+
+	for i := range questions {
+		if !slices.Contains(sessionItems, func(item model.SessionItem) bool {
+			return item.QuestionID == questions[i].ID
+		}) {
+			return &questions[i], nil
+		}
+	}
+
+	return nil, contracts.ErrQuestionQueueIsEmpty
+}
+
+// findShownQuestion вопрос, который уже показан игроку, но еще без ответа
+func findShownQuestion(questions []model.Question, sessionItems []model.SessionItem) *model.Question {
+	for _, item := range sessionItems {
+		if item.AnsweredAt != nil {
+			continue
 		}
 
-		return &questions[nextQuestionIndex], nil
+		for i := range questions {
+			if questions[i].ID == item.QuestionID {
+				return &questions[i]
+			}
+		}
 	}
 
-	lastQuestionIndex, ok := questionIndexMap[latestSessionItem.QuestionID]
-	if !ok || lastQuestionIndex >= len(questions)-1 {
-		return nil, contracts.ErrQuestionQueueIsEmpty
+	return nil
+}
+
+// countRemainingQuestions при ветвлении число оставшихся вопросов зависит от ответов, поэтому считается по текущему маршруту
+func countRemainingQuestions(questions []model.Question, currentQuestion *model.Question) int {
+	if currentQuestion == nil {
+		return 0
 	}
 
-	nextQuestion := questions[lastQuestionIndex+1]
-	return &nextQuestion, nil
+	for i := range questions {
+		if questions[i].ID == currentQuestion.ID {
+			return model.CountQuestionPath(questions, i)
+		}
+	}
+
+	return 0
 }
@@ -0,0 +1,29 @@
+package session
+
+import (
+	"context"
+	"quizzly/internal/quizzly/model"
+	"quizzly/internal/quizzly/repositories/session"
+
+	"github.com/google/uuid"
+)
+
+const leaderboardLimit = 100
+
+func (u *Usecase) GetLeaderboard(ctx context.Context, gameID uuid.UUID, order model.LeaderboardOrder) ([]model.LeaderboardEntry, error) {
+	if order != model.LeaderboardOrderCorrectAnswers {
+		order = model.LeaderboardOrderScore
+	}
+
+	specificGame, err := u.getGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.sessions.GetLeaderboard(ctx, &session.LeaderboardSpec{
+		GameID:        gameID,
+		Order:         order,
+		Limit:         leaderboardLimit,
+		AttemptPolicy: specificGame.Settings.AttemptPolicy,
+	})
+}
@@ -0,0 +1,105 @@
+package session
+
+import (
+	"math/rand/v2"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs/collections/slices"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// drawQuestions выбирает вопросы для игрока из пула вопросов игры.
+// Возвращает nil, если игроку достаются все вопросы игры
+func drawQuestions(specificGame *model.Game, questions []model.Question) []uuid.UUID {
+	poolSize := specificGame.Settings.QuestionPoolSize
+	if specificGame.Type == model.GameTypeLive || poolSize == nil || *poolSize <= 0 || *poolSize >= len(questions) {
+		return nil
+	}
+
+	groups := [][]model.Question{questions}
+	if specificGame.Settings.StratifyPool {
+		groups = groupQuestionsByTag(questions)
+	}
+
+	drawn := make(map[uuid.UUID]bool, *poolSize)
+	for i, quota := range allocateQuotas(groups, *poolSize) {
+		for _, index := range rand.Perm(len(groups[i]))[:quota] {
+			drawn[groups[i][index].ID] = true
+		}
+	}
+
+	// Выпавшие вопросы идут в том же порядке, что и в игре
+	result := make([]uuid.UUID, 0, *poolSize)
+	for _, question := range questions {
+		if drawn[question.ID] {
+			result = append(result, question.ID)
+		}
+	}
+
+	return result
+}
+
+// filterDrawnQuestions оставляет только вопросы, выпавшие игроку
+func filterDrawnQuestions(questions []model.Question, specificSession *model.Session) []model.Question {
+	if specificSession.QuestionIDs == nil {
+		return questions
+	}
+
+	return slices.Filter(questions, func(question model.Question) bool {
+		return slices.ContainsValue(specificSession.QuestionIDs, question.ID)
+	})
+}
+
+// groupQuestionsByTag вопросы без тега составляют отдельную группу
+func groupQuestionsByTag(questions []model.Question) [][]model.Question {
+	result := make([][]model.Question, 0, 1)
+	indexMap := make(map[string]int)
+	for _, question := range questions {
+		tag := ""
+		if question.Tag != nil {
+			tag = *question.Tag
+		}
+
+		index, ok := indexMap[tag]
+		if !ok {
+			result = append(result, make([]model.Question, 0, 1))
+			index = len(result) - 1
+			indexMap[tag] = index
+		}
+		result[index] = append(result[index], question)
+	}
+
+	return result
+}
+
+// allocateQuotas число вопросов из каждой группы пропорционально ее размеру,
+// оставшиеся после округления вопросы достаются группам с наибольшей дробной частью
+func allocateQuotas(groups [][]model.Question, poolSize int) []int {
+	total := 0
+	for _, group := range groups {
+		total += len(group)
+	}
+
+	quotas := make([]int, len(groups))
+	remainders := make([]int, len(groups))
+	allocated := 0
+	for i, group := range groups {
+		quotas[i] = poolSize * len(group) / total
+		remainders[i] = poolSize * len(group) % total
+		allocated += quotas[i]
+	}
+
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for _, index := range order[:poolSize-allocated] {
+		quotas[index]++
+	}
+
+	return quotas
+}
@@ -0,0 +1,98 @@
+package session
+
+import (
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAllocateQuotas(t *testing.T) {
+	tests := []struct {
+		name     string
+		sizes    []int
+		poolSize int
+		expected []int
+	}{
+		{name: "one group", sizes: []int{7}, poolSize: 3, expected: []int{3}},
+		{name: "exact proportion", sizes: []int{4, 2}, poolSize: 3, expected: []int{2, 1}},
+		{name: "largest remainder", sizes: []int{6, 3, 1}, poolSize: 4, expected: []int{3, 1, 0}},
+		{name: "largest remainder in smaller group", sizes: []int{1, 4}, poolSize: 3, expected: []int{1, 2}},
+		{name: "equal remainders go to earlier group", sizes: []int{5, 3, 2}, poolSize: 5, expected: []int{3, 1, 1}},
+		{name: "small groups", sizes: []int{1, 1, 1}, poolSize: 2, expected: []int{1, 1, 0}},
+		{name: "whole pool", sizes: []int{2, 3}, poolSize: 5, expected: []int{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := make([][]model.Question, 0, len(tt.sizes))
+			for _, size := range tt.sizes {
+				groups = append(groups, make([]model.Question, size))
+			}
+
+			result := allocateQuotas(groups, tt.poolSize)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDrawQuestions(t *testing.T) {
+	tags := []*string{structs.Pointer("история"), structs.Pointer("история"), structs.Pointer("история"), structs.Pointer("история"), nil, nil}
+	questions := make([]model.Question, 0, len(tags))
+	for _, tag := range tags {
+		questions = append(questions, model.Question{ID: uuid.New(), Tag: tag})
+	}
+
+	tests := []struct {
+		name     string
+		game     model.Game
+		expected int
+	}{
+		{name: "no pool", game: model.Game{Type: model.GameTypeAsync}},
+		{name: "pool of all questions", game: model.Game{Type: model.GameTypeAsync, Settings: model.GameSettings{QuestionPoolSize: structs.Pointer(6)}}},
+		{name: "live game", game: model.Game{Type: model.GameTypeLive, Settings: model.GameSettings{QuestionPoolSize: structs.Pointer(3)}}},
+		{name: "pool", game: model.Game{Type: model.GameTypeAsync, Settings: model.GameSettings{QuestionPoolSize: structs.Pointer(3)}}, expected: 3},
+		{name: "stratified pool", game: model.Game{Type: model.GameTypeAsync, Settings: model.GameSettings{QuestionPoolSize: structs.Pointer(3), StratifyPool: true}}, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// вопросы выбираются случайно, поэтому проверяется несколько розыгрышей
+			for range 20 {
+				result := drawQuestions(&tt.game, questions)
+				if tt.expected == 0 {
+					if result != nil {
+						t.Fatalf("expected all questions, got %v", result)
+					}
+					return
+				}
+				if len(result) != tt.expected {
+					t.Fatalf("expected %d questions, got %d", tt.expected, len(result))
+				}
+
+				indexes := make([]int, 0, len(result))
+				for _, id := range result {
+					indexes = append(indexes, slices.IndexFunc(questions, func(question model.Question) bool {
+						return question.ID == id
+					}))
+				}
+				if !slices.IsSorted(indexes) || slices.Contains(indexes, -1) || len(slices.Compact(slices.Clone(indexes))) != len(indexes) {
+					t.Fatalf("expected distinct questions in game order, got %v", indexes)
+				}
+
+				// из 4 вопросов с тегом и 2 без тега в пул из 3 попадают 2 и 1
+				if tt.game.Settings.StratifyPool && indexes[1] > 3 {
+					t.Fatalf("expected 2 tagged questions, got %v", indexes)
+				}
+				if tt.game.Settings.StratifyPool && indexes[2] < 4 {
+					t.Fatalf("expected 1 untagged question, got %v", indexes)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/internal/quizzly/repositories/game"
+	"quizzly/internal/quizzly/repositories/session"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (u *Usecase) GetPendingReviews(ctx context.Context, gameID uuid.UUID) ([]model.ReviewItem, error) {
+	return u.sessions.GetPendingReviewItems(ctx, gameID)
+}
+
+// ReviewAnswer проверка может быть изменена автором игры повторно, поэтому уже проверенные ответы не отсекаются
+func (u *Usecase) ReviewAnswer(ctx context.Context, in *contracts.ReviewAnswerIn) error {
+	var item *model.ReviewItem
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		var err error
+		item, err = u.sessions.UpdateSessionItemReview(ctx, &session.UpdateReviewIn{
+			ItemID:    in.ItemID,
+			GameID:    in.GameID,
+			IsCorrect: in.IsCorrect,
+			Comment:   in.Comment,
+		})
+		if err != nil {
+			return err
+		}
+
+		specificGames, err := u.games.GetBySpec(ctx, &game.Spec{
+			IDs: []uuid.UUID{in.GameID},
+		})
+		if err != nil {
+			return err
+		}
+		if len(specificGames) == 0 {
+			return contracts.ErrGameNotFound
+		}
+
+		specificQuestions, err := u.games.GetQuestionsBySpec(ctx, &game.QuestionsSpec{
+			IDs: []uuid.UUID{item.QuestionID},
+		})
+		if err != nil {
+			return err
+		}
+		if len(specificQuestions) == 0 {
+			return errors.New("question not found")
+		}
+
+		var elapsed time.Duration
+		if item.AnsweredAt != nil {
+			elapsed = item.AnsweredAt.Sub(item.CreatedAt)
+		}
+
+		return u.sessions.UpdateSessionItemScore(ctx, item.ID, calculateScore(
+			specificGames[0].Settings,
+			&specificQuestions[0],
+			&scoreIn{
+				isCorrect: item.IsCorrect,
+				elapsed:   elapsed,
+			},
+		))
+	})
+	if err != nil {
+		return err
+	}
+
+	u.publish(model.GameEvent{
+		Type:       model.GameEventAnswerReviewed,
+		GameID:     in.GameID,
+		PlayerID:   &item.PlayerID,
+		QuestionID: &item.QuestionID,
+		IsCorrect:  item.IsCorrect,
+	})
+	return nil
+}
@@ -0,0 +1,91 @@
+package session
+
+import (
+	"math"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"time"
+)
+
+// speedBonusWindow за это время бонус за скорость уменьшается до нуля, если у вопроса нет ограничения по времени
+const speedBonusWindow = 30 * time.Second
+
+type scoreIn struct {
+	isCorrect  *bool
+	isTimedOut bool
+	details    []contracts.AnswerResult
+	// elapsed время от показа вопроса до ответа
+	elapsed time.Duration
+}
+
+// calculateScore баллы за ответ с учетом настроек игры.
+// Бонус за скорость удваивает баллы за мгновенный ответ и уменьшается до нуля к концу отведенного времени
+func calculateScore(settings model.GameSettings, question *model.Question, in *scoreIn) *float64 {
+	if question.Type.IsPoll() || in.isCorrect == nil {
+		return nil
+	}
+	if in.isTimedOut {
+		return structs.Pointer(float64(0))
+	}
+
+	points := float64(question.Points)
+	score := float64(0)
+	if *in.isCorrect {
+		score = points
+	} else if settings.PartialCredit && question.Type == model.QuestionTypeMultipleChoice {
+		score = points * calculatePartialCredit(question, in.details)
+	}
+
+	if score == 0 {
+		if settings.NegativeMarking {
+			return structs.Pointer(-points)
+		}
+
+		return structs.Pointer(float64(0))
+	}
+
+	if settings.SpeedBonus {
+		window := speedBonusWindow
+		if timeLimit := question.GetTimeLimit(settings); timeLimit != nil {
+			window = *timeLimit
+		}
+
+		score += score * max(1-in.elapsed.Seconds()/window.Seconds(), 0)
+	}
+
+	return structs.Pointer(math.Round(score*100) / 100)
+}
+
+// calculatePartialCredit доля правильных вариантов, за каждый неправильный вариант одна доля вычитается
+func calculatePartialCredit(question *model.Question, details []contracts.AnswerResult) float64 {
+	correctAnswersCount := len(question.GetCorrectAnswers())
+	if correctAnswersCount == 0 {
+		return 0
+	}
+
+	credit := 0
+	for _, detail := range details {
+		if detail.IsCorrect {
+			credit++
+		} else {
+			credit--
+		}
+	}
+
+	return max(float64(credit), 0) / float64(correctAnswersCount)
+}
+
+// getMaxScore баллы за все вопросы игры при правильных ответах без бонуса за скорость
+func getMaxScore(questions []model.Question) float64 {
+	total := float64(0)
+	for _, question := range questions {
+		if question.Type.IsPoll() {
+			continue
+		}
+
+		total += float64(question.Points)
+	}
+
+	return total
+}
@@ -0,0 +1,180 @@
+package session
+
+import (
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCalculateScore(t *testing.T) {
+	choice := &model.Question{Type: model.QuestionTypeChoice, Points: 2}
+	timed := &model.Question{Type: model.QuestionTypeChoice, Points: 2, TimeLimit: structs.Pointer(20 * time.Second)}
+	poll := &model.Question{Type: model.QuestionTypePoll, Points: 2}
+	multiple := &model.Question{
+		Type:   model.QuestionTypeMultipleChoice,
+		Points: 3,
+		AnswerOptions: []model.AnswerOption{
+			{ID: 1, IsCorrect: true},
+			{ID: 2, IsCorrect: true},
+			{ID: 3, IsCorrect: true},
+			{ID: 4},
+		},
+	}
+	details := func(in ...bool) []contracts.AnswerResult {
+		result := make([]contracts.AnswerResult, 0, len(in))
+		for _, isCorrect := range in {
+			result = append(result, contracts.AnswerResult{IsCorrect: isCorrect})
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		settings model.GameSettings
+		question *model.Question
+		in       scoreIn
+		expected *float64
+	}{
+		{name: "poll", question: poll, in: scoreIn{isCorrect: structs.Pointer(false)}},
+		{name: "not reviewed", question: choice, in: scoreIn{}},
+		{name: "correct", question: choice, in: scoreIn{isCorrect: structs.Pointer(true)}, expected: structs.Pointer(float64(2))},
+		{name: "wrong", question: choice, in: scoreIn{isCorrect: structs.Pointer(false)}, expected: structs.Pointer(float64(0))},
+		{
+			name:     "negative marking",
+			settings: model.GameSettings{NegativeMarking: true},
+			question: choice,
+			in:       scoreIn{isCorrect: structs.Pointer(false)},
+			expected: structs.Pointer(float64(-2)),
+		},
+		{
+			name:     "timed out is not penalized",
+			settings: model.GameSettings{NegativeMarking: true, SpeedBonus: true},
+			question: choice,
+			in:       scoreIn{isCorrect: structs.Pointer(false), isTimedOut: true},
+			expected: structs.Pointer(float64(0)),
+		},
+		{
+			name:     "speed bonus by question time limit",
+			settings: model.GameSettings{SpeedBonus: true},
+			question: timed,
+			in:       scoreIn{isCorrect: structs.Pointer(true), elapsed: 5 * time.Second},
+			expected: structs.Pointer(3.5),
+		},
+		{
+			name:     "speed bonus by game time limit",
+			settings: model.GameSettings{SpeedBonus: true, QuestionTimeLimit: structs.Pointer(10 * time.Second)},
+			question: choice,
+			in:       scoreIn{isCorrect: structs.Pointer(true), elapsed: 5 * time.Second},
+			expected: structs.Pointer(float64(3)),
+		},
+		{
+			name:     "speed bonus without time limit",
+			settings: model.GameSettings{SpeedBonus: true},
+			question: choice,
+			in:       scoreIn{isCorrect: structs.Pointer(true), elapsed: 10 * time.Second},
+			expected: structs.Pointer(3.33),
+		},
+		{
+			name:     "instant answer doubles points",
+			settings: model.GameSettings{SpeedBonus: true},
+			question: timed,
+			in:       scoreIn{isCorrect: structs.Pointer(true)},
+			expected: structs.Pointer(float64(4)),
+		},
+		{
+			name:     "late answer has no bonus",
+			settings: model.GameSettings{SpeedBonus: true},
+			question: timed,
+			in:       scoreIn{isCorrect: structs.Pointer(true), elapsed: time.Minute},
+			expected: structs.Pointer(float64(2)),
+		},
+		{
+			name:     "no bonus for wrong answer",
+			settings: model.GameSettings{SpeedBonus: true},
+			question: timed,
+			in:       scoreIn{isCorrect: structs.Pointer(false)},
+			expected: structs.Pointer(float64(0)),
+		},
+		{
+			name:     "partial credit disabled",
+			question: multiple,
+			in:       scoreIn{isCorrect: structs.Pointer(false), details: details(true, true)},
+			expected: structs.Pointer(float64(0)),
+		},
+		{
+			name:     "partial credit",
+			settings: model.GameSettings{PartialCredit: true},
+			question: multiple,
+			in:       scoreIn{isCorrect: structs.Pointer(false), details: details(true, true)},
+			expected: structs.Pointer(float64(2)),
+		},
+		{
+			name:     "partial credit with wrong option",
+			settings: model.GameSettings{PartialCredit: true},
+			question: multiple,
+			in:       scoreIn{isCorrect: structs.Pointer(false), details: details(true, true, false)},
+			expected: structs.Pointer(float64(1)),
+		},
+		{
+			name:     "partial credit is not below zero",
+			settings: model.GameSettings{PartialCredit: true},
+			question: multiple,
+			in:       scoreIn{isCorrect: structs.Pointer(false), details: details(true, false, false)},
+			expected: structs.Pointer(float64(0)),
+		},
+		{
+			name:     "partial credit without correct options is negative",
+			settings: model.GameSettings{PartialCredit: true, NegativeMarking: true},
+			question: multiple,
+			in:       scoreIn{isCorrect: structs.Pointer(false), details: details(false)},
+			expected: structs.Pointer(float64(-3)),
+		},
+		{
+			name:     "partial credit with speed bonus",
+			settings: model.GameSettings{PartialCredit: true, SpeedBonus: true, QuestionTimeLimit: structs.Pointer(10 * time.Second)},
+			question: multiple,
+			in:       scoreIn{isCorrect: structs.Pointer(false), details: details(true), elapsed: 5 * time.Second},
+			expected: structs.Pointer(1.5),
+		},
+		{
+			name:     "partial credit only for multiple choice",
+			settings: model.GameSettings{PartialCredit: true},
+			question: &model.Question{Type: model.QuestionTypeCloze, Points: 2, AnswerOptions: []model.AnswerOption{{IsCorrect: true}, {IsCorrect: true}}},
+			in:       scoreIn{isCorrect: structs.Pointer(false), details: details(true, false)},
+			expected: structs.Pointer(float64(0)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calculateScore(tt.settings, tt.question, &tt.in)
+			if (result == nil) != (tt.expected == nil) || result != nil && *result != *tt.expected {
+				t.Errorf("expected %s, got %s", formatScore(tt.expected), formatScore(result))
+			}
+		})
+	}
+}
+
+func formatScore(in *float64) string {
+	if in == nil {
+		return "nil"
+	}
+
+	return strconv.FormatFloat(*in, 'f', -1, 64)
+}
+
+func TestGetMaxScore(t *testing.T) {
+	questions := []model.Question{
+		{Type: model.QuestionTypeChoice, Points: 2},
+		{Type: model.QuestionTypePoll, Points: 5},
+		{Type: model.QuestionTypeOpenText, Points: 3},
+	}
+
+	if result := getMaxScore(questions); result != 5 {
+		t.Errorf("expected 5, got %v", result)
+	}
+}
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"quizzly/internal/quizzly/model"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const (
+	shuffleStreamQuestions uint64 = iota
+	shuffleStreamAnswerOptions
+	shuffleStreamPairs
+)
+
+// newSessionRand при одинаковых аргументах выдает одну и ту же последовательность,
+// поэтому порядок вопросов и вариантов ответа не меняется при обновлении страницы
+func newSessionRand(specificSession *model.Session, id uuid.UUID, stream uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(specificSession.ShuffleSeed), binary.BigEndian.Uint64(id[:8])^stream))
+}
+
+// shuffleQuestions исходный список вопросов не изменяется
+func shuffleQuestions(specificSession *model.Session, questions []model.Question) []model.Question {
+	result := make([]model.Question, len(questions))
+	copy(result, questions)
+
+	newSessionRand(specificSession, uuid.Nil, shuffleStreamQuestions).Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+
+	return result
+}
+
+func shuffleAnswerOptions(specificSession *model.Session, question *model.Question) {
+	newSessionRand(specificSession, question.ID, shuffleStreamAnswerOptions).Shuffle(len(question.AnswerOptions), func(i, j int) {
+		question.AnswerOptions[i], question.AnswerOptions[j] = question.AnswerOptions[j], question.AnswerOptions[i]
+	})
+}
+
+// getPairs правая колонка перемешивается отдельно от левой, иначе пары совпадают.
+// Одинаковые правые части выводятся один раз, игроку их все равно не различить
+func getPairs(specificSession *model.Session, question *model.Question) []string {
+	result := make([]string, 0, len(question.AnswerOptions))
+	for _, answerOption := range question.AnswerOptions {
+		if !slices.Contains(result, answerOption.GetPair()) {
+			result = append(result, answerOption.GetPair())
+		}
+	}
+
+	newSessionRand(specificSession, question.ID, shuffleStreamPairs).Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+
+	return result
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestShuffleQuestions(t *testing.T) {
+	questions := make([]model.Question, 20)
+	for i := range questions {
+		questions[i].ID = uuid.New()
+	}
+	original := slices.Clone(questions)
+	ids := func(in []model.Question) []uuid.UUID {
+		result := make([]uuid.UUID, 0, len(in))
+		for _, question := range in {
+			result = append(result, question.ID)
+		}
+
+		return result
+	}
+
+	result := shuffleQuestions(&model.Session{ShuffleSeed: 1}, questions)
+	if !reflect.DeepEqual(questions, original) {
+		t.Fatal("source questions are changed")
+	}
+	if reflect.DeepEqual(ids(result), ids(original)) {
+		t.Error("questions are not shuffled")
+	}
+	if !slices.Equal(slices.SortedFunc(slices.Values(ids(result)), compareUUID), slices.SortedFunc(slices.Values(ids(original)), compareUUID)) {
+		t.Error("shuffled questions differ from source")
+	}
+
+	if repeated := shuffleQuestions(&model.Session{ShuffleSeed: 1}, questions); !reflect.DeepEqual(ids(repeated), ids(result)) {
+		t.Error("same seed gives different order")
+	}
+	if other := shuffleQuestions(&model.Session{ShuffleSeed: 2}, questions); reflect.DeepEqual(ids(other), ids(result)) {
+		t.Error("different seeds give same order")
+	}
+}
+
+func TestShuffleAnswerOptions(t *testing.T) {
+	newQuestion := func(id uuid.UUID) *model.Question {
+		question := &model.Question{ID: id, AnswerOptions: make([]model.AnswerOption, 20)}
+		for i := range question.AnswerOptions {
+			question.AnswerOptions[i].ID = model.AnswerOptionID(i + 1)
+		}
+
+		return question
+	}
+	order := func(question *model.Question) []model.AnswerOptionID {
+		result := make([]model.AnswerOptionID, 0, len(question.AnswerOptions))
+		for _, answerOption := range question.AnswerOptions {
+			result = append(result, answerOption.ID)
+		}
+
+		return result
+	}
+	specificSession := &model.Session{ShuffleSeed: 1}
+	id := uuid.New()
+
+	first := newQuestion(id)
+	shuffleAnswerOptions(specificSession, first)
+	second := newQuestion(id)
+	shuffleAnswerOptions(specificSession, second)
+	if !slices.Equal(order(first), order(second)) {
+		t.Error("same seed gives different order")
+	}
+	if slices.IsSorted(order(first)) {
+		t.Error("answer options are not shuffled")
+	}
+
+	// у каждого вопроса свой порядок вариантов, иначе варианты с тем же номером всегда оказываются на одном месте
+	other := newQuestion(uuid.New())
+	shuffleAnswerOptions(specificSession, other)
+	if slices.Equal(order(first), order(other)) {
+		t.Error("different questions give same order")
+	}
+}
+
+func TestGetPairs(t *testing.T) {
+	question := &model.Question{
+		ID:   uuid.New(),
+		Type: model.QuestionTypeMatching,
+		AnswerOptions: []model.AnswerOption{
+			{ID: 1, Answer: "Франция", Pair: structs.Pointer("Европа")},
+			{ID: 2, Answer: "Италия", Pair: structs.Pointer("Европа")},
+			{ID: 3, Answer: "Япония", Pair: structs.Pointer("Азия")},
+			{ID: 4, Answer: "Египет", Pair: structs.Pointer("Африка")},
+		},
+	}
+	specificSession := &model.Session{ShuffleSeed: 1}
+
+	result := getPairs(specificSession, question)
+	if !slices.Equal(slices.Sorted(slices.Values(result)), []string{"Азия", "Африка", "Европа"}) {
+		t.Errorf("expected distinct pairs, got %v", result)
+	}
+	if repeated := getPairs(specificSession, question); !slices.Equal(repeated, result) {
+		t.Errorf("same seed gives different order: %v, %v", result, repeated)
+	}
+}
+
+func compareUUID(a, b uuid.UUID) int {
+	return slices.Compare(a[:], b[:])
+}
@@ -0,0 +1,165 @@
+package session
+
+import (
+	"context"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/internal/quizzly/repositories/game"
+	"quizzly/internal/quizzly/repositories/session"
+	"quizzly/pkg/structs/collections/slices"
+
+	"github.com/google/uuid"
+)
+
+type attemptResult struct {
+	session    model.Session
+	statistics model.SessionStatistics
+}
+
+// GetStatistics результат считается по завершенной попытке, выбранной по настройке игры
+func (u *Usecase) GetStatistics(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*model.SessionStatistics, error) {
+	var result *model.SessionStatistics
+	return result, u.trm.Do(ctx, func(ctx context.Context) error {
+		specificGame, err := u.getGame(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		attempts, err := u.sessions.GetAttemptsBySpec(ctx, &session.Spec{
+			PlayerID: playerID,
+			GameID:   gameID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(attempts) == 0 {
+			return contracts.ErrSessionNotFound
+		}
+
+		finishedAttempts := slices.Filter(attempts, func(attempt model.Session) bool {
+			return attempt.Status == model.SessionStatusFinished
+		})
+		if len(finishedAttempts) == 0 {
+			return contracts.ErrSessionNotFinished
+		}
+
+		sessionItems, err := u.sessions.GetSessionBySpec(
+			ctx,
+			&session.ItemSpec{
+				PlayerID:    playerID,
+				GameID:      gameID,
+				AllAttempts: true,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		questions, err := u.games.GetQuestionsBySpec(ctx, &game.QuestionsSpec{
+			GameID: &gameID,
+		})
+		if err != nil {
+			return err
+		}
+
+		results := make([]attemptResult, 0, len(finishedAttempts))
+		for _, attempt := range finishedAttempts {
+			attemptItems := slices.Filter(sessionItems, func(item model.SessionItem) bool {
+				return item.SessionID == attempt.ID
+			})
+			if len(attemptItems) == 0 {
+				continue
+			}
+
+			results = append(results, attemptResult{
+				session:    attempt,
+				statistics: calculateStatistics(filterDrawnQuestions(questions, &attempt), attemptItems),
+			})
+		}
+		if len(results) == 0 {
+			return contracts.ErrSessionNotFound
+		}
+
+		counted := selectCountedAttempt(specificGame.Settings.AttemptPolicy, results)
+		result = &counted.statistics
+		result.Attempt = counted.session.Attempt
+		result.AttemptsCount = len(attempts)
+		result.AttemptsLeft = getAttemptsLeft(specificGame, &attempts[len(attempts)-1])
+		return nil
+	})
+}
+
+func calculateStatistics(questions []model.Question, sessionItems []model.SessionItem) model.SessionStatistics {
+	// При ветвлении игрок проходит только часть вопросов, максимум считается по пройденному маршруту
+	if model.IsBranching(questions) {
+		questions = slices.Filter(questions, func(question model.Question) bool {
+			return slices.Contains(sessionItems, func(item model.SessionItem) bool {
+				return item.QuestionID == question.ID
+			})
+		})
+	}
+
+	totalQuestions := int64(0)
+	correctAnswers := int64(0)
+	pendingReview := int64(0)
+	score := float64(0)
+	for _, item := range sessionItems {
+		if !item.IsScored() {
+			continue
+		}
+
+		if item.Score != nil {
+			score += *item.Score
+		}
+
+		totalQuestions++
+		if item.IsPendingReview() {
+			pendingReview++
+			continue
+		}
+		if item.IsCorrect == nil || !*item.IsCorrect {
+			continue
+		}
+
+		correctAnswers++
+	}
+
+	return model.SessionStatistics{
+		QuestionsCount:      totalQuestions,
+		CorrectAnswersCount: correctAnswers,
+		PendingReviewCount:  pendingReview,
+		Score:               score,
+		MaxScore:            getMaxScore(questions),
+	}
+}
+
+// selectCountedAttempt попытки упорядочены по номеру. Лучшая попытка выбирается как в таблице лидеров с порядком по баллам:
+// больше баллов, при равенстве больше правильных ответов, при полном равенстве более ранняя
+func selectCountedAttempt(policy model.AttemptPolicy, results []attemptResult) attemptResult {
+	switch policy {
+	case model.AttemptPolicyFirst:
+		return results[0]
+	case model.AttemptPolicyBest:
+		best := results[0]
+		for _, result := range results[1:] {
+			if result.statistics.Score > best.statistics.Score ||
+				result.statistics.Score == best.statistics.Score &&
+					result.statistics.CorrectAnswersCount > best.statistics.CorrectAnswersCount {
+				best = result
+			}
+		}
+		return best
+	default:
+		return results[len(results)-1]
+	}
+}
+
+// getAttemptsLeft сколько новых попыток доступно после последней, nil - без ограничений
+func getAttemptsLeft(specificGame *model.Game, lastAttempt *model.Session) *int {
+	if specificGame.Settings.MaxAttempts == nil {
+		return nil
+	}
+
+	left := max(*specificGame.Settings.MaxAttempts-lastAttempt.Attempt, 0)
+	return &left
+}
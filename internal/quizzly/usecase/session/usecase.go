@@ -5,11 +5,14 @@ import (
 	"errors"
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/google/uuid"
+	"math/rand/v2"
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
 	"quizzly/internal/quizzly/repositories/game"
 	"quizzly/internal/quizzly/repositories/player"
 	"quizzly/internal/quizzly/repositories/session"
+	"quizzly/pkg/broker"
+	"quizzly/pkg/structs/collections/slices"
 )
 
 type (
@@ -21,6 +24,7 @@ type (
 		sessions session.Repository
 		games    game.Repository
 		players  player.Repository
+		events   broker.Broker[model.GameEvent]
 		trm      trm.Manager
 
 		optionIDAcceptors map[model.QuestionType]AnswerOptionIDAcceptor
@@ -31,6 +35,7 @@ func NewUsecase(
 	sessions session.Repository,
 	games game.Repository,
 	players player.Repository,
+	events broker.Broker[model.GameEvent],
 	trm trm.Manager,
 	optionIDAcceptors map[model.QuestionType]AnswerOptionIDAcceptor,
 ) contracts.SessionUsecase {
@@ -38,17 +43,31 @@ func NewUsecase(
 		sessions:          sessions,
 		games:             games,
 		players:           players,
+		events:            events,
 		trm:               trm,
 		optionIDAcceptors: optionIDAcceptors,
 	}
 }
 
 func (u *Usecase) Start(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
-	return u.trm.Do(ctx, func(ctx context.Context) error {
-		if _, err := u.getActiveGame(ctx, gameID); err != nil {
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		specificGame, err := u.getActiveGame(ctx, gameID)
+		if err != nil {
 			return err
 		}
 
+		var questionIDs []uuid.UUID
+		if specificGame.Settings.QuestionPoolSize != nil {
+			questions, err := u.games.GetQuestionsBySpec(ctx, &game.QuestionsSpec{
+				GameID: &gameID,
+			})
+			if err != nil {
+				return err
+			}
+
+			questionIDs = drawQuestions(specificGame, questions)
+		}
+
 		specificPlayers, err := u.players.GetByIDs(ctx, []uuid.UUID{playerID})
 		if err != nil {
 			return err
@@ -66,16 +85,30 @@ func (u *Usecase) Start(ctx context.Context, gameID uuid.UUID, playerID uuid.UUI
 		return u.sessions.Insert(
 			ctx,
 			&model.Session{
-				PlayerID: playerID,
-				GameID:   gameID,
-				Status:   model.SessionStatusStarted,
+				PlayerID:    playerID,
+				GameID:      gameID,
+				Status:      model.SessionStatusStarted,
+				QuestionIDs: questionIDs,
+				ShuffleSeed: rand.Int64(),
+				Attempt:     1,
 			},
 		)
 	})
+	if err != nil {
+		return err
+	}
+
+	u.publish(model.GameEvent{
+		Type:     model.GameEventPlayerJoined,
+		GameID:   gameID,
+		PlayerID: &playerID,
+	})
+	return nil
 }
 
 func (u *Usecase) Finish(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
-	return u.trm.Do(ctx, func(ctx context.Context) error {
+	isFinished := false
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
 		if _, err := u.getActiveGame(ctx, gameID); err != nil {
 			return err
 		}
@@ -92,13 +125,26 @@ func (u *Usecase) Finish(ctx context.Context, gameID uuid.UUID, playerID uuid.UU
 		}
 
 		specificPlayerGame.Status = model.SessionStatusFinished
+		isFinished = true
 		return u.sessions.Update(ctx, specificPlayerGame)
 	})
+	if err != nil || !isFinished {
+		return err
+	}
+
+	u.publish(model.GameEvent{
+		Type:     model.GameEventSessionFinished,
+		GameID:   gameID,
+		PlayerID: &playerID,
+	})
+	return nil
 }
 
+// Restart каждая попытка сохраняется отдельной сессией, выпавшие игроку вопросы и их порядок переносятся в новую попытку
 func (u *Usecase) Restart(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
 	return u.trm.Do(ctx, func(ctx context.Context) error {
-		if _, err := u.getActiveGame(ctx, gameID); err != nil {
+		specificGame, err := u.getActiveGame(ctx, gameID)
+		if err != nil {
 			return err
 		}
 
@@ -114,81 +160,108 @@ func (u *Usecase) Restart(ctx context.Context, gameID uuid.UUID, playerID uuid.U
 			return err
 		}
 
-		err = u.sessions.DeleteSessionItemsBySessionID(ctx, specificPlayerGame.ID)
-		if err != nil {
-			return err
-		}
-
-		specificPlayerGame.Status = model.SessionStatusStarted
-		return u.sessions.Update(ctx, specificPlayerGame)
-	})
-}
-
-func (u *Usecase) GetStatistics(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*model.SessionStatistics, error) {
-	var result *model.SessionStatistics
-	return result, u.trm.Do(ctx, func(ctx context.Context) error {
-		specificPlayerGame, err := u.sessions.GetBySpec(ctx, &session.Spec{
+		sessionItems, err := u.sessions.GetSessionBySpec(ctx, &session.ItemSpec{
 			PlayerID: playerID,
 			GameID:   gameID,
 		})
 		if err != nil {
 			return err
 		}
-		if specificPlayerGame.Status != model.SessionStatusFinished {
-			return contracts.ErrSessionNotFinished
-		}
 
-		sessionItems, err := u.sessions.GetSessionBySpec(
-			ctx,
-			&session.ItemSpec{
-				PlayerID: playerID,
-				GameID:   gameID,
-			},
-		)
-		if err != nil {
-			return err
+		// Попытка без ответов не расходует лимит, игрок проходит ее заново
+		if !slices.Contains(sessionItems, func(item model.SessionItem) bool {
+			return item.AnsweredAt != nil
+		}) {
+			err = u.sessions.DeleteSessionItemsBySessionID(ctx, specificPlayerGame.ID)
+			if err != nil {
+				return err
+			}
+
+			specificPlayerGame.Status = model.SessionStatusStarted
+			return u.sessions.Update(ctx, specificPlayerGame)
 		}
-		if len(sessionItems) == 0 {
-			return contracts.ErrSessionNotFound
+
+		attemptsLeft := getAttemptsLeft(specificGame, specificPlayerGame)
+		if attemptsLeft != nil && *attemptsLeft == 0 {
+			return contracts.ErrAttemptsExhausted
 		}
 
-		totalQuestions := int64(len(sessionItems))
-		correctAnswers := int64(0)
-		for _, item := range sessionItems {
-			if item.IsCorrect == nil || !*item.IsCorrect {
-				continue
+		// Незаконченная попытка завершается и дальше учитывается наравне с остальными
+		if specificPlayerGame.Status == model.SessionStatusStarted {
+			specificPlayerGame.Status = model.SessionStatusFinished
+			if err := u.sessions.Update(ctx, specificPlayerGame); err != nil {
+				return err
 			}
-
-			correctAnswers++
 		}
 
-		result = &model.SessionStatistics{
-			QuestionsCount:      totalQuestions,
-			CorrectAnswersCount: correctAnswers,
-		}
-		return nil
+		return u.sessions.Insert(ctx, &model.Session{
+			PlayerID:    playerID,
+			GameID:      gameID,
+			Status:      model.SessionStatusStarted,
+			QuestionIDs: specificPlayerGame.QuestionIDs,
+			ShuffleSeed: specificPlayerGame.ShuffleSeed,
+			Attempt:     specificPlayerGame.Attempt + 1,
+		})
 	})
 }
 
-func (u *Usecase) GetExtendedSessions(ctx context.Context, gameID uuid.UUID, page int64, limit int64) (*contracts.GetExtendedSessionsOut, error) {
+func (u *Usecase) GetExtendedSessions(ctx context.Context, in *contracts.GetExtendedSessionsIn) (*contracts.GetExtendedSessionsOut, error) {
 	sessions, err := u.sessions.GetExtendedSessionsBySpec(ctx, &session.GetExtendedSessionSpec{
-		GameID: gameID,
+		GameID: in.GameID,
 		Page: &session.Page{
-			Number: page,
-			Limit:  limit,
+			Number: in.Page,
+			Limit:  in.Limit,
 		},
+		AllAttempts: in.AllAttempts,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &contracts.GetExtendedSessionsOut{
-		Result:     sessions.Result,
-		TotalCount: sessions.TotalCount,
+		Result:         sessions.Result,
+		TotalCount:     sessions.TotalCount,
+		FinishedCount:  sessions.FinishedCount,
+		AbandonedCount: sessions.AbandonedCount,
+	}, nil
+}
+
+func (u *Usecase) GetExtendedSession(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*model.ExtendedSession, error) {
+	specificSession, err := u.sessions.GetBySpec(ctx, &session.Spec{
+		PlayerID: playerID,
+		GameID:   gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessionItems, err := u.sessions.GetSessionBySpec(ctx, &session.ItemSpec{
+		PlayerID: playerID,
+		GameID:   gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ExtendedSession{
+		Session: *specificSession,
+		Items:   sessionItems,
 	}, nil
 }
 
 func (u *Usecase) getActiveGame(ctx context.Context, gameID uuid.UUID) (*model.Game, error) {
+	specificGame, err := u.getGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if specificGame.Status != model.GameStatusStarted {
+		return nil, contracts.ErrGameNotStarted
+	}
+
+	return specificGame, nil
+}
+
+func (u *Usecase) getGame(ctx context.Context, gameID uuid.UUID) (*model.Game, error) {
 	specificGames, err := u.games.GetBySpec(ctx, &game.Spec{
 		IDs: []uuid.UUID{gameID},
 	})
@@ -199,12 +272,7 @@ func (u *Usecase) getActiveGame(ctx context.Context, gameID uuid.UUID) (*model.G
 		return nil, contracts.ErrGameNotFound
 	}
 
-	specificGame := specificGames[0]
-	if specificGame.Status != model.GameStatusStarted {
-		return nil, errors.New("game isn't started")
-	}
-
-	return &specificGame, nil
+	return &specificGames[0], nil
 }
 
 func (u *Usecase) getSession(ctx context.Context, playerID uuid.UUID, gameID uuid.UUID) (*model.Session, error) {
@@ -227,5 +295,17 @@ func (u *Usecase) getSession(ctx context.Context, playerID uuid.UUID, gameID uui
 		return nil, err
 	}
 
+	// игрок вернулся в брошенное прохождение и продолжает его
+	if specificSession.Status == model.SessionStatusAbandoned {
+		specificSession.Status = model.SessionStatusStarted
+		if err := u.sessions.Update(ctx, specificSession); err != nil {
+			return nil, err
+		}
+	}
+
 	return specificSession, nil
 }
+
+func (u *Usecase) publish(event model.GameEvent) {
+	u.events.Publish(event.GameID.String(), event)
+}
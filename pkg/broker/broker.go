@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"sync"
+)
+
+const (
+	defaultBufferSize = 16
+)
+
+type (
+	subscriber[T any] struct {
+		messages chan T
+	}
+
+	DefaultBroker[T any] struct {
+		mu          sync.RWMutex
+		subscribers map[string]map[*subscriber[T]]struct{}
+		bufferSize  int
+	}
+)
+
+// NewDefaultBroker брокер сообщений внутри одного процесса.
+// Медленный подписчик не блокирует публикацию: сообщения, не поместившиеся в буфер, отбрасываются
+func NewDefaultBroker[T any]() *DefaultBroker[T] {
+	return &DefaultBroker[T]{
+		subscribers: map[string]map[*subscriber[T]]struct{}{},
+		bufferSize:  defaultBufferSize,
+	}
+}
+
+func (b *DefaultBroker[T]) Publish(topic string, message T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[topic] {
+		select {
+		case sub.messages <- message:
+		default:
+		}
+	}
+}
+
+func (b *DefaultBroker[T]) Subscribe(topic string) (<-chan T, func()) {
+	sub := &subscriber[T]{
+		messages: make(chan T, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	if _, ok := b.subscribers[topic]; !ok {
+		b.subscribers[topic] = map[*subscriber[T]]struct{}{}
+	}
+	b.subscribers[topic][sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return sub.messages, func() {
+		once.Do(func() {
+			b.unsubscribe(topic, sub)
+		})
+	}
+}
+
+func (b *DefaultBroker[T]) unsubscribe(topic string, sub *subscriber[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers[topic], sub)
+	if len(b.subscribers[topic]) == 0 {
+		delete(b.subscribers, topic)
+	}
+	close(sub.messages)
+}
@@ -0,0 +1,8 @@
+package broker
+
+type (
+	Broker[T any] interface {
+		Publish(topic string, message T)
+		Subscribe(topic string) (<-chan T, func())
+	}
+)
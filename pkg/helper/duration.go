@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSeconds разбирает положительное количество секунд, пустая строка означает отсутствие значения
+func ParseSeconds(in string) (*time.Duration, error) {
+	value := strings.TrimSpace(in)
+	if value == "" {
+		return nil, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return nil, errors.New("invalid seconds value")
+	}
+
+	result := time.Duration(seconds) * time.Second
+	return &result, nil
+}
+
+// ParseMinutes разбирает положительное количество минут, пустая строка означает отсутствие значения
+func ParseMinutes(in string) (*time.Duration, error) {
+	minutes, err := ParsePositiveInt(in)
+	if err != nil || minutes == nil {
+		return nil, err
+	}
+
+	result := time.Duration(*minutes) * time.Minute
+	return &result, nil
+}
@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ParseNumber разбирает число с учетом локали: десятичным разделителем может быть как точка, так и запятая.
+// Если встречаются оба разделителя, десятичным считается последний, а первый - разделителем разрядов
+func ParseNumber(in string) (float64, error) {
+	value := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f', '\'':
+			return -1
+		case '\u2212':
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(in))
+	if value == "" {
+		return 0, errors.New("empty number")
+	}
+
+	lastDot := strings.LastIndex(value, ".")
+	lastComma := strings.LastIndex(value, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0 && lastDot > lastComma:
+		value = strings.ReplaceAll(value, ",", "")
+	case lastDot >= 0 && lastComma >= 0:
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	case lastComma >= 0:
+		value = strings.ReplaceAll(value, ",", ".")
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// ParsePositiveInt разбирает целое положительное число, пустая строка означает отсутствие значения
+func ParsePositiveInt(in string) (*int, error) {
+	value := strings.TrimSpace(in)
+	if value == "" {
+		return nil, nil
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil || result <= 0 {
+		return nil, errors.New("invalid positive number")
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"strings"
+	"time"
+)
+
+// dateTimeLayout формат значения поля datetime-local
+const dateTimeLayout = "2006-01-02T15:04"
+
+// ParseDateTime разбирает значение поля datetime-local в указанной зоне, пустая строка означает отсутствие значения
+func ParseDateTime(in string, location *time.Location) (*time.Time, error) {
+	value := strings.TrimSpace(in)
+	if value == "" {
+		return nil, nil
+	}
+
+	result, err := time.ParseInLocation(dateTimeLayout, value, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FormatDateTime значение для поля datetime-local
+func FormatDateTime(in time.Time, location *time.Location) string {
+	return in.In(location).Format(dateTimeLayout)
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"quizzly/internal/quizzly"
+	"quizzly/internal/quizzly/formats/archive"
+	"quizzly/internal/quizzly/formats/gift"
+	"quizzly/internal/quizzly/formats/lms"
+	"quizzly/internal/quizzly/formats/sheet"
 	"quizzly/pkg/cookie"
 	"quizzly/pkg/files"
 	"quizzly/pkg/logger"
@@ -17,6 +22,7 @@ import (
 	"quizzly/web/frontend/handlers/admin/game"
 	"quizzly/web/frontend/handlers/admin/question"
 	"quizzly/web/frontend/handlers/admin/static/faq"
+	gameEvents "quizzly/web/frontend/handlers/events"
 	files2 "quizzly/web/frontend/handlers/files"
 	gamePublic "quizzly/web/frontend/handlers/public/game"
 	"quizzly/web/frontend/handlers/public/login"
@@ -96,6 +102,7 @@ func adminRoutes(
 	quizzlyConfig *quizzly.Configuration,
 	authClient supabase.Auth,
 	filesManager files.Manager,
+	realtime bool,
 ) {
 	security := authClient.MiddlewareAuth
 
@@ -106,13 +113,42 @@ func adminRoutes(
 	mux.HandleFunc("DELETE /admin/question", "/admin/question", security(handlers.Templ[question.GetDeleteData](question.NewPostDeleteHandler(
 		quizzlyConfig.Game.MustGet(),
 	), log)))
+	mux.HandleFunc("POST /admin/question/next", "/admin/question/next", security(handlers.Templ[question.PostNextData](question.NewPostNextHandler(
+		quizzlyConfig.Game.MustGet(),
+	), log)))
+	mux.HandleFunc("POST /admin/question/import/gift", "/admin/question/import/gift", security(handlers.Templ[question.PostImportData](question.NewPostImportHandler(
+		quizzlyConfig.Game.MustGet(),
+		"gift",
+		gift.Parse,
+	), log)))
+	mux.HandleFunc("POST /admin/question/import/sheet", "/admin/question/import/sheet", security(handlers.Templ[question.PostImportData](question.NewPostImportHandler(
+		quizzlyConfig.Game.MustGet(),
+		"sheet",
+		sheet.Parse,
+	), log)))
 	mux.HandleFunc("GET /admin/question/list", "/admin/question/list", security(handlers.Templ[question.GetListData](question.NewGetHandler(quizzlyConfig.Game.MustGet()), log)))
 
-	mux.HandleFunc("GET /admin/game/new", "/admin/game/new", security(handlers.Templ[struct{}](game.NewGetCreateHandler(quizzlyConfig.Game.MustGet()), log)))
+	mux.HandleFunc("GET /admin/game/new", "/admin/game/new", security(handlers.Templ[game.GetCreateData](game.NewGetCreateHandler(quizzlyConfig.Game.MustGet()), log)))
 	mux.HandleFunc("GET /admin/game/{game_id}", "/admin/game/:game_id", security(handlers.Templ[game.GetGamePageData](game.NewGetPageHandler(
 		quizzlyConfig.Game.MustGet(),
 		config.link.MustGet(),
 	), log)))
+	mux.HandleFunc("GET /admin/game/{game_id}/archive", "/admin/game/:game_id/archive", security(game.NewGetArchiveHandler(
+		quizzlyConfig.Game.MustGet(),
+		filesManager,
+		log,
+	).Handle()))
+	// запас сверх размера архива на остальные части формы
+	mux.HandleFunc("POST /admin/game/import", "/admin/game/import", security(handlers.LimitBody(archive.MaxSize+1<<20, handlers.Templ[struct{}](game.NewPostImportHandler(
+		quizzlyConfig.Game.MustGet(),
+		filesManager,
+	), log))))
+	mux.HandleFunc("GET /admin/game/{game_id}/export/{format}", "/admin/game/:game_id/export/:format", security(game.NewGetExportHandler(
+		quizzlyConfig.Game.MustGet(),
+		lms.NewExporter(filesManager),
+		log,
+	).Handle()))
+	mux.HandleFunc("POST /admin/game/{game_id}/markdown", "/admin/game/:game_id/markdown", security(handlers.Templ[game.PostMarkdownData](game.NewPostMarkdownHandler(quizzlyConfig.Game.MustGet()), log)))
 	mux.HandleFunc("POST /admin/game/{game_id}/update", "/admin/game/:game_id/update", security(handlers.Templ[game.PostUpdateData](game.NewPostUpdateHandler(quizzlyConfig.Game.MustGet()), log)))
 	mux.HandleFunc("POST /admin/game/start", "/admin/game/start", security(handlers.Templ[game.PostStartData](game.NewPostStartHandler(
 		quizzlyConfig.Game.MustGet(),
@@ -122,6 +158,32 @@ func adminRoutes(
 		quizzlyConfig.Game.MustGet(),
 		config.link.MustGet(),
 	), log)))
+	mux.HandleFunc("POST /admin/game/{game_id}/live/next", "/admin/game/:game_id/live/next", security(handlers.Templ[struct{}](game.NewPostLiveNextHandler(
+		quizzlyConfig.Game.MustGet(),
+		config.link.MustGet(),
+	), log)))
+	mux.HandleFunc("POST /admin/game/{game_id}/live/close", "/admin/game/:game_id/live/close", security(handlers.Templ[struct{}](game.NewPostLiveCloseHandler(
+		quizzlyConfig.Game.MustGet(),
+		config.link.MustGet(),
+	), log)))
+
+	mux.HandleFunc("GET /admin/game/{game_id}/monitor", "/admin/game/:game_id/monitor", security(handlers.Templ[struct{}](game.NewGetMonitorHandler(
+		quizzlyConfig.Game.MustGet(),
+		config.sessions.MustGet(),
+		realtime,
+	), log)))
+
+	mux.HandleFunc("GET /admin/game/{game_id}/review", "/admin/game/:game_id/review", security(handlers.Templ[struct{}](game.NewGetReviewListHandler(
+		config.sessions.MustGet(),
+	), log)))
+	mux.HandleFunc("POST /admin/game/{game_id}/review", "/admin/game/:game_id/review", security(handlers.Templ[game.PostReviewData](game.NewPostReviewHandler(
+		quizzlyConfig.Session.MustGet(),
+		config.sessions.MustGet(),
+	), log)))
+
+	mux.HandleFunc("GET /admin/game/{game_id}/leaderboard", "/admin/game/:game_id/leaderboard", security(handlers.Templ[game.GetLeaderboardData](game.NewGetLeaderboardHandler(
+		config.sessions.MustGet(),
+	), log)))
 
 	mux.HandleFunc("GET /admin/game/list", "/admin/game/list", security(handlers.Templ[struct{}](game.NewGetListHandler(quizzlyConfig.Game.MustGet()), log)))
 	mux.HandleFunc("GET /admin/game/session/list", "/admin/game/session/list", security(handlers.Templ[game.GetSessionListData](game.NewGetSessionListHandler(config.sessions.MustGet()), log)))
@@ -135,6 +197,7 @@ func publicRoutes(
 	config *configuration,
 	quizzlyConfig *quizzly.Configuration,
 	authClient supabase.Auth,
+	realtime bool,
 ) {
 	security := authClient.MiddlewareTrace
 
@@ -146,6 +209,7 @@ func publicRoutes(
 		quizzlyConfig.Session.MustGet(),
 		config.player.MustGet(),
 		config.link.MustGet(),
+		realtime,
 	)
 	gameRestartPageHandler := gamePublic.NewGetRestartPageHandler(
 		quizzlyConfig.Game.MustGet(),
@@ -178,6 +242,7 @@ func publicRoutes(
 		quizzlyConfig.Session.MustGet(),
 		config.player.MustGet(),
 		config.link.MustGet(),
+		realtime,
 	), log))
 
 	mux.HandleFunc("GET /game/{game_id}/restart", "/game/:game_id/restart", security(handlers.Templ[gamePublic.GetRestartPageData](gameRestartPageHandler, log)))
@@ -188,6 +253,12 @@ func publicRoutes(
 	// backwards compatibility
 	mux.HandleFunc("GET /game/results", "/game/:game_id/results/:player_id (old)", security(handlers.Templ[gamePublic.GetPlayResultsPageData](gameResultsPagehandler, log)))
 
+	mux.HandleFunc("GET /game/{game_id}/leaderboard", "/game/:game_id/leaderboard", security(handlers.Templ[gamePublic.GetLeaderboardPageData](gamePublic.NewGetLeaderboardPageHandler(
+		quizzlyConfig.Game.MustGet(),
+		config.sessions.MustGet(),
+		config.player.MustGet(),
+	), log)))
+
 	mux.HandleFunc("POST /game/{game_id}/player/{player_id}/rename", "/game/:game_id/player/:player_id/rename", security(handlers.Templ[gamePublic.PostRenamePlayerData](gameRenamePlayerHandler, log)))
 }
 
@@ -203,6 +274,7 @@ func NewServer(
 	config := &configuration{
 		sessions: structs.NewSingleton(func() (sessionService.Service, error) {
 			return sessionService.NewService(
+				quizzlyConfig.Game.MustGet(),
 				quizzlyConfig.Session.MustGet(),
 				quizzlyConfig.Player.MustGet(),
 			), nil
@@ -265,16 +337,34 @@ func NewServer(
 		promhttp.Handler().ServeHTTP(w, r)
 	})
 
-	adminRoutes(muxExtended, config, log, quizzlyConfig, authClient, filesManager)
-	publicRoutes(muxExtended, log, config, quizzlyConfig, authClient)
+	// SSE требует постоянного соединения, в lambda страницы работают без него
+	realtime := serverType == ServerTypeHttp
+	if realtime {
+		// регистрируется напрямую, без middleware метрик: долгий стрим им не нужен
+		mux.HandleFunc("GET /game/{game_id}/events", gameEvents.NewGetGameEventsHandler(quizzlyConfig.Events.MustGet(), log).Handle())
+		mux.HandleFunc("GET /admin/game/{game_id}/monitor/events", authClient.MiddlewareAuth(gameEvents.NewGetGameMonitorEventsHandler(
+			quizzlyConfig.Events.MustGet(),
+			config.sessions.MustGet(),
+			log,
+		).Handle()))
+	}
+
+	adminRoutes(muxExtended, config, log, quizzlyConfig, authClient, filesManager, realtime)
+	publicRoutes(muxExtended, log, config, quizzlyConfig, authClient, realtime)
 
+	// закрывается при остановке сервера, чтобы завершить открытые SSE соединения
+	baseCtx, cancelBaseCtx := context.WithCancel(context.Background())
 	server := &http.Server{
 		Addr:         settings.Port,
 		Handler:      muxExtended.mux, // Implement your handlers function
 		ReadTimeout:  settings.ReadTimeout,
 		WriteTimeout: settings.WriteTimeout,
 		IdleTimeout:  settings.IdleTimeout,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
+	server.RegisterOnShutdown(cancelBaseCtx)
 
 	return &ServerInstance{
 		serverLambda: httpadapter.New(muxExtended.mux).ProxyWithContext,
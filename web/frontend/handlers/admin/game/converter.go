@@ -15,6 +15,7 @@ func convertModelGameToHandlersGame(game *model.Game) *handlers.Game {
 	return &handlers.Game{
 		ID:        game.ID,
 		Status:    game.Status,
+		Type:      game.Type,
 		Title:     title,
 		CreatedAt: game.CreatedAt,
 		Settings: handlers.GameSettings{
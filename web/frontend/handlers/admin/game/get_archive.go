@@ -0,0 +1,75 @@
+package game
+
+import (
+	"bytes"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/formats/archive"
+	"quizzly/pkg/files"
+	"quizzly/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type GetArchiveHandler struct {
+	uc     contracts.GameUsecase
+	images files.Manager
+	log    logger.Logger
+}
+
+func NewGetArchiveHandler(uc contracts.GameUsecase, images files.Manager, log logger.Logger) *GetArchiveHandler {
+	return &GetArchiveHandler{
+		uc:     uc,
+		images: images,
+		log:    log,
+	}
+}
+
+func (h *GetArchiveHandler) Handle() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		gameID, err := uuid.Parse(r.PathValue(pathValueGameID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		buffer := bytes.Buffer{}
+		if err = h.write(r, gameID, &buffer); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			h.log.Error("handle request error", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="game.zip"`)
+		if _, err = w.Write(buffer.Bytes()); err != nil {
+			h.log.Error("handle request error", err)
+		}
+	}
+}
+
+func (h *GetArchiveHandler) write(r *http.Request, gameID uuid.UUID, buffer *bytes.Buffer) error {
+	game, err := h.uc.Get(r.Context(), gameID)
+	if err != nil {
+		return err
+	}
+
+	questions, err := h.uc.GetQuestions(r.Context(), gameID)
+	if err != nil {
+		return err
+	}
+
+	images, err := formats.LoadImages(r.Context(), h.images, questions)
+	if err != nil {
+		return err
+	}
+
+	return archive.Write(buffer, &archive.Game{
+		Title:     game.Title,
+		Type:      game.Type,
+		Settings:  game.Settings,
+		Questions: questions,
+		Images:    images,
+	})
+}
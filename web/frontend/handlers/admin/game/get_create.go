@@ -11,6 +11,10 @@ import (
 )
 
 type (
+	GetCreateData struct {
+		Type *string `schema:"type"`
+	}
+
 	GetCreateHandler struct {
 		uc contracts.GameUsecase
 	}
@@ -22,13 +26,18 @@ func NewGetCreateHandler(uc contracts.GameUsecase) *GetCreateHandler {
 	}
 }
 
-func (h *GetCreateHandler) Handle(_ http.ResponseWriter, request *http.Request, _ struct{}) (templ.Component, error) {
+func (h *GetCreateHandler) Handle(_ http.ResponseWriter, request *http.Request, in GetCreateData) (templ.Component, error) {
+	gameType := model.GameTypeAsync
+	if in.Type != nil && *in.Type == string(model.GameTypeLive) {
+		gameType = model.GameTypeLive
+	}
+
 	authContext := request.Context().(supabase.AuthContext)
 	gameID, err := h.uc.Create(
 		request.Context(),
 		&contracts.CreateGameIn{
 			AuthorID: authContext.UserID(),
-			Type:     model.GameTypeAsync,
+			Type:     gameType,
 		},
 	)
 	if err != nil {
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats/lms"
+	"quizzly/internal/quizzly/formats/markdown"
+	"quizzly/internal/quizzly/formats/sheet"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/logger"
+	"quizzly/pkg/structs/collections/slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const pathValueFormat = "format"
+
+type (
+	exportFormat struct {
+		contentType string
+		fileName    string
+		write       func(ctx context.Context, out io.Writer, game *model.Game, questions []model.Question) error
+	}
+
+	GetExportHandler struct {
+		uc      contracts.GameUsecase
+		formats map[string]exportFormat
+		log     logger.Logger
+	}
+)
+
+func NewGetExportHandler(uc contracts.GameUsecase, exporter *lms.Exporter, log logger.Logger) *GetExportHandler {
+	return &GetExportHandler{
+		uc: uc,
+		formats: map[string]exportFormat{
+			"csv": {
+				contentType: "text/csv; charset=utf-8",
+				fileName:    "questions.csv",
+				write:       writeQuestions(sheet.WriteCSV),
+			},
+			"xlsx": {
+				contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+				fileName:    "questions.xlsx",
+				write:       writeQuestions(sheet.WriteXLSX),
+			},
+			"markdown": {
+				contentType: "text/markdown; charset=utf-8",
+				fileName:    "game.md",
+				write: func(_ context.Context, out io.Writer, game *model.Game, questions []model.Question) error {
+					return markdown.Write(out, game.Title, questions)
+				},
+			},
+			"moodle": {
+				contentType: "application/xml",
+				fileName:    "questions-moodle.xml",
+				write: func(ctx context.Context, out io.Writer, _ *model.Game, questions []model.Question) error {
+					return exporter.WriteMoodleXML(ctx, out, questions)
+				},
+			},
+			"qti": {
+				contentType: "application/zip",
+				fileName:    "questions-qti.zip",
+				write: func(ctx context.Context, out io.Writer, game *model.Game, questions []model.Question) error {
+					title := "Игра от " + game.CreatedAt.Format("02.01.2006")
+					if game.Title != nil {
+						title = *game.Title
+					}
+
+					return exporter.WriteQTI(ctx, out, title, questions)
+				},
+			},
+		},
+		log: log,
+	}
+}
+
+func (h *GetExportHandler) Handle() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, ok := h.formats[r.PathValue(pathValueFormat)]
+		if !ok {
+			http.Error(w, "unknown export format", http.StatusNotFound)
+			return
+		}
+
+		gameID, err := uuid.Parse(r.PathValue(pathValueGameID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// файл собирается целиком до отправки, чтобы при ошибке не отдать клиенту половину таблицы
+		buffer := bytes.Buffer{}
+		err = h.write(r.Context(), gameID, format, &buffer)
+		var unsupportedErr *markdown.UnsupportedError
+		if errors.As(err, &unsupportedErr) {
+			numbers := slices.SafeMap(unsupportedErr.Numbers, strconv.Itoa)
+			http.Error(w, "В Markdown можно выгрузить только вопросы с выбором ответа и опросы. Не подходят вопросы: "+strings.Join(numbers, ", "), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			h.log.Error("handle request error", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+format.fileName+`"`)
+		if _, err = w.Write(buffer.Bytes()); err != nil {
+			h.log.Error("handle request error", err)
+		}
+	}
+}
+
+func (h *GetExportHandler) write(ctx context.Context, gameID uuid.UUID, format exportFormat, out io.Writer) error {
+	game, err := h.uc.Get(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	questions, err := h.uc.GetQuestions(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	return format.write(ctx, out, game, questions)
+}
+
+// writeQuestions для форматов, которым нужны только вопросы
+func writeQuestions(write func(out io.Writer, questions []model.Question) error) func(context.Context, io.Writer, *model.Game, []model.Question) error {
+	return func(_ context.Context, out io.Writer, _ *model.Game, questions []model.Question) error {
+		return write(out, questions)
+	}
+}
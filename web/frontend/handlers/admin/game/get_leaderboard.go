@@ -0,0 +1,49 @@
+package game
+
+import (
+	"net/http"
+	"quizzly/internal/quizzly/model"
+	"quizzly/web/frontend/services/session"
+	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+)
+
+type (
+	GetLeaderboardData struct {
+		Order *string `schema:"order"`
+	}
+
+	GetLeaderboardHandler struct {
+		sessionService session.Service
+	}
+)
+
+func NewGetLeaderboardHandler(sessionService session.Service) *GetLeaderboardHandler {
+	return &GetLeaderboardHandler{
+		sessionService: sessionService,
+	}
+}
+
+func (h *GetLeaderboardHandler) Handle(_ http.ResponseWriter, request *http.Request, in GetLeaderboardData) (templ.Component, error) {
+	gameID, err := uuid.Parse(request.PathValue(pathValueGameID))
+	if err != nil {
+		return nil, err
+	}
+
+	order := model.LeaderboardOrderScore
+	if in.Order != nil && *in.Order == string(model.LeaderboardOrderCorrectAnswers) {
+		order = model.LeaderboardOrderCorrectAnswers
+	}
+
+	items, err := h.sessionService.Leaderboard(request.Context(), &session.LeaderboardSpec{
+		GameID: gameID,
+		Order:  order,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return frontendAdminGame.Leaderboard(gameID, order, items), nil
+}
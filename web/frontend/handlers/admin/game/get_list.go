@@ -43,6 +43,7 @@ func (h *GetListHandler) Handle(_ http.ResponseWriter, request *http.Request, _
 	components = append(components, frontendComponents.Header(
 		getListTitle,
 		frontendAdminGame.ActionAddNewGame(),
+		frontendAdminGame.ActionImportGame(),
 	))
 	for _, game := range games {
 		game := game
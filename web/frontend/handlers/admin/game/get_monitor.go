@@ -0,0 +1,50 @@
+package game
+
+import (
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/web/frontend/services/session"
+	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
+)
+
+type (
+	GetMonitorHandler struct {
+		uc             contracts.GameUsecase
+		sessionService session.Service
+		realtime       bool
+	}
+)
+
+func NewGetMonitorHandler(
+	uc contracts.GameUsecase,
+	sessionService session.Service,
+	realtime bool,
+) *GetMonitorHandler {
+	return &GetMonitorHandler{
+		uc:             uc,
+		sessionService: sessionService,
+		realtime:       realtime,
+	}
+}
+
+func (h *GetMonitorHandler) Handle(_ http.ResponseWriter, request *http.Request, _ struct{}) (templ.Component, error) {
+	gameID, err := uuid.Parse(request.PathValue(pathValueGameID))
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := h.uc.Get(request.Context(), gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := h.sessionService.MonitorList(request.Context(), &session.Spec{GameID: gameID})
+	if err != nil {
+		return nil, err
+	}
+
+	return frontendAdminGame.Monitor(gameID, game.Status == model.GameStatusStarted, h.realtime, items), nil
+}
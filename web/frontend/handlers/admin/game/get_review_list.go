@@ -0,0 +1,36 @@
+package game
+
+import (
+	"net/http"
+	"quizzly/web/frontend/services/session"
+	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+)
+
+type (
+	GetReviewListHandler struct {
+		sessionService session.Service
+	}
+)
+
+func NewGetReviewListHandler(sessionService session.Service) *GetReviewListHandler {
+	return &GetReviewListHandler{
+		sessionService: sessionService,
+	}
+}
+
+func (h *GetReviewListHandler) Handle(_ http.ResponseWriter, request *http.Request, _ struct{}) (templ.Component, error) {
+	gameID, err := uuid.Parse(request.PathValue(pathValueGameID))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := h.sessionService.ReviewList(request.Context(), &session.Spec{GameID: gameID})
+	if err != nil {
+		return nil, err
+	}
+
+	return frontendAdminGame.ReviewList(gameID, items), nil
+}
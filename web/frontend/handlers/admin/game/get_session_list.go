@@ -2,6 +2,7 @@ package game
 
 import (
 	"net/http"
+	"quizzly/web/frontend/handlers"
 	"quizzly/web/frontend/services/session"
 	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
 	frontendComponents "quizzly/web/frontend/templ/components"
@@ -18,6 +19,8 @@ type (
 	GetSessionListData struct {
 		GameID     uuid.UUID `schema:"game_id"`
 		PageNumber *int64    `schema:"page_number"`
+		// AllAttempts показать все попытки игроков, а не только последние
+		AllAttempts *bool `schema:"all_attempts"`
 	}
 
 	GetSessionListHandler struct {
@@ -40,7 +43,8 @@ func (h *GetSessionListHandler) Handle(_ http.ResponseWriter, request *http.Requ
 	sessionList, err := h.sessionService.List(
 		request,
 		&session.Spec{
-			GameID: in.GameID,
+			GameID:      in.GameID,
+			AllAttempts: in.AllAttempts != nil && *in.AllAttempts,
 		},
 		page,
 		defaultLimit,
@@ -50,11 +54,18 @@ func (h *GetSessionListHandler) Handle(_ http.ResponseWriter, request *http.Requ
 	}
 
 	return frontendComponents.Composition(
-		frontendAdminGame.SessionListStatistics(sessionList.TotalCount),
+		frontendAdminGame.SessionListStatistics(handlers.SessionListStatistics{
+			TotalCount:     int(sessionList.TotalCount),
+			FinishedCount:  int(sessionList.FinishedCount),
+			AbandonedCount: int(sessionList.AbandonedCount),
+			AllAttempts:    in.AllAttempts != nil && *in.AllAttempts,
+		}),
 		frontendComponents.Table(
 			[]string{
 				"Имя",
+				"Попытка",
 				"Процент прохождения",
+				"Баллы",
 				"Дата старта",
 				"Дата последнего ответа",
 				"Статус прохождения"},
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/formats/archive"
+	"quizzly/pkg/files"
+	"quizzly/pkg/supabase"
+	"quizzly/web/frontend/handlers"
+	frontendComponents "quizzly/web/frontend/templ/components"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+)
+
+const gameFileName = "game_file"
+
+// questionErrors ошибки в импортируемых вопросах, которые автор может исправить
+var questionErrors = map[error]string{
+	archive.ErrInvalidQuestionID:        "нет идентификатора вопроса или он повторяется",
+	contracts.ErrEmptyAnswerOptions:     "нет вариантов ответа",
+	contracts.ErrNoCorrectAnswerOptions: "не указан правильный ответ",
+	contracts.ErrInvalidAnswerOptions:   "варианты ответа не подходят для типа вопроса",
+}
+
+type PostImportHandler struct {
+	uc     contracts.GameUsecase
+	images files.Manager
+}
+
+func NewPostImportHandler(uc contracts.GameUsecase, images files.Manager) *PostImportHandler {
+	return &PostImportHandler{
+		uc:     uc,
+		images: images,
+	}
+}
+
+func (h *PostImportHandler) Handle(_ http.ResponseWriter, request *http.Request, _ struct{}) (templ.Component, error) {
+	file, _, err := request.FormFile(gameFileName)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, handlers.BadRequest(errors.New("выберите архив с игрой"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	game, err := archive.Read(file)
+	if errors.Is(err, archive.ErrInvalidArchive) {
+		return nil, handlers.BadRequest(errors.New("файл не является архивом игры или поврежден"))
+	}
+	if errors.Is(err, formats.ErrTooLarge) {
+		return nil, handlers.BadRequest(errors.New("архив слишком большой"))
+	}
+	if err = questionError(err); err != nil {
+		return nil, err
+	}
+
+	// изображения получают новые имена, чтобы не перезаписать файлы других игр
+	imageIDs := make(map[string]string, len(game.Images))
+	for name := range game.Images {
+		imageIDs[name] = uuid.New().String() + path.Ext(name)
+	}
+	for i := range game.Questions {
+		if game.Questions[i].ImageID == nil {
+			continue
+		}
+
+		imageID := imageIDs[*game.Questions[i].ImageID]
+		game.Questions[i].ImageID = &imageID
+	}
+
+	authContext := request.Context().(supabase.AuthContext)
+	gameID, err := h.uc.ImportGame(
+		request.Context(),
+		&contracts.CreateGameIn{
+			AuthorID: authContext.UserID(),
+			Type:     game.Type,
+			Title:    game.Title,
+			Settings: game.Settings,
+		},
+		game.Questions,
+	)
+	if err = questionError(err); err != nil {
+		return nil, err
+	}
+
+	// изображения загружаются только после сохранения игры, чтобы от отклоненного архива не оставалось файлов
+	for name, data := range game.Images {
+		err = h.images.Upload(request.Context(), &files.UploadFile{
+			Data: bytes.NewReader(data),
+			Name: imageIDs[name],
+			Size: int64(len(data)),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return frontendComponents.Redirect(fmt.Sprintf("/admin/game/%s", gameID.String())), nil
+}
+
+// questionError ошибки в вопросах архива показываются автору с номером вопроса
+func questionError(err error) error {
+	var target *contracts.QuestionError
+	if !errors.As(err, &target) {
+		return err
+	}
+
+	var invalidErr *archive.InvalidQuestionError
+	if errors.As(target.Err, &invalidErr) {
+		return handlers.BadRequest(fmt.Errorf("вопрос %d: %s", target.Number, invalidErr.Err))
+	}
+
+	message, ok := questionErrors[target.Err]
+	if !ok {
+		return err
+	}
+
+	return handlers.BadRequest(fmt.Errorf("вопрос %d: %s", target.Number, message))
+}
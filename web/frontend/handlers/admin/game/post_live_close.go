@@ -0,0 +1,50 @@
+package game
+
+import (
+	"errors"
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/web/frontend/services/link"
+)
+
+type (
+	PostLiveCloseHandler struct {
+		uc contracts.GameUsecase
+
+		service *service
+	}
+)
+
+func NewPostLiveCloseHandler(
+	uc contracts.GameUsecase,
+	linkService link.Service,
+) *PostLiveCloseHandler {
+	return &PostLiveCloseHandler{
+		uc: uc,
+		service: &service{
+			uc:          uc,
+			linkService: linkService,
+		},
+	}
+}
+
+func (h *PostLiveCloseHandler) Handle(_ http.ResponseWriter, request *http.Request, _ struct{}) (templ.Component, error) {
+	pathGameID := request.PathValue(pathValueGameID)
+	if pathGameID == "" {
+		return nil, errors.New("game_id is absent in path")
+	}
+
+	gameID, err := uuid.Parse(pathGameID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.uc.CloseLiveQuestion(request.Context(), gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.liveControl(request.Context(), gameID)
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"errors"
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/web/frontend/handlers"
+	"quizzly/web/frontend/services/link"
+)
+
+type (
+	PostLiveNextHandler struct {
+		uc contracts.GameUsecase
+
+		service *service
+	}
+)
+
+func NewPostLiveNextHandler(
+	uc contracts.GameUsecase,
+	linkService link.Service,
+) *PostLiveNextHandler {
+	return &PostLiveNextHandler{
+		uc: uc,
+		service: &service{
+			uc:          uc,
+			linkService: linkService,
+		},
+	}
+}
+
+func (h *PostLiveNextHandler) Handle(_ http.ResponseWriter, request *http.Request, _ struct{}) (templ.Component, error) {
+	pathGameID := request.PathValue(pathValueGameID)
+	if pathGameID == "" {
+		return nil, errors.New("game_id is absent in path")
+	}
+
+	gameID, err := uuid.Parse(pathGameID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.uc.NextLiveQuestion(request.Context(), gameID)
+	if errors.Is(err, contracts.ErrQuestionQueueIsEmpty) {
+		return nil, handlers.BadRequest(errors.New("вопросы закончились"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.liveControl(request.Context(), gameID)
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/formats/markdown"
+	"quizzly/web/frontend/handlers"
+	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
+	frontendAdminQuestion "quizzly/web/frontend/templ/admin/question"
+	frontendComponents "quizzly/web/frontend/templ/components"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+)
+
+type (
+	PostMarkdownData struct {
+		Markdown string `schema:"markdown"`
+	}
+
+	PostMarkdownHandler struct {
+		uc contracts.GameUsecase
+	}
+)
+
+func NewPostMarkdownHandler(uc contracts.GameUsecase) *PostMarkdownHandler {
+	return &PostMarkdownHandler{uc: uc}
+}
+
+func (h *PostMarkdownHandler) Handle(writer http.ResponseWriter, request *http.Request, in PostMarkdownData) (templ.Component, error) {
+	gameID, err := uuid.Parse(request.PathValue(pathValueGameID))
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := markdown.Parse(strings.NewReader(in.Markdown))
+	var report formats.Report
+	if errors.As(err, &report) {
+		lines := make([]string, 0, len(report))
+		for _, item := range report {
+			lines = append(lines, item.Error())
+		}
+		return h.report(writer, lines), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(document.Questions) == 0 {
+		return nil, handlers.BadRequest(errors.New(`в тексте нет вопросов, вопрос начинается с заголовка "## "`))
+	}
+
+	err = h.uc.ImportQuestions(request.Context(), gameID, document.Title, document.Questions)
+	var questionErr *contracts.QuestionError
+	if errors.As(err, &questionErr) {
+		if message, ok := questionErrors[questionErr.Err]; ok {
+			return h.report(writer, []string{fmt.Sprintf("вопрос %d: %s", questionErr.Number, message)}), nil
+		}
+	}
+	if errors.Is(err, contracts.ErrGameAlreadyStarted) {
+		return nil, handlers.BadRequest(errors.New("вопросы можно добавить только до начала игры"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// страница перезагружается целиком, чтобы обновить название игры и список вопросов
+	return frontendComponents.Redirect(fmt.Sprintf("/admin/game/%s", gameID.String())), nil
+}
+
+// report отчет выводится под полем ввода, текст в поле остается для исправления
+func (h *PostMarkdownHandler) report(writer http.ResponseWriter, lines []string) templ.Component {
+	writer.Header().Set("HX-Retarget", "#"+frontendAdminGame.MarkdownResultID)
+	return frontendAdminQuestion.ImportReport(lines)
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/web/frontend/services/session"
+	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+)
+
+type (
+	PostReviewData struct {
+		ItemID    int64  `schema:"item_id"`
+		IsCorrect bool   `schema:"is_correct"`
+		Comment   string `schema:"comment"`
+	}
+
+	PostReviewHandler struct {
+		uc             contracts.SessionUsecase
+		sessionService session.Service
+	}
+)
+
+func NewPostReviewHandler(uc contracts.SessionUsecase, sessionService session.Service) *PostReviewHandler {
+	return &PostReviewHandler{
+		uc:             uc,
+		sessionService: sessionService,
+	}
+}
+
+func (h *PostReviewHandler) Handle(_ http.ResponseWriter, request *http.Request, in PostReviewData) (templ.Component, error) {
+	gameID, err := uuid.Parse(request.PathValue(pathValueGameID))
+	if err != nil {
+		return nil, err
+	}
+
+	reviewIn := &contracts.ReviewAnswerIn{
+		GameID:    gameID,
+		ItemID:    in.ItemID,
+		IsCorrect: in.IsCorrect,
+	}
+	if comment := strings.TrimSpace(in.Comment); comment != "" {
+		reviewIn.Comment = &comment
+	}
+
+	if err := h.uc.ReviewAnswer(request.Context(), reviewIn); err != nil {
+		return nil, err
+	}
+
+	items, err := h.sessionService.ReviewList(request.Context(), &session.Spec{GameID: gameID})
+	if err != nil {
+		return nil, err
+	}
+
+	return frontendAdminGame.ReviewList(gameID, items), nil
+}
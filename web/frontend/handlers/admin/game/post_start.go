@@ -1,10 +1,12 @@
 package game
 
 import (
+	"errors"
 	"github.com/a-h/templ"
 	"github.com/google/uuid"
 	"net/http"
 	"quizzly/internal/quizzly/contracts"
+	"quizzly/web/frontend/handlers"
 	"quizzly/web/frontend/services/link"
 )
 
@@ -35,7 +37,14 @@ func NewPostStartHandler(
 
 func (h *PostStartHandler) Handle(_ http.ResponseWriter, request *http.Request, in PostStartData) (templ.Component, error) {
 	err := h.uc.Start(request.Context(), in.GameID)
-	if err != nil {
+	switch {
+	case errors.Is(err, contracts.ErrQuestionFlowCycle):
+		return nil, handlers.BadRequest(errors.New("переходы между вопросами зацикливаются"))
+	case errors.Is(err, contracts.ErrQuestionFlowUnreachable):
+		return nil, handlers.BadRequest(errors.New("на некоторые вопросы нельзя попасть по переходам"))
+	case errors.Is(err, contracts.ErrQuestionFlowNotSupported):
+		return nil, handlers.BadRequest(errors.New("переходы между вопросами недоступны в live-игре, при перемешивании вопросов и при выборе вопросов из пула"))
+	case err != nil:
 		return nil, err
 	}
 
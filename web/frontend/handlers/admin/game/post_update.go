@@ -5,6 +5,9 @@ import (
 	"net/http"
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/helper"
+	"quizzly/web/frontend/handlers"
+	"time"
 
 	frontendComponents "quizzly/web/frontend/templ/components"
 
@@ -14,12 +17,24 @@ import (
 
 type (
 	PostUpdateData struct {
-		ShuffleQuestions *bool   `schema:"shuffle_questions"`
-		ShuffleAnswers   *bool   `schema:"shuffle_answers"`
-		ShowRightAnswers *bool   `schema:"show_right_answers"`
-		InputCustomName  *bool   `schema:"input_custom_name"`
-		IsPrivate        *bool   `schema:"is_private"`
-		Title            *string `schema:"title"`
+		ShuffleQuestions  *bool   `schema:"shuffle_questions"`
+		ShuffleAnswers    *bool   `schema:"shuffle_answers"`
+		ShowRightAnswers  *bool   `schema:"show_right_answers"`
+		InputCustomName   *bool   `schema:"input_custom_name"`
+		IsPrivate         *bool   `schema:"is_private"`
+		Title             *string `schema:"title"`
+		QuestionTimeLimit *string `schema:"question_time_limit"`
+		SpeedBonus        *bool   `schema:"speed_bonus"`
+		NegativeMarking   *bool   `schema:"negative_marking"`
+		PartialCredit     *bool   `schema:"partial_credit"`
+		HideLeaderboard   *bool   `schema:"hide_leaderboard"`
+		QuestionPoolSize  *string `schema:"question_pool_size"`
+		StratifyPool      *bool   `schema:"stratify_pool"`
+		MaxAttempts       *string `schema:"max_attempts"`
+		AttemptPolicy     *string `schema:"attempt_policy"`
+		OpensAt           *string `schema:"opens_at"`
+		ClosesAt          *string `schema:"closes_at"`
+		InactivityTimeout *string `schema:"inactivity_timeout"`
 	}
 
 	PostUpdateHandler struct {
@@ -47,7 +62,11 @@ func (h *PostUpdateHandler) Handle(_ http.ResponseWriter, request *http.Request,
 		return nil, err
 	}
 
-	fill(&in, game)
+	err = fill(&in, game)
+	if err != nil {
+		return nil, err
+	}
+
 	err = h.uc.Update(request.Context(), game)
 	if err != nil {
 		return nil, err
@@ -56,7 +75,7 @@ func (h *PostUpdateHandler) Handle(_ http.ResponseWriter, request *http.Request,
 	return frontendComponents.Composition(), nil
 }
 
-func fill(in *PostUpdateData, specificGame *model.Game) {
+func fill(in *PostUpdateData, specificGame *model.Game) error {
 	if in.Title != nil {
 		specificGame.Title = in.Title
 	}
@@ -75,4 +94,86 @@ func fill(in *PostUpdateData, specificGame *model.Game) {
 	if in.IsPrivate != nil {
 		specificGame.Settings.IsPrivate = *in.IsPrivate
 	}
+	if in.SpeedBonus != nil {
+		specificGame.Settings.SpeedBonus = *in.SpeedBonus
+	}
+	if in.NegativeMarking != nil {
+		specificGame.Settings.NegativeMarking = *in.NegativeMarking
+	}
+	if in.PartialCredit != nil {
+		specificGame.Settings.PartialCredit = *in.PartialCredit
+	}
+	if in.HideLeaderboard != nil {
+		specificGame.Settings.HideLeaderboard = *in.HideLeaderboard
+	}
+	if in.StratifyPool != nil {
+		specificGame.Settings.StratifyPool = *in.StratifyPool
+	}
+	if in.QuestionPoolSize != nil {
+		poolSize, err := helper.ParsePositiveInt(*in.QuestionPoolSize)
+		if err != nil {
+			return err
+		}
+		specificGame.Settings.QuestionPoolSize = poolSize
+	}
+	if in.MaxAttempts != nil {
+		maxAttempts, err := helper.ParsePositiveInt(*in.MaxAttempts)
+		if err != nil {
+			return err
+		}
+		specificGame.Settings.MaxAttempts = maxAttempts
+	}
+	if in.AttemptPolicy != nil {
+		switch policy := model.AttemptPolicy(*in.AttemptPolicy); policy {
+		case model.AttemptPolicyLast, model.AttemptPolicyBest, model.AttemptPolicyFirst:
+			specificGame.Settings.AttemptPolicy = policy
+		default:
+			return errors.New("invalid attempt policy")
+		}
+	}
+	if in.InactivityTimeout != nil {
+		inactivityTimeout, err := helper.ParseMinutes(*in.InactivityTimeout)
+		if err != nil {
+			return err
+		}
+		specificGame.Settings.InactivityTimeout = inactivityTimeout
+	}
+	if in.OpensAt != nil || in.ClosesAt != nil {
+		if err := fillSchedule(in, specificGame); err != nil {
+			return err
+		}
+	}
+	if in.QuestionTimeLimit != nil {
+		timeLimit, err := helper.ParseSeconds(*in.QuestionTimeLimit)
+		if err != nil {
+			return err
+		}
+		specificGame.Settings.QuestionTimeLimit = timeLimit
+	}
+
+	return nil
+}
+
+func fillSchedule(in *PostUpdateData, specificGame *model.Game) error {
+	moscowLocation, _ := time.LoadLocation("Europe/Moscow")
+	if in.OpensAt != nil {
+		opensAt, err := helper.ParseDateTime(*in.OpensAt, moscowLocation)
+		if err != nil {
+			return err
+		}
+		specificGame.OpensAt = opensAt
+	}
+	if in.ClosesAt != nil {
+		closesAt, err := helper.ParseDateTime(*in.ClosesAt, moscowLocation)
+		if err != nil {
+			return err
+		}
+		specificGame.ClosesAt = closesAt
+	}
+
+	if specificGame.OpensAt != nil && specificGame.ClosesAt != nil && !specificGame.ClosesAt.After(*specificGame.OpensAt) {
+		return handlers.BadRequest(errors.New("время завершения игры должно быть позже времени начала"))
+	}
+
+	return nil
 }
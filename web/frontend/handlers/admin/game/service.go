@@ -1,13 +1,18 @@
 package game
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/helper"
+	"quizzly/web/frontend/handlers"
 	"quizzly/web/frontend/services/link"
 	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
 	frontendAdminQuestion "quizzly/web/frontend/templ/admin/question"
 	frontendComponents "quizzly/web/frontend/templ/components"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/google/uuid"
@@ -15,8 +20,25 @@ import (
 
 const (
 	listUrl = "/admin/game/list"
+
+	timeLimitHint     = "время на ответ для вопросов, у которых оно не указано. Если игрок не успеет ответить, вопрос будет засчитан как неправильный"
+	poolSizeHint      = "каждому игроку выпадет указанное число случайных вопросов из всех вопросов игры. Набор вопросов выбирается один раз и сохраняется при повторной игре."
+	maxAttemptsHint   = "сколько раз игрок может пройти игру. Каждая попытка сохраняется, все попытки можно посмотреть во вкладке \"Участники\"."
+	attemptPolicyHint = "какая из завершенных попыток игрока учитывается в его результате и таблице лидеров: последняя, лучшая по баллам или первая."
+	opensAtHint       = "игра запустится автоматически в указанное время (МСК). Игроки, открывшие ссылку раньше, увидят, через сколько начнется игра. Если к этому времени в игре нет вопросов или переходы между вопросами настроены с ошибкой, время запуска сбрасывается."
+	inactivityHint    = "если игрок не отвечает дольше указанного числа минут, его прохождение помечается как брошенное и не считается завершенным. Вернувшись в игру, игрок продолжит с того же места. В live-игре не используется."
+	closesAtHint      = "игра завершится автоматически в указанное время (МСК). Время можно изменить, пока игра не завершена."
+
+	sheetImportDescription = "Таблица CSV или XLSX: первая строка — заголовки колонок, каждая следующая строка — один вопрос. Описание колонок есть в разделе FAQ, пример можно получить кнопкой «Скачать CSV»."
+	giftImportDescription  = "Файл в формате GIFT, как в Moodle: поддерживаются вопросы с выбором ответа, коротким ответом и верно/неверно. Категория вопроса становится его тегом."
 )
 
+var attemptPolicyNames = map[model.AttemptPolicy]string{
+	model.AttemptPolicyLast:  "последняя",
+	model.AttemptPolicyBest:  "лучшая",
+	model.AttemptPolicyFirst: "первая",
+}
+
 var (
 	settings = []setting{
 		{
@@ -46,7 +68,7 @@ var (
 		{
 			slug: "show_right_answers",
 			text: "Показывать правильный ответ в случае неудачи",
-			hint: "при неправильном ответе игрока на экран результатов будет выводиться правильный ответ. Обратите внимание, что кнопка \"играть снова\" всегда активна, так что игрок может запомнить правильные ответы и пройти викторину без ошибок во второй раз.",
+			hint: "при неправильном ответе игрока на экран результатов будет выводиться правильный ответ. Обратите внимание, что без ограничения числа попыток кнопка \"играть снова\" всегда активна, так что игрок может запомнить правильные ответы и пройти викторину без ошибок во второй раз.",
 			value: func(settings *model.GameSettings) bool {
 				return settings.ShowRightAnswers
 			},
@@ -59,6 +81,46 @@ var (
 				return settings.InputCustomName
 			},
 		},
+		{
+			slug: "speed_bonus",
+			text: "Бонус за скорость",
+			hint: "чем быстрее игрок ответит правильно, тем больше баллов получит. За мгновенный ответ баллы удваиваются, к концу времени на ответ бонус уменьшается до нуля.",
+			value: func(settings *model.GameSettings) bool {
+				return settings.SpeedBonus
+			},
+		},
+		{
+			slug: "negative_marking",
+			text: "Штраф за неправильный ответ",
+			hint: "за неправильный ответ баллы вопроса вычитаются из результата игрока. Если игрок не успел ответить, штраф не начисляется.",
+			value: func(settings *model.GameSettings) bool {
+				return settings.NegativeMarking
+			},
+		},
+		{
+			slug: "partial_credit",
+			text: "Частичные баллы",
+			hint: "в вопросах с несколькими правильными ответами баллы начисляются за каждый выбранный правильный вариант, а за каждый неправильный вычитаются.",
+			value: func(settings *model.GameSettings) bool {
+				return settings.PartialCredit
+			},
+		},
+		{
+			slug: "hide_leaderboard",
+			text: "Скрыть таблицу лидеров",
+			hint: "игроки не увидят таблицу лидеров игры. Рейтинг по-прежнему будет доступен вам во вкладке \"Рейтинг\".",
+			value: func(settings *model.GameSettings) bool {
+				return settings.HideLeaderboard
+			},
+		},
+		{
+			slug: "stratify_pool",
+			text: "Выбирать вопросы равномерно по тегам",
+			hint: "если задано число вопросов для игрока, из каждого тега выбирается доля вопросов, пропорциональная их количеству. Например, для 10 из 40 вопросов, среди которых 20 легких и 20 сложных, игрок получит по 5 вопросов каждого тега.",
+			value: func(settings *model.GameSettings) bool {
+				return settings.StratifyPool
+			},
+		},
 	}
 )
 
@@ -75,13 +137,26 @@ func (s *service) getGamePage(request *http.Request, gameID uuid.UUID) (templ.Co
 	handlersGame := convertModelGameToHandlersGame(game)
 
 	titleComponent := frontendAdminGame.Title(game.Title)
-	questionsComponent := frontendAdminQuestion.QuestionListContainer(game.ID, game.Status == model.GameStatusCreated)
+	questionsComponent := frontendComponents.Composition(
+		frontendComponents.CompositionMB4(
+			frontendAdminGame.ActionExportQuestions(game.ID),
+			frontendAdminGame.ActionExportMarkdown(game.ID),
+			frontendAdminGame.ActionDownloadGame(game.ID),
+		),
+		frontendAdminQuestion.QuestionListContainer(game.ID, game.Status == model.GameStatusCreated),
+	)
 
 	if game.Status == model.GameStatusCreated {
 		titleComponent = frontendAdminGame.TitleInput(game.ID, game.Title)
 		questionsComponent = frontendComponents.Composition(
-			frontendComponents.CompositionMB4(frontendAdminGame.ActionAddQuestion()),
-			questionsComponent,
+			frontendComponents.CompositionMB4(
+				frontendAdminGame.ActionAddQuestion(),
+				frontendAdminGame.ActionPasteMarkdown(game.ID),
+				frontendAdminGame.ActionExportQuestions(game.ID),
+				frontendAdminGame.ActionExportMarkdown(game.ID),
+				frontendAdminGame.ActionDownloadGame(game.ID),
+			),
+			frontendAdminQuestion.QuestionListContainer(game.ID, true),
 			frontendComponents.Modal(
 				"addQuestionModal",
 				"Добавить вопрос",
@@ -99,6 +174,52 @@ func (s *service) getGamePage(request *http.Request, gameID uuid.UUID) (templ.Co
 						Name:    "Ввод слова",
 						Content: fillTheGapQuestionForm(game.ID),
 					},
+					frontendComponents.Tab{
+						Name:    "Число",
+						Content: numericQuestionForm(game.ID),
+					},
+					frontendComponents.Tab{
+						Name:    "Пропуски",
+						Content: clozeQuestionForm(game.ID),
+					},
+					frontendComponents.Tab{
+						Name:    "Порядок",
+						Content: orderingQuestionForm(game.ID),
+					},
+					frontendComponents.Tab{
+						Name:    "Пары",
+						Content: matchingQuestionForm(game.ID),
+					},
+					frontendComponents.Tab{
+						Name:    "Область на картинке",
+						Content: hotspotQuestionForm(game.ID),
+					},
+					frontendComponents.Tab{
+						Name:    "Развернутый ответ",
+						Content: openTextQuestionForm(game.ID),
+					},
+					frontendComponents.Tab{
+						Name:    "Опрос",
+						Content: pollQuestionForm(game.ID),
+					},
+					frontendComponents.Tab{
+						Name: "Из файла GIFT",
+						Content: frontendAdminQuestion.ImportForm(
+							game.ID,
+							"gift",
+							".gift,.txt",
+							giftImportDescription,
+						),
+					},
+					frontendComponents.Tab{
+						Name: "Из таблицы",
+						Content: frontendAdminQuestion.ImportForm(
+							game.ID,
+							"sheet",
+							".csv,.xlsx",
+							sheetImportDescription,
+						),
+					},
 				),
 			),
 		)
@@ -125,6 +246,83 @@ func (s *service) getGamePage(request *http.Request, gameID uuid.UUID) (templ.Co
 		))
 	}
 
+	if game.Status == model.GameStatusCreated {
+		settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+			"Время на ответ, секунд",
+			timeLimitHint,
+			frontendAdminGame.SettingTimeLimitInput(game.ID, game.Settings.QuestionTimeLimit),
+		))
+	} else if game.Settings.QuestionTimeLimit != nil {
+		settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+			fmt.Sprintf("Время на ответ: %d сек.", int(game.Settings.QuestionTimeLimit.Seconds())),
+			timeLimitHint,
+		))
+	}
+
+	if game.Status == model.GameStatusCreated {
+		settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+			"Вопросов для игрока",
+			poolSizeHint,
+			frontendAdminGame.SettingPoolSizeInput(game.ID, game.Settings.QuestionPoolSize),
+		))
+	} else if game.Settings.QuestionPoolSize != nil {
+		settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+			fmt.Sprintf("Вопросов для игрока: %d", *game.Settings.QuestionPoolSize),
+			poolSizeHint,
+		))
+	}
+
+	if game.Status == model.GameStatusCreated {
+		settingsComponents = append(settingsComponents,
+			frontendAdminGame.SettingBadge(
+				"Число попыток",
+				maxAttemptsHint,
+				frontendAdminGame.SettingMaxAttemptsInput(game.ID, game.Settings.MaxAttempts),
+			),
+			frontendAdminGame.SettingBadge(
+				"Учитывать попытку",
+				attemptPolicyHint,
+				frontendAdminGame.SettingAttemptPolicySelect(game.ID, game.Settings.AttemptPolicy),
+			),
+		)
+	} else {
+		if game.Settings.MaxAttempts != nil {
+			settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+				fmt.Sprintf("Число попыток: %d", *game.Settings.MaxAttempts),
+				maxAttemptsHint,
+			))
+		}
+		if policyName, ok := attemptPolicyNames[game.Settings.AttemptPolicy]; ok && game.Settings.AttemptPolicy != model.AttemptPolicyLast {
+			settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+				fmt.Sprintf("Учитывается попытка: %s", policyName),
+				attemptPolicyHint,
+			))
+		}
+	}
+
+	if game.Type != model.GameTypeLive && game.Status == model.GameStatusCreated {
+		settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+			"Прохождение брошено через, минут",
+			inactivityHint,
+			frontendAdminGame.SettingInactivityTimeoutInput(game.ID, game.Settings.InactivityTimeout),
+		))
+	} else if game.Type != model.GameTypeLive && game.Settings.InactivityTimeout != nil {
+		settingsComponents = append(settingsComponents, frontendAdminGame.SettingBadge(
+			fmt.Sprintf("Прохождение брошено через: %d мин.", int(game.Settings.InactivityTimeout.Minutes())),
+			inactivityHint,
+		))
+	}
+
+	settingsComponents = append(settingsComponents, getScheduleComponents(game)...)
+
+	liveComponent := frontendComponents.Composition()
+	if game.Type == model.GameTypeLive && game.Status == model.GameStatusStarted {
+		liveComponent, err = s.liveControl(request.Context(), game.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return frontendAdminGame.Page(
 		frontendComponents.BackLink(listUrl),
 		frontendAdminGame.Header(
@@ -132,6 +330,7 @@ func (s *service) getGamePage(request *http.Request, gameID uuid.UUID) (templ.Co
 			titleComponent,
 		),
 		frontendAdminGame.Invite(s.linkService.GameLink(game.ID, request)),
+		liveComponent,
 		frontendComponents.Tabs(
 			uuid.New(),
 			frontendComponents.Tab{
@@ -145,10 +344,52 @@ func (s *service) getGamePage(request *http.Request, gameID uuid.UUID) (templ.Co
 				Name:    "Участники",
 				Content: frontendAdminGame.SessionListContainer(game.ID),
 			},
+			frontendComponents.Tab{
+				Name:    "Монитор",
+				Content: frontendAdminGame.MonitorContainer(game.ID),
+			},
+			frontendComponents.Tab{
+				Name:    "Проверка",
+				Content: frontendAdminGame.ReviewListContainer(game.ID),
+			},
+			frontendComponents.Tab{
+				Name:    "Рейтинг",
+				Content: frontendAdminGame.LeaderboardContainer(game.ID),
+			},
 		),
 	), nil
 }
 
+func (s *service) liveControl(ctx context.Context, gameID uuid.UUID) (templ.Component, error) {
+	state, err := s.uc.GetLiveState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.uc.GetQuestions(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &handlers.GameLiveState{
+		QuestionsCount: len(questions),
+	}
+	if state.CurrentQuestionID != nil {
+		for i, question := range questions {
+			if question.ID != *state.CurrentQuestionID {
+				continue
+			}
+
+			result.QuestionNumber = i + 1
+			result.QuestionText = question.GetDisplayText()
+			result.IsOpened = state.IsQuestionOpened(question.ID)
+			break
+		}
+	}
+
+	return frontendAdminGame.LiveControl(gameID, result), nil
+}
+
 func singleChoiceQuestionForm(gameID uuid.UUID) templ.Component {
 	return frontendAdminQuestion.Form(
 		gameID,
@@ -197,3 +438,133 @@ func fillTheGapQuestionForm(gameID uuid.UUID) templ.Component {
 		),
 	)
 }
+
+func hotspotQuestionForm(gameID uuid.UUID) templ.Component {
+	return frontendAdminQuestion.Form(
+		gameID,
+		model.QuestionTypeHotspot,
+		frontendComponents.Composition(
+			frontendAdminQuestion.QuestionTextInput(),
+		),
+		frontendComponents.Composition(
+			frontendAdminQuestion.AnswerHotspotInput(),
+		),
+	)
+}
+
+func openTextQuestionForm(gameID uuid.UUID) templ.Component {
+	return frontendAdminQuestion.Form(
+		gameID,
+		model.QuestionTypeOpenText,
+		frontendComponents.Composition(
+			frontendAdminQuestion.QuestionImageInput(),
+			frontendAdminQuestion.QuestionTextInput(),
+		),
+		frontendComponents.Composition(
+			frontendAdminQuestion.AnswerOpenTextInput(),
+		),
+	)
+}
+
+func numericQuestionForm(gameID uuid.UUID) templ.Component {
+	return frontendAdminQuestion.Form(
+		gameID,
+		model.QuestionTypeNumeric,
+		frontendComponents.Composition(
+			frontendAdminQuestion.QuestionImageInput(),
+			frontendAdminQuestion.QuestionTextInput(),
+		),
+		frontendComponents.Composition(
+			frontendAdminQuestion.AnswerNumericInput(),
+		),
+	)
+}
+
+func clozeQuestionForm(gameID uuid.UUID) templ.Component {
+	return frontendAdminQuestion.Form(
+		gameID,
+		model.QuestionTypeCloze,
+		frontendComponents.Composition(
+			frontendAdminQuestion.QuestionImageInput(),
+			frontendAdminQuestion.ClozeTextInput(),
+		),
+		frontendComponents.Composition(
+			frontendAdminQuestion.AnswerClozeInput(),
+		),
+	)
+}
+
+func orderingQuestionForm(gameID uuid.UUID) templ.Component {
+	return frontendAdminQuestion.Form(
+		gameID,
+		model.QuestionTypeOrdering,
+		frontendComponents.Composition(
+			frontendAdminQuestion.QuestionImageInput(),
+			frontendAdminQuestion.QuestionTextInput(),
+		),
+		frontendComponents.Composition(
+			frontendAdminQuestion.AnswerOrderingInput(4),
+		),
+	)
+}
+
+func matchingQuestionForm(gameID uuid.UUID) templ.Component {
+	return frontendAdminQuestion.Form(
+		gameID,
+		model.QuestionTypeMatching,
+		frontendComponents.Composition(
+			frontendAdminQuestion.QuestionImageInput(),
+			frontendAdminQuestion.QuestionTextInput(),
+		),
+		frontendComponents.Composition(
+			frontendAdminQuestion.AnswerMatchingInput(4),
+		),
+	)
+}
+
+func pollQuestionForm(gameID uuid.UUID) templ.Component {
+	return frontendAdminQuestion.Form(
+		gameID,
+		model.QuestionTypePoll,
+		frontendComponents.Composition(
+			frontendAdminQuestion.QuestionImageInput(),
+			frontendAdminQuestion.QuestionTextInput(),
+			frontendAdminQuestion.QuestionPollOption(),
+		),
+		frontendComponents.Composition(
+			frontendAdminQuestion.AnswerPollInput(4),
+		),
+	)
+}
+
+// getScheduleComponents время начала можно изменить до запуска игры, время завершения - до ее завершения
+func getScheduleComponents(game *model.Game) []templ.Component {
+	moscowLocation, _ := time.LoadLocation("Europe/Moscow")
+	out := make([]templ.Component, 0, 2)
+
+	if game.Status == model.GameStatusCreated {
+		out = append(out, frontendAdminGame.SettingBadge(
+			"Начало игры",
+			opensAtHint,
+			frontendAdminGame.SettingScheduleInput(game.ID, "opens_at", formatScheduleValue(game.OpensAt, moscowLocation)),
+		))
+	}
+
+	if game.Status != model.GameStatusFinished {
+		out = append(out, frontendAdminGame.SettingBadge(
+			"Завершение игры",
+			closesAtHint,
+			frontendAdminGame.SettingScheduleInput(game.ID, "closes_at", formatScheduleValue(game.ClosesAt, moscowLocation)),
+		))
+	}
+
+	return out
+}
+
+func formatScheduleValue(in *time.Time, location *time.Location) string {
+	if in == nil {
+		return ""
+	}
+
+	return helper.FormatDateTime(*in, location)
+}
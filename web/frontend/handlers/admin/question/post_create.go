@@ -5,18 +5,19 @@ import (
 	"errors"
 	"net/http"
 	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats"
 	"quizzly/internal/quizzly/model"
 	"quizzly/pkg/files"
 	"quizzly/pkg/structs/collections/slices"
+	"quizzly/web/frontend/handlers"
+	stdslices "slices"
 	"strings"
 
 	"github.com/a-h/templ"
 	"github.com/google/uuid"
 )
 
-const (
-	questionImageName = "question_image"
-)
+const questionImageName = "question_image"
 
 var (
 	availableQuestionTypes = []string{
@@ -24,17 +25,39 @@ var (
 		string(model.QuestionTypeMultipleChoice),
 		string(model.QuestionTypeOneOfChoice),
 		string(model.QuestionTypeFillTheGap),
+		string(model.QuestionTypeOrdering),
+		string(model.QuestionTypeMatching),
+		string(model.QuestionTypeNumeric),
+		string(model.QuestionTypeCloze),
+		string(model.QuestionTypePoll),
+		string(model.QuestionTypeOpenText),
+		string(model.QuestionTypeHotspot),
 	}
 )
 
 type (
 	NewPostData struct {
-		QuestionText               string    `schema:"question_text"`
-		QuestionType               string    `schema:"question_type"`
-		QuestionMultipleChoiceType *string   `schema:"question_multiple_choice_type"`
-		QuestionCorrectAnswer      []bool    `schema:"question_correct_answer"`
-		QuestionAnswerOptionText   []string  `schema:"question_answer_option_text"`
-		GameID                     uuid.UUID `schema:"game_id"`
+		QuestionText                 string    `schema:"question_text"`
+		QuestionType                 string    `schema:"question_type"`
+		QuestionMultipleChoiceType   *string   `schema:"question_multiple_choice_type"`
+		QuestionPollType             *string   `schema:"question_poll_type"`
+		QuestionCorrectAnswer        []bool    `schema:"question_correct_answer"`
+		QuestionAnswerOptionText     []string  `schema:"question_answer_option_text"`
+		QuestionAnswerOptionPair     []string  `schema:"question_answer_option_pair"`
+		QuestionHotspotRegion        []string  `schema:"question_hotspot_region"`
+		QuestionNumericValue         string    `schema:"question_numeric_value"`
+		QuestionNumericTolerance     string    `schema:"question_numeric_tolerance"`
+		QuestionNumericToleranceType string    `schema:"question_numeric_tolerance_type"`
+		QuestionNumericMin           string    `schema:"question_numeric_min"`
+		QuestionNumericMax           string    `schema:"question_numeric_max"`
+		QuestionAnswerSynonyms       string    `schema:"question_answer_synonyms"`
+		QuestionTextMatchMode        string    `schema:"question_text_match_mode"`
+		QuestionTextMatchDistance    string    `schema:"question_text_match_distance"`
+		QuestionTextMatchRatio       string    `schema:"question_text_match_ratio"`
+		QuestionTimeLimit            string    `schema:"question_time_limit"`
+		QuestionPoints               string    `schema:"question_points"`
+		QuestionTag                  string    `schema:"question_tag"`
+		GameID                       uuid.UUID `schema:"game_id"`
 	}
 
 	PostCreateHandler struct {
@@ -56,22 +79,28 @@ func NewPostCreateHandler(
 }
 
 func (h *PostCreateHandler) Handle(_ http.ResponseWriter, request *http.Request, in NewPostData) (templ.Component, error) {
-	converted, err := convert(&in)
+	image, err := findQuestionImage(request)
 	if err != nil {
 		return nil, err
 	}
 
-	image, err := findQuestionImage(request)
+	// вопрос проверяется до загрузки изображения, чтобы не оставлять файлы от отклоненных вопросов
+	var imageID *string
+	if image != nil {
+		image.Name = strings.ReplaceAll(strings.TrimSpace(image.Name), " ", "_")
+		imageID = &image.Name
+	}
+
+	converted, err := convert(&in, imageID)
 	if err != nil {
-		return nil, err
+		return nil, handlers.BadRequest(err)
 	}
+
 	if image != nil {
-		image.Name = strings.ReplaceAll(strings.TrimSpace(image.Name), " ", "_")
 		err = h.images.Upload(request.Context(), image)
 		if err != nil {
 			return nil, err
 		}
-		converted.ImageID = &image.Name
 	}
 
 	err = h.uc.CreateQuestion(request.Context(), converted)
@@ -82,35 +111,61 @@ func (h *PostCreateHandler) Handle(_ http.ResponseWriter, request *http.Request,
 	return h.service.list(request.Context(), in.GameID, true, true)
 }
 
-func convert(in *NewPostData) (*model.Question, error) {
+// convert поля формы переводятся в общий вид, проверка и сборка вопроса те же, что и при импорте из таблицы
+func convert(in *NewPostData, imageID *string) (*model.Question, error) {
 	clearIn(in)
 
-	answerOptions := make([]model.AnswerOption, 0, len(in.QuestionAnswerOptionText))
-	for i, text := range in.QuestionAnswerOptionText {
-		answerOptions = append(answerOptions, model.AnswerOption{
-			Answer:    text,
-			IsCorrect: in.QuestionCorrectAnswer[i],
-		})
-	}
-
 	if !slices.Contains(availableQuestionTypes, func(t string) bool {
 		return t == in.QuestionType
 	}) {
-		return nil, errors.New("invalid question type")
+		return nil, errors.New("неизвестный тип вопроса")
 	}
 
 	questionType := model.QuestionType(in.QuestionType)
 	if in.QuestionMultipleChoiceType != nil && *in.QuestionMultipleChoiceType == "one_of" {
 		questionType = model.QuestionTypeOneOfChoice
 	}
+	if questionType == model.QuestionTypePoll && in.QuestionPollType != nil && *in.QuestionPollType == "multiple" {
+		questionType = model.QuestionTypeMultiplePoll
+	}
+
+	options := in.QuestionAnswerOptionText
+	if questionType == model.QuestionTypeFillTheGap {
+		// основной ответ и синонимы (по одному на строку) сохраняются как правильные варианты ответа
+		options = append(stdslices.Clone(options), strings.Split(in.QuestionAnswerSynonyms, "\n")...)
+	}
+
+	question, err := formats.ConvertQuestion(&formats.QuestionIn{
+		Type:      questionType,
+		Text:      in.QuestionText,
+		ImageID:   imageID,
+		Points:    in.QuestionPoints,
+		TimeLimit: in.QuestionTimeLimit,
+		Tag:       in.QuestionTag,
+		Options:   options,
+		Correct:   in.QuestionCorrectAnswer,
+		Pairs:     in.QuestionAnswerOptionPair,
+		Regions:   in.QuestionHotspotRegion,
+		Numeric: formats.NumericIn{
+			Value:         in.QuestionNumericValue,
+			Tolerance:     in.QuestionNumericTolerance,
+			ToleranceType: in.QuestionNumericToleranceType,
+			Min:           in.QuestionNumericMin,
+			Max:           in.QuestionNumericMax,
+		},
+		TextMatch: formats.TextMatchIn{
+			Mode:     in.QuestionTextMatchMode,
+			Distance: in.QuestionTextMatchDistance,
+			Ratio:    in.QuestionTextMatchRatio,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return &model.Question{
-		ID:            uuid.New(),
-		GameID:        in.GameID,
-		Text:          in.QuestionText,
-		Type:          questionType,
-		AnswerOptions: answerOptions,
-	}, nil
+	question.ID = uuid.New()
+	question.GameID = in.GameID
+	return question, nil
 }
 
 func clearIn(in *NewPostData) {
@@ -0,0 +1,102 @@
+package question
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/formats"
+	"quizzly/internal/quizzly/model"
+	"quizzly/web/frontend/handlers"
+	frontendAdminQuestion "quizzly/web/frontend/templ/admin/question"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+)
+
+const questionsFileName = "questions_file"
+
+// answerOptionsErrors ошибки проверки вариантов ответа, которые автор может исправить в файле
+var answerOptionsErrors = map[error]string{
+	contracts.ErrEmptyAnswerOptions:     "нет вариантов ответа",
+	contracts.ErrNoCorrectAnswerOptions: "не указан правильный ответ",
+	contracts.ErrInvalidAnswerOptions:   "варианты ответа не подходят для типа вопроса",
+}
+
+type (
+	PostImportData struct {
+		GameID uuid.UUID `schema:"game_id"`
+	}
+
+	// Parser разбирает файл с вопросами, ошибки в строках файла возвращаются как formats.Report
+	Parser func(in io.Reader) ([]model.Question, error)
+
+	PostImportHandler struct {
+		uc      contracts.GameUsecase
+		format  string
+		parse   Parser
+		service *service
+	}
+)
+
+func NewPostImportHandler(uc contracts.GameUsecase, format string, parse Parser) *PostImportHandler {
+	return &PostImportHandler{
+		uc:      uc,
+		format:  format,
+		parse:   parse,
+		service: &service{uc: uc},
+	}
+}
+
+func (h *PostImportHandler) Handle(writer http.ResponseWriter, request *http.Request, in PostImportData) (templ.Component, error) {
+	file, _, err := request.FormFile(questionsFileName)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, handlers.BadRequest(errors.New("выберите файл с вопросами"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	questions, err := h.parse(file)
+	var report formats.Report
+	if errors.As(err, &report) {
+		lines := make([]string, 0, len(report))
+		for _, item := range report {
+			lines = append(lines, item.Error())
+		}
+		return h.report(writer, lines), nil
+	}
+	if errors.Is(err, formats.ErrTooLarge) {
+		return nil, handlers.BadRequest(errors.New("файл слишком большой"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, handlers.BadRequest(errors.New("в файле нет вопросов"))
+	}
+
+	err = h.uc.ImportQuestions(request.Context(), in.GameID, nil, questions)
+	var questionErr *contracts.QuestionError
+	if errors.As(err, &questionErr) {
+		if message, ok := answerOptionsErrors[questionErr.Err]; ok {
+			return h.report(writer, []string{fmt.Sprintf("вопрос %d: %s", questionErr.Number, message)}), nil
+		}
+	}
+	if errors.Is(err, contracts.ErrGameAlreadyStarted) {
+		return nil, handlers.BadRequest(errors.New("вопросы можно добавить только до начала игры"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.list(request.Context(), in.GameID, true, true)
+}
+
+// report отчет выводится под формой импорта, список вопросов не меняется
+func (h *PostImportHandler) report(writer http.ResponseWriter, lines []string) templ.Component {
+	writer.Header().Set("HX-Retarget", "#"+frontendAdminQuestion.ImportReportID(h.format))
+	return frontendAdminQuestion.ImportReport(lines)
+}
@@ -0,0 +1,62 @@
+package question
+
+import (
+	"errors"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/web/frontend/handlers"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+)
+
+type (
+	PostNextData struct {
+		GameID         uuid.UUID             `schema:"game_id"`
+		QuestionID     uuid.UUID             `schema:"question_id"`
+		AnswerOptionID *model.AnswerOptionID `schema:"answer_option_id"`
+		NextQuestionID *string               `schema:"next_question_id"`
+	}
+
+	PostNextHandler struct {
+		uc      contracts.GameUsecase
+		service *service
+	}
+)
+
+func NewPostNextHandler(uc contracts.GameUsecase) *PostNextHandler {
+	return &PostNextHandler{
+		uc:      uc,
+		service: &service{uc: uc},
+	}
+}
+
+func (h *PostNextHandler) Handle(_ http.ResponseWriter, request *http.Request, in PostNextData) (templ.Component, error) {
+	var nextQuestionID *uuid.UUID
+	if in.NextQuestionID != nil && *in.NextQuestionID != "" {
+		tempNextQuestionID, err := uuid.Parse(*in.NextQuestionID)
+		if err != nil {
+			return nil, err
+		}
+
+		nextQuestionID = &tempNextQuestionID
+	}
+
+	err := h.uc.SetNextQuestion(request.Context(), &contracts.SetNextQuestionIn{
+		GameID:         in.GameID,
+		QuestionID:     in.QuestionID,
+		AnswerOptionID: in.AnswerOptionID,
+		NextQuestionID: nextQuestionID,
+	})
+	switch {
+	case errors.Is(err, contracts.ErrGameAlreadyStarted):
+		return nil, handlers.BadRequest(errors.New("переходы можно изменить только до начала игры"))
+	case errors.Is(err, contracts.ErrQuestionNotFound), errors.Is(err, contracts.ErrInvalidAnswerOptions):
+		return nil, handlers.BadRequest(errors.New("вопрос или вариант ответа не найден в этой игре"))
+	case err != nil:
+		return nil, err
+	}
+
+	return h.service.list(request.Context(), in.GameID, true, true)
+}
@@ -2,6 +2,7 @@ package question
 
 import (
 	"context"
+	"fmt"
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
 	"quizzly/pkg/structs/collections/slices"
@@ -16,6 +17,8 @@ import (
 
 const (
 	listTitle = "Список вопросов"
+
+	flowTargetTitleLength = 40
 )
 
 type service struct {
@@ -45,6 +48,7 @@ func (s *service) list(ctx context.Context, gameID uuid.UUID, inContainer bool,
 
 func convertListToTempl(in []model.Question, editable bool) templ.Component {
 	components := make([]templ.Component, 0, len(in)+1)
+	showFlow := len(in) > 1 && (editable || model.IsBranching(in))
 
 	for i, question := range in {
 		var actions []templ.Component
@@ -54,17 +58,23 @@ func convertListToTempl(in []model.Question, editable bool) templ.Component {
 			}
 		}
 
+		answerOptions := convertAnswerOptionsToTempl(&question)
+		if showFlow {
+			answerOptions = append(answerOptions, convertQuestionFlowToTempl(in, i, editable))
+		}
+
 		components = append(components, frontend_admin_question.QuestionListItem(
 			i+1,
 			handlers.Question{
-				ID:      question.ID,
-				ImageID: question.ImageID,
-				Type:    question.Type,
-				Text:    question.Text,
+				ID:        question.ID,
+				ImageID:   question.ImageID,
+				Type:      question.Type,
+				Text:      question.Text,
+				TimeLimit: question.TimeLimit,
+				Points:    question.Points,
+				Tag:       question.Tag,
 			},
-			slices.SafeMap(question.AnswerOptions, func(ao model.AnswerOption) templ.Component {
-				return frontend_admin_question.QuestionListItemAnswerOption(ao.Answer, ao.IsCorrect)
-			}),
+			answerOptions,
 			actions,
 		))
 
@@ -80,3 +90,86 @@ func convertListToTempl(in []model.Question, editable bool) templ.Component {
 
 	return frontendComponents.Composition(components...)
 }
+
+func convertAnswerOptionsToTempl(question *model.Question) []templ.Component {
+	switch question.Type {
+	case model.QuestionTypeOrdering:
+		// варианты хранятся в случайном порядке, поэтому выводятся отсортированными по месту в правильном ответе
+		orderedAnswers := question.GetOrderedAnswers()
+		result := make([]templ.Component, 0, len(orderedAnswers))
+		for i, ao := range orderedAnswers {
+			result = append(result, frontend_admin_question.QuestionListItemAnswerOption(fmt.Sprintf("%d. %s", i+1, ao.Answer), true))
+		}
+
+		return result
+	case model.QuestionTypeCloze:
+		return slices.SafeMap(question.GetOrderedAnswers(), func(ao model.AnswerOption) templ.Component {
+			return frontend_admin_question.QuestionListItemAnswerOption(fmt.Sprintf("%d. %s", *ao.Position, ao.Answer), true)
+		})
+	case model.QuestionTypeOpenText:
+		return slices.SafeMap(slices.Filter(question.AnswerOptions, func(ao model.AnswerOption) bool {
+			return ao.Answer != ""
+		}), func(ao model.AnswerOption) templ.Component {
+			return frontend_admin_question.QuestionListItemNeutralOption(fmt.Sprintf("Образец ответа: %s", ao.Answer))
+		})
+	case model.QuestionTypePoll, model.QuestionTypeMultiplePoll:
+		return slices.SafeMap(question.AnswerOptions, func(ao model.AnswerOption) templ.Component {
+			return frontend_admin_question.QuestionListItemNeutralOption(ao.Answer)
+		})
+	case model.QuestionTypeMatching:
+		return slices.SafeMap(question.AnswerOptions, func(ao model.AnswerOption) templ.Component {
+			return frontend_admin_question.QuestionListItemAnswerOption(fmt.Sprintf("%s — %s", ao.Answer, ao.GetPair()), true)
+		})
+	default:
+		return slices.SafeMap(question.AnswerOptions, func(ao model.AnswerOption) templ.Component {
+			return frontend_admin_question.QuestionListItemAnswerOption(ao.Answer, ao.IsCorrect)
+		})
+	}
+}
+
+// convertQuestionFlowToTempl переход по варианту ответа настраивается только для вопросов с выбором одного варианта
+func convertQuestionFlowToTempl(questions []model.Question, index int, editable bool) templ.Component {
+	question := questions[index]
+
+	targets := make([]handlers.QuestionFlowTarget, 0, len(questions)-1)
+	for i, target := range questions {
+		if i == index {
+			continue
+		}
+
+		title := []rune(target.GetDisplayText())
+		if len(title) > flowTargetTitleLength {
+			title = append(title[:flowTargetTitleLength], '…')
+		}
+		targets = append(targets, handlers.QuestionFlowTarget{
+			ID:    target.ID,
+			Title: fmt.Sprintf("#%d %s", i+1, string(title)),
+		})
+	}
+
+	item := func(label string, answerOptionID *model.AnswerOptionID, selected *uuid.UUID) templ.Component {
+		if editable {
+			return frontend_admin_question.QuestionFlowSelect(label, question.ID, answerOptionID, selected, targets)
+		}
+
+		target := "Следующий по порядку"
+		for _, item := range targets {
+			if selected != nil && item.ID == *selected {
+				target = item.Title
+			}
+		}
+		return frontend_admin_question.QuestionFlowLabel(label, target)
+	}
+
+	items := make([]templ.Component, 0, len(question.AnswerOptions)+1)
+	questionLabel := "После ответа"
+	if question.Type.HasAnswerBranching() {
+		for _, ao := range question.AnswerOptions {
+			items = append(items, item(fmt.Sprintf("Если выбран ответ \"%s\"", ao.Answer), &ao.ID, ao.NextQuestionID))
+		}
+		questionLabel = "В остальных случаях"
+	}
+	items = append(items, item(questionLabel, nil, question.NextQuestionID))
+
+	return frontend_admin_question.QuestionFlow(items)
+}
@@ -30,9 +30,22 @@ func (err *BadRequestErr) Error() string {
 	return err.originalErr.Error()
 }
 
+// LimitBody запрос больше limit байт не читается целиком, Templ ответит на него ошибкой 400
+func LimitBody(limit int64, next func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next(w, r)
+	}
+}
+
 func Templ[T any](handler Handler[T], log logger.Logger) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		inStruct, err := parseIn[T](r)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "файл слишком большой", http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			log.Error("parse request error", err)
@@ -64,7 +77,7 @@ func parseIn[T any](r *http.Request) (T, error) {
 		if strings.Contains(contentType, "multipart/form-data") {
 			err := r.ParseMultipartForm(4 << 20)
 			if err != nil {
-				return inStruct, fmt.Errorf("parse multipart form error: %v", err)
+				return inStruct, fmt.Errorf("parse multipart form error: %w", err)
 			}
 		} else {
 			err := r.ParseForm()
@@ -16,6 +16,7 @@ type (
 	Game struct {
 		ID        uuid.UUID
 		Status    model.GameStatus
+		Type      model.GameType
 		Title     string
 		CreatedAt time.Time
 		Settings  GameSettings
@@ -29,6 +30,13 @@ type (
 		IsPrivate        bool
 	}
 
+	GameLiveState struct {
+		QuestionNumber int
+		QuestionsCount int
+		QuestionText   string
+		IsOpened       bool
+	}
+
 	GameStatistics struct {
 		QuestionsCount    int
 		ParticipantsCount int
@@ -48,12 +56,20 @@ type (
 		QuestionText  string
 		QuestionImage *string
 		Answers       []SessionItemAnswer
+		// IsTimedOut игрок не успел ответить за отведенное время
+		IsTimedOut bool
+		Score      *float64
 	}
 
 	SessionItemAnswer struct {
 		AnswerText       string
 		IsCorrect        bool
 		IsPlayerAnswered bool
+		// IsNeutral ответ без правильного варианта, например в опросе
+		IsNeutral bool
+		// IsPendingReview ответ еще не проверен автором игры
+		IsPendingReview bool
+		ReviewComment   *string
 	}
 
 	Participant struct {
@@ -68,6 +84,10 @@ type (
 		Text          string
 		Type          model.QuestionType
 		AnswerOptions []AnswerOption
+		// TimeLimit собственное время на ответ, без учета настроек игры
+		TimeLimit *time.Duration
+		Points    int
+		Tag       *string
 	}
 
 	AnswerOption struct {
@@ -75,6 +95,23 @@ type (
 		Text string
 	}
 
+	// QuestionFlowTarget вопрос, на который можно настроить переход
+	QuestionFlowTarget struct {
+		ID    uuid.UUID
+		Title string
+	}
+
+	ClozePart struct {
+		Text  string
+		IsGap bool
+	}
+
+	PollVote struct {
+		Text    string
+		Count   int64
+		Percent int
+	}
+
 	AnswerResult struct {
 		IsCorrect bool
 	}
@@ -87,11 +124,57 @@ type (
 	SessionStatistics struct {
 		QuestionsCount      int
 		CorrectAnswersCount int
+		PendingReviewCount  int
+		Score               float64
+		MaxScore            float64
+		// Attempt попытка, по которой посчитан результат
+		Attempt       int
+		AttemptsCount int
+	}
+
+	ReviewItem struct {
+		ItemID          int64
+		PlayerName      string
+		QuestionText    string
+		ReferenceAnswer string
+		Answer          string
+		AnsweredAt      time.Time
+	}
+
+	SessionMonitorItem struct {
+		PlayerID              uuid.UUID
+		PlayerName            string
+		CurrentQuestion       int
+		QuestionsCount        int
+		CorrectAnswersCount   int
+		IncorrectAnswersCount int
+		LastActivityAt        time.Time
+		SessionStatus         model.SessionStatus
+	}
+
+	LeaderboardItem struct {
+		Position            int
+		PlayerName          string
+		Score               float64
+		CorrectAnswersCount int
+		// Duration время прохождения в формате ч:мм:сс
+		Duration        string
+		IsCurrentPlayer bool
+	}
+
+	SessionListStatistics struct {
+		TotalCount     int
+		FinishedCount  int
+		AbandonedCount int
+		// AllAttempts в списке все попытки игроков, а не только последние
+		AllAttempts bool
 	}
 
 	SessionItemStatistics struct {
 		PlayerName                    string
+		Attempt                       int
 		CompletionRate                int
+		Score                         float64
 		SessionStatus                 model.SessionStatus
 		SessionStartedAt              time.Time
 		SessionLastQuestionAnsweredAt *time.Time
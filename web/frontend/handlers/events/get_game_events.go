@@ -0,0 +1,67 @@
+package events
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"net/http"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/broker"
+	"quizzly/pkg/logger"
+)
+
+const (
+	pathValueGameID = "game_id"
+)
+
+type (
+	gameEvent struct {
+		GameID     uuid.UUID  `json:"game_id"`
+		PlayerID   *uuid.UUID `json:"player_id,omitempty"`
+		QuestionID *uuid.UUID `json:"question_id,omitempty"`
+		IsCorrect  *bool      `json:"is_correct,omitempty"`
+	}
+
+	GetGameEventsHandler struct {
+		events broker.Broker[model.GameEvent]
+		log    logger.Logger
+	}
+)
+
+func NewGetGameEventsHandler(events broker.Broker[model.GameEvent], log logger.Logger) *GetGameEventsHandler {
+	return &GetGameEventsHandler{
+		events: events,
+		log:    log,
+	}
+}
+
+// Handle отдает события игры в формате Server-Sent Events.
+// Название события совпадает с model.GameEventType, поэтому на странице его можно слушать через hx-trigger="sse:<type>"
+func (h *GetGameEventsHandler) Handle() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		gameID, err := uuid.Parse(r.PathValue(pathValueGameID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		messages, unsubscribe := h.events.Subscribe(gameID.String())
+		defer unsubscribe()
+
+		stream(w, r, h.log, messages, func(event model.GameEvent) (*message, error) {
+			data, err := json.Marshal(gameEvent{
+				GameID:     event.GameID,
+				PlayerID:   event.PlayerID,
+				QuestionID: event.QuestionID,
+				IsCorrect:  event.IsCorrect,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &message{
+				name: string(event.Type),
+				data: string(data),
+			}, nil
+		})
+	}
+}
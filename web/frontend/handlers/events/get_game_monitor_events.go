@@ -0,0 +1,87 @@
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/broker"
+	"quizzly/pkg/logger"
+	"quizzly/web/frontend/services/session"
+	frontendAdminGame "quizzly/web/frontend/templ/admin/game"
+)
+
+const (
+	monitorNewEvent  = "monitor_new"
+	monitorItemEvent = "monitor_%s"
+)
+
+type (
+	GetGameMonitorEventsHandler struct {
+		events         broker.Broker[model.GameEvent]
+		sessionService session.Service
+		log            logger.Logger
+	}
+)
+
+func NewGetGameMonitorEventsHandler(
+	events broker.Broker[model.GameEvent],
+	sessionService session.Service,
+	log logger.Logger,
+) *GetGameMonitorEventsHandler {
+	return &GetGameMonitorEventsHandler{
+		events:         events,
+		sessionService: sessionService,
+		log:            log,
+	}
+}
+
+// Handle отдает готовые строки монитора участников: новый участник добавляется в конец таблицы,
+// строка существующего заменяется целиком
+func (h *GetGameMonitorEventsHandler) Handle() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		gameID, err := uuid.Parse(r.PathValue(pathValueGameID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		messages, unsubscribe := h.events.Subscribe(gameID.String())
+		defer unsubscribe()
+
+		stream(w, r, h.log, messages, func(event model.GameEvent) (*message, error) {
+			// после завершения игры монитор перезапрашивается целиком
+			if event.Type == model.GameEventFinished {
+				return &message{name: string(event.Type)}, nil
+			}
+			if event.PlayerID == nil {
+				return nil, nil
+			}
+
+			name := fmt.Sprintf(monitorItemEvent, event.PlayerID.String())
+			switch event.Type {
+			case model.GameEventPlayerJoined:
+				name = monitorNewEvent
+			case model.GameEventAnswerAccepted, model.GameEventAnswerReviewed, model.GameEventSessionFinished, model.GameEventSessionAbandoned:
+			default:
+				return nil, nil
+			}
+
+			item, err := h.sessionService.MonitorItem(r.Context(), &session.Spec{GameID: gameID}, *event.PlayerID)
+			if err != nil {
+				return nil, err
+			}
+
+			buffer := bytes.Buffer{}
+			if err := frontendAdminGame.MonitorItem(*item, true).Render(r.Context(), &buffer); err != nil {
+				return nil, err
+			}
+
+			return &message{
+				name: name,
+				data: buffer.String(),
+			}, nil
+		})
+	}
+}
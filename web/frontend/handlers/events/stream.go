@@ -0,0 +1,91 @@
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"quizzly/pkg/logger"
+	"strings"
+	"time"
+)
+
+const (
+	keepAliveInterval = 15 * time.Second
+)
+
+type (
+	message struct {
+		name string
+		data string
+	}
+)
+
+// stream держит SSE соединение открытым и пересылает клиенту сообщения из канала, пока клиент не отключится.
+// convert может вернуть nil, тогда сообщение пропускается
+func stream[T any](w http.ResponseWriter, r *http.Request, log logger.Logger, messages <-chan T, convert func(T) (*message, error)) {
+	controller := http.NewResponseController(w)
+	// соединение живет дольше, чем WriteTimeout сервера
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		log.Error("reset write deadline error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := write(w, controller, ": connected\n\n"); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if err := write(w, controller, ": ping\n\n"); err != nil {
+				return
+			}
+		case item, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			converted, err := convert(item)
+			if err != nil {
+				log.Error("convert event error", err)
+				continue
+			}
+			if converted == nil {
+				continue
+			}
+
+			if err := write(w, controller, converted.String()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func write(w http.ResponseWriter, controller *http.ResponseController, message string) error {
+	if _, err := fmt.Fprint(w, message); err != nil {
+		return err
+	}
+
+	return controller.Flush()
+}
+
+func (m *message) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("event: %s\n", m.name))
+	// многострочные данные передаются несколькими полями data
+	for _, line := range strings.Split(m.data, "\n") {
+		builder.WriteString(fmt.Sprintf("data: %s\n", line))
+	}
+	builder.WriteString("\n")
+
+	return builder.String()
+}
@@ -0,0 +1,182 @@
+package game
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"quizzly/internal/quizzly/model"
+	"quizzly/pkg/structs/collections/slices"
+	"quizzly/web/frontend/handlers"
+	"strconv"
+)
+
+func convertSessionItems(questions []model.Question, items []model.SessionItem) []handlers.SessionItem {
+	questionsMap := make(map[uuid.UUID]*model.Question, len(questions))
+	for i := range questions {
+		questionsMap[questions[i].ID] = &questions[i]
+	}
+
+	result := make([]handlers.SessionItem, 0, len(items))
+	for _, item := range items {
+		question, ok := questionsMap[item.QuestionID]
+		if !ok || item.AnsweredAt == nil {
+			continue
+		}
+
+		result = append(result, handlers.SessionItem{
+			QuestionText:  question.GetDisplayText(),
+			QuestionImage: question.ImageID,
+			Answers:       convertSessionItemAnswers(question, &item),
+			IsTimedOut:    item.IsTimedOut(),
+			Score:         item.Score,
+		})
+	}
+
+	return result
+}
+
+func convertSessionItemAnswers(question *model.Question, item *model.SessionItem) []handlers.SessionItemAnswer {
+	answerOptions := make(map[model.AnswerOptionID]model.AnswerOption, len(question.AnswerOptions))
+	for _, answerOption := range question.AnswerOptions {
+		answerOptions[answerOption.ID] = answerOption
+	}
+
+	result := make([]handlers.SessionItemAnswer, 0, len(question.AnswerOptions))
+	switch question.Type {
+	case model.QuestionTypeFillTheGap, model.QuestionTypeNumeric:
+		isCorrect := item.IsCorrect != nil && *item.IsCorrect
+		for _, answer := range item.Answers {
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       answer,
+				IsCorrect:        isCorrect,
+				IsPlayerAnswered: true,
+			})
+		}
+		if !isCorrect {
+			for _, answerOption := range question.GetCorrectAnswers() {
+				result = append(result, handlers.SessionItemAnswer{
+					AnswerText: answerOption.Answer,
+					IsCorrect:  true,
+				})
+			}
+		}
+	case model.QuestionTypeHotspot:
+		isCorrect := item.IsCorrect != nil && *item.IsCorrect
+		for _, answer := range item.Answers {
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       findHotspotRegionName(question, answer),
+				IsCorrect:        isCorrect,
+				IsPlayerAnswered: true,
+			})
+		}
+		if !isCorrect {
+			for _, answerOption := range question.GetCorrectAnswers() {
+				result = append(result, handlers.SessionItemAnswer{
+					AnswerText: answerOption.Answer,
+					IsCorrect:  true,
+				})
+			}
+		}
+	case model.QuestionTypeOpenText:
+		for _, answer := range item.Answers {
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       answer,
+				IsCorrect:        item.IsCorrect != nil && *item.IsCorrect,
+				IsPlayerAnswered: true,
+				IsPendingReview:  item.IsPendingReview(),
+				ReviewComment:    item.ReviewComment,
+			})
+		}
+	case model.QuestionTypeCloze:
+		isCorrect := item.IsCorrect != nil && *item.IsCorrect
+		for i, answer := range item.Answers {
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       fmt.Sprintf("%d. %s", i+1, answer),
+				IsCorrect:        isCorrect,
+				IsPlayerAnswered: true,
+			})
+		}
+		if !isCorrect {
+			for i, answerOption := range question.GetCorrectAnswers() {
+				result = append(result, handlers.SessionItemAnswer{
+					AnswerText: fmt.Sprintf("%d. %s", i+1, answerOption.Answer),
+					IsCorrect:  true,
+				})
+			}
+		}
+	case model.QuestionTypeOrdering:
+		orderedAnswers := question.GetOrderedAnswers()
+		for i, answer := range item.Answers {
+			id, err := strconv.ParseInt(answer, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       fmt.Sprintf("%d. %s", i+1, answerOptions[model.AnswerOptionID(id)].Answer),
+				IsCorrect:        i < len(orderedAnswers) && orderedAnswers[i].ID == model.AnswerOptionID(id),
+				IsPlayerAnswered: true,
+			})
+		}
+	case model.QuestionTypeMatching:
+		for _, answer := range item.Answers {
+			leftID, pair, err := model.ParseMatchingAnswer(answer)
+			if err != nil {
+				continue
+			}
+
+			left := answerOptions[leftID]
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       fmt.Sprintf("%s — %s", left.Answer, pair),
+				IsCorrect:        left.GetPair() == pair,
+				IsPlayerAnswered: true,
+			})
+			if left.GetPair() != pair {
+				result = append(result, handlers.SessionItemAnswer{
+					AnswerText: fmt.Sprintf("%s — %s", left.Answer, left.GetPair()),
+					IsCorrect:  true,
+				})
+			}
+		}
+	case model.QuestionTypePoll, model.QuestionTypeMultiplePoll:
+		for _, answerOption := range question.AnswerOptions {
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       answerOption.Answer,
+				IsPlayerAnswered: slices.ContainsValue(item.Answers, strconv.FormatInt(int64(answerOption.ID), 10)),
+				IsNeutral:        true,
+			})
+		}
+	default:
+		for _, answerOption := range question.AnswerOptions {
+			result = append(result, handlers.SessionItemAnswer{
+				AnswerText:       answerOption.Answer,
+				IsCorrect:        answerOption.IsCorrect,
+				IsPlayerAnswered: slices.ContainsValue(item.Answers, strconv.FormatInt(int64(answerOption.ID), 10)),
+			})
+		}
+	}
+
+	return result
+}
+
+// findHotspotRegionName подпись области, в которую попала точка игрока
+func findHotspotRegionName(question *model.Question, answer string) string {
+	const outside = "Точка вне отмеченных областей"
+
+	point, err := model.ParseHotspotPoint(answer)
+	if err != nil {
+		return outside
+	}
+
+	for _, answerOption := range question.AnswerOptions {
+		region, err := answerOption.GetRegion()
+		if err != nil || region == nil {
+			continue
+		}
+
+		if region.Contains(point) {
+			return answerOption.Answer
+		}
+	}
+
+	return outside
+}
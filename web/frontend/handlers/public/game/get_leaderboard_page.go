@@ -0,0 +1,95 @@
+package game
+
+import (
+	"errors"
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"net/http"
+	"quizzly/internal/quizzly/contracts"
+	"quizzly/internal/quizzly/model"
+	"quizzly/web/frontend/services/page"
+	"quizzly/web/frontend/services/player"
+	"quizzly/web/frontend/services/session"
+	frontendComponents "quizzly/web/frontend/templ/components"
+	frontendPublicGame "quizzly/web/frontend/templ/public/game"
+)
+
+const getLeaderboardTitle = "Таблица лидеров"
+
+type (
+	GetLeaderboardPageData struct {
+		Order *string `schema:"order"`
+	}
+
+	GetLeaderboardPageHandler struct {
+		gameUC contracts.GameUsecase
+
+		sessionService session.Service
+		playerService  player.Service
+	}
+)
+
+func NewGetLeaderboardPageHandler(
+	gameUC contracts.GameUsecase,
+	sessionService session.Service,
+	playerService player.Service,
+) *GetLeaderboardPageHandler {
+	return &GetLeaderboardPageHandler{
+		gameUC:         gameUC,
+		sessionService: sessionService,
+		playerService:  playerService,
+	}
+}
+
+func (h *GetLeaderboardPageHandler) Handle(writer http.ResponseWriter, request *http.Request, in GetLeaderboardPageData) (templ.Component, error) {
+	gameID, err := uuid.Parse(request.PathValue(pathValueGameID))
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := h.gameUC.Get(request.Context(), gameID)
+	if errors.Is(err, contracts.ErrGameNotFound) {
+		return frontendComponents.Redirect("/?warn=Игра не найдена"), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Settings.HideLeaderboard {
+		return page.PublicIndexPage(
+			request.Context(),
+			getLeaderboardTitle,
+			frontendComponents.StatusMessage("Автор игры скрыл таблицу лидеров"),
+		), nil
+	}
+
+	currentPlayer, err := h.playerService.GetPlayer(writer, request, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	order := model.LeaderboardOrderScore
+	if in.Order != nil && *in.Order == string(model.LeaderboardOrderCorrectAnswers) {
+		order = model.LeaderboardOrderCorrectAnswers
+	}
+
+	items, err := h.sessionService.Leaderboard(request.Context(), &session.LeaderboardSpec{
+		GameID:          game.ID,
+		Order:           order,
+		CurrentPlayerID: &currentPlayer.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.PublicIndexPage(
+		request.Context(),
+		getLeaderboardTitle,
+		frontendPublicGame.Page(
+			frontendPublicGame.LeaderboardHeader(game.Title),
+			frontendPublicGame.LeaderboardOrder(game.ID, order),
+			frontendPublicGame.Leaderboard(items),
+			frontendComponents.GridLine(frontendPublicGame.ActionPlayGame(game.ID)),
+		),
+	), nil
+}
@@ -33,6 +33,7 @@ func NewGetPlayPageHandler(
 	sessionUC contracts.SessionUsecase,
 	playerService player.Service,
 	linkService link.Service,
+	realtime bool,
 ) *GetPlayPageHandler {
 	return &GetPlayPageHandler{
 		gameUC:        gameUC,
@@ -40,6 +41,7 @@ func NewGetPlayPageHandler(
 		service: &service{
 			sessionUC:   sessionUC,
 			linkService: linkService,
+			realtime:    realtime,
 		},
 	}
 }
@@ -81,9 +83,14 @@ func (h *GetPlayPageHandler) Handle(writer http.ResponseWriter, request *http.Re
 		return nil, err
 	}
 
+	body := frontendPublicGame.Page(question)
+	if h.service.realtime {
+		body = frontendPublicGame.Events(game.ID, body)
+	}
+
 	return page.PublicIndexPage(
 		request.Context(),
 		gameTitle(game),
-		frontendPublicGame.Page(question),
+		body,
 	), nil
 }
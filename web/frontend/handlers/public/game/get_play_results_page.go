@@ -1,6 +1,7 @@
 package game
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/a-h/templ"
@@ -108,10 +109,20 @@ func (h *GetPlayResultsPageHandler) Handle(writer http.ResponseWriter, request *
 		playerName = players[0].Name
 	}
 
+	resultAnswers := frontendComponents.Composition()
+	if currentPlayer.ID == *playerID && game.Settings.ShowRightAnswers {
+		resultAnswers, err = h.getResultAnswers(request.Context(), game.ID, *playerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	resultPlayer := frontendPublicGame.ResultPlayer(playerName)
-	actions := make([]templ.Component, 0, 2)
+	actions := make([]templ.Component, 0, 3)
 	if currentPlayer.ID == *playerID {
-		actions = append(actions, frontendPublicGame.ActionRestartGame(game.ID))
+		if game.Type != model.GameTypeLive && (stats.AttemptsLeft == nil || *stats.AttemptsLeft > 0) {
+			actions = append(actions, frontendPublicGame.ActionRestartGame(game.ID))
+		}
 		actions = append(actions, frontendPublicGame.ActionShareResult(h.getShareTitle(game.Title, stats.CorrectAnswersCount, stats.QuestionsCount)))
 
 		resultPlayer = frontendPublicGame.ResultPlayer(
@@ -121,6 +132,9 @@ func (h *GetPlayResultsPageHandler) Handle(writer http.ResponseWriter, request *
 	} else {
 		actions = append(actions, frontendPublicGame.ActionPlayGame(game.ID))
 	}
+	if !game.Settings.HideLeaderboard {
+		actions = append(actions, frontendPublicGame.ActionLeaderboard(game.ID))
+	}
 
 	publicGames, err := h.gameUC.GetPublic(request.Context())
 	if err != nil {
@@ -142,8 +156,14 @@ func (h *GetPlayResultsPageHandler) Handle(writer http.ResponseWriter, request *
 				&handlers.SessionStatistics{
 					QuestionsCount:      int(stats.QuestionsCount),
 					CorrectAnswersCount: int(stats.CorrectAnswersCount),
+					PendingReviewCount:  int(stats.PendingReviewCount),
+					Score:               stats.Score,
+					MaxScore:            stats.MaxScore,
+					Attempt:             stats.Attempt,
+					AttemptsCount:       stats.AttemptsCount,
 				},
 			),
+			resultAnswers,
 			frontendComponents.GridLine(actions...),
 			frontendPublicGame.ResultAdditional(
 				frontendComponents.DividerVerticalLight("Или",
@@ -167,6 +187,21 @@ func (h *GetPlayResultsPageHandler) Handle(writer http.ResponseWriter, request *
 		}), nil
 }
 
+// getResultAnswers ответы игрока показываются только ему самому и только если в игре разрешено показывать правильные ответы
+func (h *GetPlayResultsPageHandler) getResultAnswers(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (templ.Component, error) {
+	questions, err := h.gameUC.GetQuestions(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	extendedSession, err := h.sessionUC.GetExtendedSession(ctx, gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return frontendPublicGame.ResultAnswers(convertSessionItems(questions, extendedSession.Items)), nil
+}
+
 func (h *GetPlayResultsPageHandler) getTitle(gameTitle *string) string {
 	if gameTitle == nil {
 		return getPlayResultsTitle
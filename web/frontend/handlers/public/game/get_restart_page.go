@@ -72,6 +72,9 @@ func (h *GetRestartPageHandler) Handle(writer http.ResponseWriter, request *http
 	}
 
 	err = h.sessionUC.Restart(request.Context(), game.ID, currentPlayer.ID)
+	if errors.Is(err, contracts.ErrAttemptsExhausted) {
+		return frontendComponents.Redirect("/?warn=Все попытки пройти игру уже использованы"), nil
+	}
 	if err != nil {
 		return nil, err
 	}
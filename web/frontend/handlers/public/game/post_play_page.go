@@ -1,13 +1,16 @@
 package game
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"quizzly/internal/quizzly/contracts"
 	"quizzly/internal/quizzly/model"
 	"quizzly/pkg/structs/collections/slices"
+	"quizzly/web/frontend/handlers"
 	"quizzly/web/frontend/services/link"
 	"quizzly/web/frontend/services/player"
-	frontendComponents "quizzly/web/frontend/templ/components"
 	frontendPublicGame "quizzly/web/frontend/templ/public/game"
 
 	"github.com/a-h/templ"
@@ -35,6 +38,7 @@ func NewPostPlayPageHandler(
 	sessionUC contracts.SessionUsecase,
 	playerService player.Service,
 	linkService link.Service,
+	realtime bool,
 ) *PostPlayPageHandler {
 	return &PostPlayPageHandler{
 		gameUC:        gameUC,
@@ -43,6 +47,7 @@ func NewPostPlayPageHandler(
 		service: &service{
 			sessionUC:   sessionUC,
 			linkService: linkService,
+			realtime:    realtime,
 		},
 	}
 }
@@ -62,14 +67,17 @@ func (h *PostPlayPageHandler) Handle(writer http.ResponseWriter, request *http.R
 	if err != nil {
 		return nil, err
 	}
-	if game.Status == model.GameStatusFinished {
-		return frontendComponents.Redirect("/?warn=Игра уже завершена"), nil
-	}
-
 	currentPlayer, err := h.playerService.GetPlayer(writer, request, game.ID)
 	if err != nil {
 		return nil, err
 	}
+	if game.Status == model.GameStatusFinished {
+		if game.Type == model.GameTypeLive {
+			return frontendPublicGame.QuestionFormRedirect(h.service.linkService.GameResultsLink(game.ID, currentPlayer.ID)), nil
+		}
+
+		return frontendPublicGame.QuestionFormRedirect("/?warn=Игра уже завершена"), nil
+	}
 
 	answerResult, err := h.sessionUC.AcceptAnswers(request.Context(), &contracts.AcceptAnswersIn{
 		GameID:     game.ID,
@@ -77,6 +85,16 @@ func (h *PostPlayPageHandler) Handle(writer http.ResponseWriter, request *http.R
 		QuestionID: in.QuestionID,
 		Answers:    in.Answers,
 	})
+	if errors.Is(err, contracts.ErrLiveQuestionClosed) {
+		// Ведущий закрыл вопрос раньше, чем игрок успел ответить
+		return h.service.GetCurrentState(
+			request.Context(),
+			&getCurrentStateIn{
+				game:   game,
+				player: currentPlayer,
+			},
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -94,12 +112,48 @@ func (h *PostPlayPageHandler) Handle(writer http.ResponseWriter, request *http.R
 }
 
 func buildAnswerComponent(answerResult *contracts.AcceptAnswersOut, displayRightAnswers bool) templ.Component {
+	if answerResult.IsTimedOut {
+		return frontendPublicGame.AnswerTimeout()
+	}
+
+	if answerResult.IsPendingReview {
+		return frontendPublicGame.AnswerPendingReview()
+	}
+
+	if answerResult.Votes != nil {
+		return frontendPublicGame.PollResult(convertPollVotes(answerResult.Votes))
+	}
+
 	var rightAnswers []string
 	if displayRightAnswers {
 		rightAnswers = slices.SafeMap(answerResult.RightAnswers, func(in model.AnswerOption) string {
+			if in.Pair != nil {
+				return fmt.Sprintf("%s — %s", in.Answer, *in.Pair)
+			}
+
 			return in.Answer
 		})
 	}
 
 	return frontendPublicGame.Answer(answerResult.IsCorrect, rightAnswers...)
 }
+
+func convertPollVotes(votes []model.AnswerVotes) []handlers.PollVote {
+	var total int64
+	for _, vote := range votes {
+		total += vote.Count
+	}
+
+	return slices.SafeMap(votes, func(in model.AnswerVotes) handlers.PollVote {
+		percent := 0
+		if total > 0 {
+			percent = int(math.Round(float64(in.Count) * 100 / float64(total)))
+		}
+
+		return handlers.PollVote{
+			Text:    in.AnswerOption.Answer,
+			Count:   in.Count,
+			Percent: percent,
+		}
+	})
+}
@@ -11,6 +11,12 @@ import (
 	"quizzly/web/frontend/services/link"
 	frontendComponents "quizzly/web/frontend/templ/components"
 	frontendPublicGame "quizzly/web/frontend/templ/public/game"
+	"time"
+)
+
+const (
+	scheduleReloadDelay       = 10 * time.Second
+	scheduleMaxReloadInterval = time.Hour
 )
 
 type (
@@ -24,12 +30,21 @@ type (
 		sessionUC contracts.SessionUsecase
 
 		linkService link.Service
+		// realtime страница получает события игры через SSE, иначе опрашивает сервер
+		realtime bool
 	}
 )
 
 func (s *service) GetCurrentState(ctx context.Context, in *getCurrentStateIn, components ...templ.Component) (templ.Component, error) {
 	if in.game.Status == model.GameStatusFinished {
-		return frontendComponents.Redirect("/?warn=Игра уже завершена"), nil
+		if in.game.Type == model.GameTypeLive {
+			return frontendPublicGame.QuestionFormRedirect(s.linkService.GameResultsLink(in.game.ID, in.player.ID)), nil
+		}
+
+		return frontendPublicGame.QuestionFormRedirect("/?warn=Игра уже завершена"), nil
+	}
+	if in.game.Status == model.GameStatusCreated && in.game.OpensAt != nil {
+		return frontendPublicGame.Page(getSchedulePage(in.game, time.Now())), nil
 	}
 	if in.game.Status == model.GameStatusCreated {
 		return frontendComponents.Redirect("/?warn=Игра еще не началась. Подождите немного или попросите автора запустить игру"), nil
@@ -61,9 +76,7 @@ func (s *service) GetCurrentState(ctx context.Context, in *getCurrentStateIn, co
 		return frontendComponents.Redirect(s.linkService.GameResultsLink(in.game.ID, in.player.ID)), nil
 	}
 
-	return frontendPublicGame.QuestionForm(
-		in.game.ID,
-		in.player.ID,
+	header := frontendComponents.Composition(
 		frontendPublicGame.Header(in.game.Title),
 		frontendComponents.GridLine(
 			frontendPublicGame.Progress(&handlers.SessionProgress{
@@ -72,19 +85,102 @@ func (s *service) GetCurrentState(ctx context.Context, in *getCurrentStateIn, co
 			}),
 			frontendPublicGame.Player(in.player.Name),
 		),
+	)
+	if session.WaitingForHost {
+		return frontendPublicGame.LiveWaiting(
+			in.game.ID,
+			s.realtime,
+			header,
+			frontendComponents.Composition(components...),
+		), nil
+	}
+
+	return frontendPublicGame.QuestionForm(
+		in.game.ID,
+		in.player.ID,
+		header,
+		getQuestionTimer(in.game, session),
 		frontendPublicGame.Question(
 			session.CurrentQuestion.ID,
-			frontendPublicGame.QuestionBlock(session.CurrentQuestion.Text, session.CurrentQuestion.ImageID),
+			getQuestionBlock(session.CurrentQuestion),
 			frontendComponents.Composition(
 				frontendPublicGame.AnswerChoiceDescription(session.CurrentQuestion.Type),
-				getAnswerOptions(session.CurrentQuestion),
+				getAnswerOptions(session),
 			),
 		),
 		frontendComponents.Composition(components...),
 	), nil
 }
 
-func getAnswerOptions(question *model.Question) templ.Component {
+func getQuestionTimer(game *model.Game, session *contracts.SessionState) templ.Component {
+	timeLimit := session.CurrentQuestion.GetTimeLimit(game.Settings)
+	if session.Deadline == nil || timeLimit == nil {
+		return frontendComponents.Composition()
+	}
+
+	return frontendPublicGame.QuestionTimer(
+		game.ID,
+		max(time.Until(*session.Deadline), 0),
+		*timeLimit,
+		contracts.AnswerGracePeriod,
+	)
+}
+
+// getSchedulePage страница перезагружается чуть позже начала игры, чтобы фоновая задача успела ее запустить
+func getSchedulePage(game *model.Game, now time.Time) templ.Component {
+	moscowLocation, _ := time.LoadLocation("Europe/Moscow")
+	startsIn := game.OpensAt.Sub(now)
+
+	return frontendPublicGame.SchedulePage(
+		game.Title,
+		formatStartsIn(startsIn),
+		game.OpensAt.In(moscowLocation).Format("15:04 02.01.2006"),
+		min(max(startsIn, 0)+scheduleReloadDelay, scheduleMaxReloadInterval),
+	)
+}
+
+func formatStartsIn(in time.Duration) string {
+	if in < time.Minute {
+		return "Игра вот-вот начнется"
+	}
+
+	days := int(in / (24 * time.Hour))
+	hours := int(in % (24 * time.Hour) / time.Hour)
+	minutes := int(in % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("Игра начнется через %d дн. %d ч", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("Игра начнется через %d ч %d мин", hours, minutes)
+	default:
+		return fmt.Sprintf("Игра начнется через %d мин", minutes)
+	}
+}
+
+func getQuestionBlock(question *model.Question) templ.Component {
+	if question.Type == model.QuestionTypeHotspot && question.ImageID != nil {
+		return frontendPublicGame.HotspotQuestionBlock(question.Text, *question.ImageID)
+	}
+
+	if question.Type != model.QuestionTypeCloze {
+		return frontendPublicGame.QuestionBlock(question.Text, question.ImageID)
+	}
+
+	parts := make([]handlers.ClozePart, 0, 4)
+	for _, part := range model.ParseCloze(question.Text) {
+		if part.IsGap() {
+			parts = append(parts, handlers.ClozePart{IsGap: true})
+			continue
+		}
+
+		parts = append(parts, handlers.ClozePart{Text: part.Text})
+	}
+
+	return frontendPublicGame.ClozeQuestionBlock(parts, question.ImageID)
+}
+
+func getAnswerOptions(session *contracts.SessionState) templ.Component {
+	question := session.CurrentQuestion
 	answerOptions := make([]handlers.AnswerOption, 0, len(question.AnswerOptions))
 	for _, answerOption := range question.AnswerOptions {
 		answerOptions = append(answerOptions, handlers.AnswerOption{
@@ -96,7 +192,17 @@ func getAnswerOptions(question *model.Question) templ.Component {
 	switch question.Type {
 	case model.QuestionTypeFillTheGap:
 		return frontendPublicGame.AnswerTextInput()
-	case model.QuestionTypeMultipleChoice, model.QuestionTypeOneOfChoice:
+	case model.QuestionTypeNumeric:
+		return frontendPublicGame.AnswerNumberInput()
+	case model.QuestionTypeOpenText:
+		return frontendPublicGame.AnswerOpenTextInput()
+	case model.QuestionTypeCloze, model.QuestionTypeHotspot:
+		return frontendComponents.Composition()
+	case model.QuestionTypeOrdering:
+		return frontendPublicGame.AnswerOrderingOptions(answerOptions)
+	case model.QuestionTypeMatching:
+		return frontendPublicGame.AnswerMatchingOptions(answerOptions, session.Pairs)
+	case model.QuestionTypeMultipleChoice, model.QuestionTypeOneOfChoice, model.QuestionTypeMultiplePoll:
 		return frontendPublicGame.AnswerChoiceOptions(question.Type, answerOptions, true)
 	default:
 		return frontendPublicGame.AnswerChoiceOptions(question.Type, answerOptions)
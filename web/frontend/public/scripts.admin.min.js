@@ -1 +1 @@
-let chosenQuestions=[];function copy(e){copyTextToClipboard(document.getElementById(e.getAttribute("data-copy-target")).value,"Ссылка скопирована")}function previewImage(e){let t=document.getElementById("image-preview"),n=document.getElementById("image-placeholder");var i;e.files&&e.files[0]?((i=new FileReader).onload=function(e){t.src=e.target.result,t.classList.remove("hidden"),n.classList.add("hidden")},i.readAsDataURL(e.files[0])):(t.src="",t.classList.add("hidden"),n.classList.remove("hidden"))}function showChoiceInput(e){e=e.getAttribute("data-id");document.getElementById("answer-choice-input-checkbox-"+e).checked=!1,document.getElementById("answer-choice-input-textarea-"+e).value="",document.getElementById("answer-choice-input-"+e).classList.remove("hidden"),document.getElementById("answer-choice-add-button-"+e).classList.add("hidden")}function showChoiceAddButton(e){e=e.getAttribute("data-id");document.getElementById("answer-choice-input-checkbox-"+e).checked=!1,document.getElementById("answer-choice-input-textarea-"+e).value="",document.getElementById("answer-choice-add-button-"+e).classList.remove("hidden"),document.getElementById("answer-choice-input-"+e).classList.add("hidden")}function selectQuestion(t){var e=chosenQuestions.findIndex(e=>e===t.value);t.checked&&-1===e&&chosenQuestions.push(t.value),t.checked||-1===e||chosenQuestions.splice(e,1)}function restoreSelectedQuestions(){chosenQuestions.forEach(e=>{e=document.getElementById(e);null!==e&&(e.checked=!0)})}function enrichRequestByQuestions(e){e=document.getElementById(e);null!==e&&e.addEventListener("submit",function(e){e.preventDefault(),chosenQuestions.forEach(e=>{var t=document.createElement("input");t.setAttribute("name","question"),t.setAttribute("value",e),t.setAttribute("type","hidden"),this.appendChild(t)}),this.submit()})}function questionListContainerListener(e){restoreSelectedQuestions()}document.addEventListener("htmx:afterRequest",function(e){"question-list-container"===e.detail.target.id&&questionListContainerListener(e)});
\ No newline at end of file
+let chosenQuestions=[];function copy(e){copyTextToClipboard(document.getElementById(e.getAttribute("data-copy-target")).value,"Ссылка скопирована")}function previewImage(e){let t=document.getElementById("image-preview"),n=document.getElementById("image-placeholder");var i;e.files&&e.files[0]?((i=new FileReader).onload=function(e){t.src=e.target.result,t.classList.remove("hidden"),n.classList.add("hidden")},i.readAsDataURL(e.files[0])):(t.src="",t.classList.add("hidden"),n.classList.remove("hidden"))}function showChoiceInput(e){e=e.getAttribute("data-id");document.getElementById("answer-choice-input-checkbox-"+e).checked=!1,document.getElementById("answer-choice-input-textarea-"+e).value="",document.getElementById("answer-choice-input-"+e).classList.remove("hidden"),document.getElementById("answer-choice-add-button-"+e).classList.add("hidden")}function showChoiceAddButton(e){e=e.getAttribute("data-id");document.getElementById("answer-choice-input-checkbox-"+e).checked=!1,document.getElementById("answer-choice-input-textarea-"+e).value="",document.getElementById("answer-choice-add-button-"+e).classList.remove("hidden"),document.getElementById("answer-choice-input-"+e).classList.add("hidden")}function selectQuestion(t){var e=chosenQuestions.findIndex(e=>e===t.value);t.checked&&-1===e&&chosenQuestions.push(t.value),t.checked||-1===e||chosenQuestions.splice(e,1)}function restoreSelectedQuestions(){chosenQuestions.forEach(e=>{e=document.getElementById(e);null!==e&&(e.checked=!0)})}function enrichRequestByQuestions(e){e=document.getElementById(e);null!==e&&e.addEventListener("submit",function(e){e.preventDefault(),chosenQuestions.forEach(e=>{var t=document.createElement("input");t.setAttribute("name","question"),t.setAttribute("value",e),t.setAttribute("type","hidden"),this.appendChild(t)}),this.submit()})}function questionListContainerListener(e){restoreSelectedQuestions()}document.addEventListener("htmx:afterRequest",function(e){"question-list-container"===e.detail.target.id&&questionListContainerListener(e)});let hotspotPolygon=[];function previewHotspotImage(e){let t=document.getElementById("hotspot-image-preview"),n=document.getElementById("hotspot-image-placeholder");var i;clearHotspotRegions(),e.files&&e.files[0]?((i=new FileReader).onload=function(e){t.src=e.target.result,t.classList.remove("hidden"),n.classList.add("hidden")},i.readAsDataURL(e.files[0])):(t.src="",t.classList.add("hidden"),n.classList.remove("hidden"))}function hotspotPoint(e,t){t=t.getBoundingClientRect();return[Math.min(Math.max((e.clientX-t.left)/t.width,0),1),Math.min(Math.max((e.clientY-t.top)/t.height,0),1)]}function initHotspotEditor(e){e=document.getElementById(e);if(null!==e){let n=e.querySelector("img"),i=null;e.addEventListener("mousedown",function(e){n.classList.contains("hidden")||(e.preventDefault(),"polygon"===document.getElementById("hotspot-mode").value?(hotspotPolygon.push(hotspotPoint(e,n)),renderHotspotRegions()):i=hotspotPoint(e,n))}),e.addEventListener("mouseup",function(e){var t;null!==i&&(t=hotspotPoint(e,n),.01<Math.abs(t[0]-i[0])&&.01<Math.abs(t[1]-i[1])&&addHotspotRegion([i,t]),i=null)})}}function finishHotspotPolygon(){3<=hotspotPolygon.length&&addHotspotRegion(hotspotPolygon),hotspotPolygon=[],renderHotspotRegions()}function addHotspotRegion(e){var t=document.getElementById("hotspot-regions");let n=document.createElement("div");n.classList.add("flex","gap-2","items-center"),n.setAttribute("data-hotspot-region","");var i=document.createElement("input"),o=(i.setAttribute("type","text"),i.setAttribute("name","question_answer_option_text"),i.setAttribute("placeholder",'Подпись области, например "Франция"'),i.classList.add("input","input-sm","w-full","text-black"),document.createElement("input")),r=(o.setAttribute("type","hidden"),o.setAttribute("name","question_hotspot_region"),o.value=e.map(e=>e[0].toFixed(4)+" "+e[1].toFixed(4)).join(","),document.createElement("button"));r.setAttribute("type","button"),r.classList.add("btn","btn-sm"),r.textContent="✕",r.addEventListener("click",function(){n.remove(),renderHotspotRegions()}),n.append(i,o,r),t.appendChild(n),renderHotspotRegions()}function clearHotspotRegions(){var e=document.getElementById("hotspot-regions");null!==e&&(e.innerHTML="",hotspotPolygon=[],renderHotspotRegions())}function renderHotspotRegions(){var e,t=document.getElementById("hotspot-canvas");null!==t&&(e=Array.from(document.querySelectorAll('input[name="question_hotspot_region"]')).map(e=>{var t,n,i,e=e.value.split(",").map(e=>e.split(" ").map(parseFloat));return 2===e.length?(t=Math.min(e[0][0],e[1][0]),n=Math.min(e[0][1],e[1][1]),i=Math.abs(e[0][0]-e[1][0]),e=Math.abs(e[0][1]-e[1][1]),`<rect x="${t}" y="${n}" width="${i}" height="${e}" fill="#22c55e" fill-opacity="0.4" stroke="#15803d" stroke-width="2" vector-effect="non-scaling-stroke"></rect>`):`<polygon points="${e.map(e=>e.join(",")).join(" ")}" fill="#22c55e" fill-opacity="0.4" stroke="#15803d" stroke-width="2" vector-effect="non-scaling-stroke"></polygon>`}),0<hotspotPolygon.length&&e.push(`<polyline points="${hotspotPolygon.map(e=>e.join(",")).join(" ")}" fill="none" stroke="#f59e0b" stroke-width="2" vector-effect="non-scaling-stroke"></polyline>`),t.innerHTML=e.join(""))}
@@ -6,4 +6,4 @@ function fallbackCopyTextToClipboard(e,t){var o=document.createElement("textarea
                               <path stroke-linecap="round" stroke-linejoin="round" d="M6 18 18 6M6 6l12 12" />
                             </svg>
                         </button>
-                    </div>`,setTimeout(function(){document.getElementById(n).remove()},5e3)}function uuidv4(){return"10000000-1000-4000-8000-100000000000".replace(/[018]/g,e=>(+e^crypto.getRandomValues(new Uint8Array(1))[0]&15>>+e/4).toString(16))}htmx.on("htmx:responseError",function(e){addToast(e.detail.xhr.responseText,"error")});
\ No newline at end of file
+                    </div>`,setTimeout(function(){document.getElementById(n).remove()},5e3)}function uuidv4(){return"10000000-1000-4000-8000-100000000000".replace(/[018]/g,e=>(+e^crypto.getRandomValues(new Uint8Array(1))[0]&15>>+e/4).toString(16))}htmx.on("htmx:responseError",function(e){addToast(e.detail.xhr.responseText,"error")});function initSortable(e){const t=document.getElementById(e);if(t&&!t.dataset.sortableInitialized){t.dataset.sortableInitialized="true";let n=null;t.addEventListener("dragstart",function(e){(n=e.target.closest("[data-sortable-item]"))&&(n.classList.add("opacity-50"),e.dataTransfer.effectAllowed="move")}),t.addEventListener("dragover",function(e){if(n){e.preventDefault();const o=e.target.closest("[data-sortable-item]");if(o&&o!==n&&o.parentNode===t){const i=o.getBoundingClientRect(),a=e.clientY>i.top+i.height/2;t.insertBefore(n,a?o.nextSibling:o)}}}),t.addEventListener("dragend",function(){n&&n.classList.remove("opacity-50"),n=null,t.dispatchEvent(new Event("change",{bubbles:!0}))})}}function moveSortableItem(e,t){const n=e.closest("[data-sortable-item]");if(n){const e=t<0?n.previousElementSibling:n.nextElementSibling;e&&(n.parentNode.insertBefore(n,t<0?e:e.nextSibling),n.dispatchEvent(new Event("change",{bubbles:!0})))}}
\ No newline at end of file
@@ -1 +1 @@
-let timeouts=[],playPageQuestionFormPromise=null;function clearTimeouts(){timeouts.forEach(e=>clearTimeout(e)),timeouts=[]}function scrollToTop(){window.scrollTo(0,0)}function initPlayPageQuestionForm(){let s=document.getElementById("play-page-question-form");if(null!=s){let a=document.getElementById("play-page-submit-button"),i=document.getElementById("play-page-submit"),o=s.querySelector('input[type="text"]');o&&o.addEventListener("input",function(){0<o.value.trim().length?(a.disabled=!1,i.classList.remove("hidden","opacity-0"),i.classList.add("animate-fade-in-up")):(a.disabled=!0,i.classList.add("hidden","opacity-0"),i.classList.remove("animate-fade-in-up"))}),s.addEventListener("change",function(){var e=s.querySelector('input[type="checkbox"]:checked, input[type="radio"]:checked'),t=!!o&&0<o.value.trim().length;e||t?(a.disabled=!1,i.classList.remove("hidden","opacity-0"),i.classList.add("animate-fade-in-up")):(a.disabled=!0,i.classList.add("hidden","opacity-0"),i.classList.remove("animate-fade-in-up"))})}}function beforeRequestPlayPageQuestionForm(e){var t=document.getElementById("game-page-overlay");t.classList.remove("hidden"),t.classList.add("opacity-0"),t.offsetHeight,t.classList.add("opacity-100"),t.classList.remove("opacity-0"),playPageQuestionFormPromise=new Promise(e=>{setTimeout(()=>{e()},300)})}function afterRequestPlayPageQuestionForm(e){playPageQuestionFormPromise&&(e.preventDefault(),playPageQuestionFormPromise.then(()=>{htmx.swap(e.detail.target,e.detail.xhr.response,{swapStyle:"outerHTML"})}).finally(()=>{playPageQuestionFormPromise=null}))}function showAnswerResult(){var e=document.getElementById("game-page-answer-read-estimation"),e=e?parseInt(e.value):1500,e=Math.max(1500,e);clearTimeouts(),hideAnswerResult(e,300)}function hideAnswerResult(e,t){let a=document.getElementById("game-page-answer-result");var i=document.getElementById("game-page-overlay");i.classList.remove("opacity-100"),i.classList.add("hidden","opacity-0"),timeouts.push(setTimeout(()=>{a.classList.add("opacity-0"),a.classList.remove("opacity-100","animate-pulse-fade-in")},e-t)),timeouts.push(setTimeout(()=>{a.classList.add("hidden");var e=document.getElementById("game-page-results-link");null!==e&&(window.location=e.value)},e))}function fire(){var e={origin:{y:.9}};confetti({...e,spread:26,startVelocity:55,particleCount:Math.floor(50)}),confetti({...e,spread:60,particleCount:Math.floor(40)}),confetti({...e,spread:100,decay:.91,scalar:.8,particleCount:Math.floor(70)}),confetti({...e,spread:120,startVelocity:25,decay:.92,scalar:1.2,particleCount:Math.floor(20)}),confetti({...e,spread:120,startVelocity:45,particleCount:Math.floor(20)})}function connectToGame(){var e=document.getElementById("game-start-page-game-id").value;""!==e&&(window.location="/game/"+e)}function copyShareResultsBlock(e){e=e.getAttribute("data-additional-text");let t=window.location.href;null!==e&&""!==e&&(t=e+"\n"+t),copyTextToClipboard(t,"Ссылка скопирована")}
\ No newline at end of file
+let timeouts=[],playPageQuestionFormPromise=null,questionTimerInterval=null;function clearTimeouts(){timeouts.forEach(e=>clearTimeout(e)),timeouts=[]}function scrollToTop(){window.scrollTo(0,0)}function initPlayPageQuestionForm(){let s=document.getElementById("play-page-question-form");if(null!=s){let a=document.getElementById("play-page-submit-button"),i=document.getElementById("play-page-submit"),o=Array.from(s.querySelectorAll('input[type="text"], textarea[name="answer"]')),d=()=>o.some(e=>0<e.value.trim().length);o.forEach(e=>{e.addEventListener("input",function(){d()?(a.disabled=!1,i.classList.remove("hidden","opacity-0"),i.classList.add("animate-fade-in-up")):(a.disabled=!0,i.classList.add("hidden","opacity-0"),i.classList.remove("animate-fade-in-up"))})}),s.addEventListener("change",function(){var e=s.querySelector('input[type="checkbox"]:checked, input[type="radio"]:checked'),t=d(),n=s.querySelector("[data-sortable-item]"),r=Array.from(s.querySelectorAll('select[name="answer"]')),l=0<r.length&&r.every(e=>""!==e.value),c=Array.from(s.querySelectorAll("input[data-hotspot-answer]")).some(e=>""!==e.value);e||t||n||l||c?(a.disabled=!1,i.classList.remove("hidden","opacity-0"),i.classList.add("animate-fade-in-up")):(a.disabled=!0,i.classList.add("hidden","opacity-0"),i.classList.remove("animate-fade-in-up"))}),s.querySelector("[data-sortable-item]")&&(a.disabled=!1,i.classList.remove("hidden","opacity-0"),i.classList.add("animate-fade-in-up"))}}function selectHotspotPoint(e,t){var n=t.getBoundingClientRect(),r=Math.min(Math.max((e.clientX-n.left)/n.width,0),1),n=Math.min(Math.max((e.clientY-n.top)/n.height,0),1),t=t.parentElement,e=t.querySelector("input[data-hotspot-answer]"),t=(e.value=r.toFixed(4)+" "+n.toFixed(4),t.querySelector("[data-hotspot-marker]"));t.style.left=100*r+"%",t.style.top=100*n+"%",t.classList.remove("hidden"),e.dispatchEvent(new Event("change",{bubbles:!0}))}function initQuestionTimer(){clearInterval(questionTimerInterval);let t=document.getElementById("play-page-timer");if(null!=t){let e=t.closest("form"),n=t.querySelector("[data-timer-value]"),r=t.querySelector("progress"),i=Date.now()+parseInt(t.dataset.remaining),a=parseInt(t.dataset.grace),o=()=>{var l=Math.max(i-Date.now(),0);n.textContent=Math.ceil(l/1e3)+" с",r.value=l,0<l||(clearInterval(questionTimerInterval),n.textContent="Время вышло",e.inert=!0,document.getElementById("play-page-submit-button").disabled=!0,setTimeout(()=>{document.body.contains(t)&&htmx.ajax("GET",t.dataset.url,{target:e,select:"#play-page-question-form",swap:"outerHTML"})},a))};o(),questionTimerInterval=setInterval(o,250)}}function beforeRequestPlayPageQuestionForm(e){var t=document.getElementById("game-page-overlay");t.classList.remove("hidden"),t.classList.add("opacity-0"),t.offsetHeight,t.classList.add("opacity-100"),t.classList.remove("opacity-0"),playPageQuestionFormPromise=new Promise(e=>{setTimeout(()=>{e()},300)})}function afterRequestPlayPageQuestionForm(e){playPageQuestionFormPromise&&(e.preventDefault(),playPageQuestionFormPromise.then(()=>{htmx.swap(e.detail.target,e.detail.xhr.response,{swapStyle:"outerHTML"})}).finally(()=>{playPageQuestionFormPromise=null}))}function showAnswerResult(){var e=document.getElementById("game-page-answer-read-estimation"),e=e?parseInt(e.value):1500,e=Math.max(1500,e);clearTimeouts(),hideAnswerResult(e,300)}function hideAnswerResult(e,t){let a=document.getElementById("game-page-answer-result");var i=document.getElementById("game-page-overlay");i.classList.remove("opacity-100"),i.classList.add("hidden","opacity-0"),timeouts.push(setTimeout(()=>{a.classList.add("opacity-0"),a.classList.remove("opacity-100","animate-pulse-fade-in")},e-t)),timeouts.push(setTimeout(()=>{a.classList.add("hidden");var e=document.getElementById("game-page-results-link");null!==e&&(window.location=e.value)},e))}function fire(){var e={origin:{y:.9}};confetti({...e,spread:26,startVelocity:55,particleCount:Math.floor(50)}),confetti({...e,spread:60,particleCount:Math.floor(40)}),confetti({...e,spread:100,decay:.91,scalar:.8,particleCount:Math.floor(70)}),confetti({...e,spread:120,startVelocity:25,decay:.92,scalar:1.2,particleCount:Math.floor(20)}),confetti({...e,spread:120,startVelocity:45,particleCount:Math.floor(20)})}function connectToGame(){var e=document.getElementById("game-start-page-game-id").value;""!==e&&(window.location="/game/"+e)}function copyShareResultsBlock(e){e=e.getAttribute("data-additional-text");let t=window.location.href;null!==e&&""!==e&&(t=e+"\n"+t),copyTextToClipboard(t,"Ссылка скопирована")}
\ No newline at end of file
@@ -1,22 +1,39 @@
 package session
 
 import (
+	"context"
 	"github.com/a-h/templ"
 	"github.com/google/uuid"
 	"net/http"
+	"quizzly/internal/quizzly/model"
+	"quizzly/web/frontend/handlers"
 )
 
 type (
 	ListOut struct {
-		Result     []templ.Component
-		TotalCount int64
+		Result         []templ.Component
+		TotalCount     int64
+		FinishedCount  int64
+		AbandonedCount int64
 	}
 
 	Spec struct {
 		GameID uuid.UUID
+		// AllAttempts по умолчанию от каждого игрока показывается только последняя попытка
+		AllAttempts bool
+	}
+
+	LeaderboardSpec struct {
+		GameID          uuid.UUID
+		Order           model.LeaderboardOrder
+		CurrentPlayerID *uuid.UUID
 	}
 
 	Service interface {
 		List(request *http.Request, spec *Spec, page int64, limit int64) (*ListOut, error)
+		MonitorList(ctx context.Context, spec *Spec) ([]handlers.SessionMonitorItem, error)
+		MonitorItem(ctx context.Context, spec *Spec, playerID uuid.UUID) (*handlers.SessionMonitorItem, error)
+		ReviewList(ctx context.Context, spec *Spec) ([]handlers.ReviewItem, error)
+		Leaderboard(ctx context.Context, spec *LeaderboardSpec) ([]handlers.LeaderboardItem, error)
 	}
 )
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"quizzly/internal/quizzly/contracts"
@@ -11,24 +12,32 @@ import (
 	"quizzly/web/frontend/handlers"
 	frontend_admin_game "quizzly/web/frontend/templ/admin/game"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/a-h/templ"
 	"github.com/google/uuid"
 )
 
+const (
+	monitorLimit = 200
+)
+
 type (
 	DefaultService struct {
+		games    contracts.GameUsecase
 		sessions contracts.SessionUsecase
 		players  contracts.PLayerUsecase
 	}
 )
 
 func NewService(
+	games contracts.GameUsecase,
 	sessions contracts.SessionUsecase,
 	players contracts.PLayerUsecase,
 ) Service {
 	return &DefaultService{
+		games:    games,
 		sessions: sessions,
 		players:  players,
 	}
@@ -47,7 +56,12 @@ func (s *DefaultService) List(request *http.Request, spec *Spec, page int64, lim
 		}
 	}
 
-	specificSessions, err := s.sessions.GetExtendedSessions(request.Context(), spec.GameID, page, limit)
+	specificSessions, err := s.sessions.GetExtendedSessions(request.Context(), &contracts.GetExtendedSessionsIn{
+		GameID:      spec.GameID,
+		Page:        page,
+		Limit:       limit,
+		AllAttempts: spec.AllAttempts,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -86,17 +100,214 @@ func (s *DefaultService) List(request *http.Request, spec *Spec, page int64, lim
 
 			return frontend_admin_game.SessionListItem(handlers.SessionItemStatistics{
 				PlayerName:                    playerName,
+				Attempt:                       session.Attempt,
 				CompletionRate:                int(session.CompletionRate()),
+				Score:                         session.Score(),
 				SessionStatus:                 session.Status,
 				SessionStartedAt:              sessionStartedAt,
 				SessionLastQuestionAnsweredAt: sessionLastQuestionAnsweredAt,
 			},
 			)
 		}),
-		TotalCount: specificSessions.TotalCount,
+		TotalCount:     specificSessions.TotalCount,
+		FinishedCount:  specificSessions.FinishedCount,
+		AbandonedCount: specificSessions.AbandonedCount,
 	}, nil
 }
 
+func (s *DefaultService) MonitorList(ctx context.Context, spec *Spec) ([]handlers.SessionMonitorItem, error) {
+	questions, err := s.games.GetQuestions(ctx, spec.GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	specificSessions, err := s.sessions.GetExtendedSessions(ctx, &contracts.GetExtendedSessionsIn{
+		GameID: spec.GameID,
+		Page:   1,
+		Limit:  monitorLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	specificPlayersMap, err := s.getPlayersMap(ctx, specificSessions.Result...)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(specificSessions.Result, func(i, j int) bool {
+		return specificSessions.Result[i].ID < specificSessions.Result[j].ID
+	})
+	return slices.SafeMap(specificSessions.Result, func(session model.ExtendedSession) handlers.SessionMonitorItem {
+		return convertMonitorItem(&session, specificPlayersMap[session.PlayerID].Name, len(questions))
+	}), nil
+}
+
+func (s *DefaultService) MonitorItem(ctx context.Context, spec *Spec, playerID uuid.UUID) (*handlers.SessionMonitorItem, error) {
+	questions, err := s.games.GetQuestions(ctx, spec.GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessions.GetExtendedSession(ctx, spec.GameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	specificPlayersMap, err := s.getPlayersMap(ctx, *session)
+	if err != nil {
+		return nil, err
+	}
+
+	return structs.Pointer(convertMonitorItem(session, specificPlayersMap[session.PlayerID].Name, len(questions))), nil
+}
+
+func (s *DefaultService) Leaderboard(ctx context.Context, spec *LeaderboardSpec) ([]handlers.LeaderboardItem, error) {
+	entries, err := s.sessions.GetLeaderboard(ctx, spec.GameID, spec.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	specificPlayers, err := s.players.Get(
+		ctx,
+		slices.SafeMap(entries, func(entry model.LeaderboardEntry) uuid.UUID {
+			return entry.PlayerID
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	specificPlayersMap := make(map[uuid.UUID]model.Player, len(specificPlayers))
+	for _, player := range specificPlayers {
+		specificPlayersMap[player.ID] = player
+	}
+
+	result := make([]handlers.LeaderboardItem, 0, len(entries))
+	for i, entry := range entries {
+		result = append(result, handlers.LeaderboardItem{
+			Position:            i + 1,
+			PlayerName:          specificPlayersMap[entry.PlayerID].Name,
+			Score:               entry.Score,
+			CorrectAnswersCount: int(entry.CorrectAnswersCount),
+			Duration:            formatDuration(entry.Duration),
+			IsCurrentPlayer:     spec.CurrentPlayerID != nil && *spec.CurrentPlayerID == entry.PlayerID,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *DefaultService) ReviewList(ctx context.Context, spec *Spec) ([]handlers.ReviewItem, error) {
+	questions, err := s.games.GetQuestions(ctx, spec.GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	questionsMap := make(map[uuid.UUID]model.Question, len(questions))
+	for _, question := range questions {
+		questionsMap[question.ID] = question
+	}
+
+	items, err := s.sessions.GetPendingReviews(ctx, spec.GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	specificPlayers, err := s.players.Get(
+		ctx,
+		slices.SafeMap(items, func(item model.ReviewItem) uuid.UUID {
+			return item.PlayerID
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	specificPlayersMap := make(map[uuid.UUID]model.Player, len(specificPlayers))
+	for _, player := range specificPlayers {
+		specificPlayersMap[player.ID] = player
+	}
+
+	moscowLocation, _ := time.LoadLocation("Europe/Moscow")
+	return slices.SafeMap(items, func(item model.ReviewItem) handlers.ReviewItem {
+		question := questionsMap[item.QuestionID]
+
+		result := handlers.ReviewItem{
+			ItemID:       item.ID,
+			PlayerName:   specificPlayersMap[item.PlayerID].Name,
+			QuestionText: question.Text,
+			Answer:       strings.Join(item.Answers, " "),
+		}
+		if len(question.AnswerOptions) > 0 {
+			result.ReferenceAnswer = question.AnswerOptions[0].Answer
+		}
+		if item.AnsweredAt != nil {
+			result.AnsweredAt = item.AnsweredAt.In(moscowLocation)
+		}
+
+		return result
+	}), nil
+}
+
+func (s *DefaultService) getPlayersMap(ctx context.Context, sessions ...model.ExtendedSession) (map[uuid.UUID]model.Player, error) {
+	specificPlayers, err := s.players.Get(
+		ctx,
+		slices.SafeMap(sessions, func(session model.ExtendedSession) uuid.UUID {
+			return session.PlayerID
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID]model.Player, len(specificPlayers))
+	for _, player := range specificPlayers {
+		result[player.ID] = player
+	}
+
+	return result, nil
+}
+
+func convertMonitorItem(session *model.ExtendedSession, playerName string, questionsCount int) handlers.SessionMonitorItem {
+	moscowLocation, _ := time.LoadLocation("Europe/Moscow")
+
+	// игроку могли выпасть не все вопросы игры
+	if session.QuestionIDs != nil {
+		questionsCount = len(session.QuestionIDs)
+	}
+
+	result := handlers.SessionMonitorItem{
+		PlayerID:       session.PlayerID,
+		PlayerName:     playerName,
+		QuestionsCount: questionsCount,
+		SessionStatus:  session.Status,
+		LastActivityAt: session.CreatedAt,
+	}
+	answeredCount := 0
+	for _, item := range session.Items {
+		if item.AnsweredAt == nil {
+			continue
+		}
+
+		answeredCount++
+		switch {
+		case !item.IsScored(), item.IsPendingReview():
+		case item.IsCorrect != nil && *item.IsCorrect:
+			result.CorrectAnswersCount++
+		default:
+			result.IncorrectAnswersCount++
+		}
+		if item.AnsweredAt.After(result.LastActivityAt) {
+			result.LastActivityAt = *item.AnsweredAt
+		}
+	}
+
+	result.CurrentQuestion = min(answeredCount+1, questionsCount)
+	result.LastActivityAt = result.LastActivityAt.In(moscowLocation)
+	return result
+}
+
 func findSessionLastAnswerTime(in []model.SessionItem) *time.Time {
 	var maximum *time.Time
 	for _, item := range in {
@@ -112,3 +323,12 @@ func findSessionLastAnswerTime(in []model.SessionItem) *time.Time {
 
 	return maximum
 }
+
+func formatDuration(in time.Duration) string {
+	seconds := int(in.Round(time.Second).Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	}
+
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -91,4 +91,151 @@ document.addEventListener('htmx:afterRequest', function (evt) {
 
 function questionListContainerListener(evt) {
     restoreSelectedQuestions()
-}
\ No newline at end of file
+}
+
+let hotspotPolygon = [];
+
+function previewHotspotImage(input) {
+    const preview = document.getElementById('hotspot-image-preview');
+    const placeholder = document.getElementById('hotspot-image-placeholder');
+    clearHotspotRegions();
+
+    if (input.files && input.files[0]) {
+        const reader = new FileReader();
+
+        reader.onload = function (e) {
+            preview.src = e.target.result;
+            preview.classList.remove('hidden');
+            placeholder.classList.add('hidden');
+        }
+
+        reader.readAsDataURL(input.files[0]);
+    } else {
+        preview.src = '';
+        preview.classList.add('hidden');
+        placeholder.classList.remove('hidden');
+    }
+}
+
+// Координаты считаются в долях от размеров изображения, поэтому не зависят от размера экрана
+function hotspotPoint(event, element) {
+    const rect = element.getBoundingClientRect();
+    return [
+        Math.min(Math.max((event.clientX - rect.left) / rect.width, 0), 1),
+        Math.min(Math.max((event.clientY - rect.top) / rect.height, 0), 1),
+    ];
+}
+
+function initHotspotEditor(id) {
+    const editor = document.getElementById(id);
+    if (editor === null) {
+        return
+    }
+
+    const preview = editor.querySelector('img');
+    let start = null;
+
+    editor.addEventListener('mousedown', function (event) {
+        if (preview.classList.contains('hidden')) {
+            return
+        }
+
+        event.preventDefault();
+        if (document.getElementById('hotspot-mode').value === 'polygon') {
+            hotspotPolygon.push(hotspotPoint(event, preview));
+            renderHotspotRegions();
+            return
+        }
+
+        start = hotspotPoint(event, preview);
+    });
+
+    editor.addEventListener('mouseup', function (event) {
+        if (start === null) {
+            return
+        }
+
+        const end = hotspotPoint(event, preview);
+        if (Math.abs(end[0] - start[0]) > 0.01 && Math.abs(end[1] - start[1]) > 0.01) {
+            addHotspotRegion([start, end]);
+        }
+        start = null;
+    });
+}
+
+function finishHotspotPolygon() {
+    if (hotspotPolygon.length >= 3) {
+        addHotspotRegion(hotspotPolygon);
+    }
+    hotspotPolygon = [];
+    renderHotspotRegions();
+}
+
+function addHotspotRegion(points) {
+    const regions = document.getElementById('hotspot-regions');
+
+    const row = document.createElement('div');
+    row.classList.add('flex', 'gap-2', 'items-center');
+    row.setAttribute('data-hotspot-region', '');
+
+    const label = document.createElement('input');
+    label.setAttribute('type', 'text');
+    label.setAttribute('name', 'question_answer_option_text');
+    label.setAttribute('placeholder', 'Подпись области, например "Франция"');
+    label.classList.add('input', 'input-sm', 'w-full', 'text-black');
+
+    const region = document.createElement('input');
+    region.setAttribute('type', 'hidden');
+    region.setAttribute('name', 'question_hotspot_region');
+    region.value = points.map(point => point[0].toFixed(4) + ' ' + point[1].toFixed(4)).join(',');
+
+    const remove = document.createElement('button');
+    remove.setAttribute('type', 'button');
+    remove.classList.add('btn', 'btn-sm');
+    remove.textContent = '✕';
+    remove.addEventListener('click', function () {
+        row.remove();
+        renderHotspotRegions();
+    });
+
+    row.append(label, region, remove);
+    regions.appendChild(row);
+    renderHotspotRegions();
+}
+
+function clearHotspotRegions() {
+    const regions = document.getElementById('hotspot-regions');
+    if (regions === null) {
+        return
+    }
+
+    regions.innerHTML = '';
+    hotspotPolygon = [];
+    renderHotspotRegions();
+}
+
+function renderHotspotRegions() {
+    const canvas = document.getElementById('hotspot-canvas');
+    if (canvas === null) {
+        return
+    }
+
+    const shapes = Array.from(document.querySelectorAll('input[name="question_hotspot_region"]')).map(input => {
+        const points = input.value.split(',').map(point => point.split(' ').map(parseFloat));
+        if (points.length === 2) {
+            const x = Math.min(points[0][0], points[1][0]);
+            const y = Math.min(points[0][1], points[1][1]);
+            const width = Math.abs(points[0][0] - points[1][0]);
+            const height = Math.abs(points[0][1] - points[1][1]);
+            return `<rect x="${x}" y="${y}" width="${width}" height="${height}" fill="#22c55e" fill-opacity="0.4" stroke="#15803d" stroke-width="2" vector-effect="non-scaling-stroke"></rect>`
+        }
+
+        return `<polygon points="${points.map(point => point.join(',')).join(' ')}" fill="#22c55e" fill-opacity="0.4" stroke="#15803d" stroke-width="2" vector-effect="non-scaling-stroke"></polygon>`
+    });
+
+    if (hotspotPolygon.length > 0) {
+        shapes.push(`<polyline points="${hotspotPolygon.map(point => point.join(',')).join(' ')}" fill="none" stroke="#f59e0b" stroke-width="2" vector-effect="non-scaling-stroke"></polyline>`);
+    }
+
+    canvas.innerHTML = shapes.join('');
+}
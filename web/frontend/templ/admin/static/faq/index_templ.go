@@ -23,7 +23,20 @@ func HowToCreateQuestion() templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\"><p class=\"mb-3\">Чтобы создать новый вопрос, перейдите в раздел <a href=\"/admin/question/list\" target=\"_blank\" class=\"link link-primary no-underline\"><b>\"Список вопросов\"</b></a> и нажмите кнопку <a href=\"/admin/question/new\" target=\"_blank\" class=\"btn btn-success rounded-2xl btn-sm align-middle\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"size-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"M12 9v6m3-3H9m12 0a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z\"></path></svg> Добавить новый вопрос</a></p><div class=\"mb-3\">Перед вами откроется форма для создания вопроса. Сначала нужно выбрать тип вопроса:<ul class=\"list-inside list-decimal\"><li class=\"pl-4\"><b>Один ответ</b> - у вопроса есть только один правильный ответ.</li><li class=\"pl-4\"><b>Несколько ответов</b> - в вопросе может быть несколько правильных ответов. Используйте селектор \"Считать ответ верным если\", чтобы определить, как засчитывать правильный ответ:<ul class=\"list-inside list-disc\"><li class=\"pl-6\"><i>\"Выбраны ВСЕ правильные варианты ответа\"</i> - ответ будет засчитан, если выбраны все правильные варианты.</li><li class=\"pl-6\"><i>\"Выбран ЛЮБОЙ из правильных вариантов ответа\"</i> - ответ считается верным, если выбран хотя бы один из правильных вариантов. (в случае выбора неверного ответа, весь ответ не засчитывается)</li></ul></li><li class=\"pl-4\"><b>Ввод слова</b> -  здесь нужно ввести ответ самостоятельно. Правильность ответа проверяется по точному совпадению с ответом, который вы укажете. Не учитывается регистр букв. Если нужно задать вопрос с пропуском слова, используйте символ \"_\" для указания места пропуска (по желанию).</li></ul></div><p>Каждый тип вопроса требует заполнения текста вопроса, также можно добавить изображение, если это необходимо. <br>Ввод вариантов ответа зависит от выбранного типа вопроса.</p></div>")
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\"><p class=\"mb-3\">Чтобы создать новый вопрос, перейдите в раздел <a href=\"/admin/question/list\" target=\"_blank\" class=\"link link-primary no-underline\"><b>\"Список вопросов\"</b></a> и нажмите кнопку <a href=\"/admin/question/new\" target=\"_blank\" class=\"btn btn-success rounded-2xl btn-sm align-middle\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"size-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"M12 9v6m3-3H9m12 0a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z\"></path></svg> Добавить новый вопрос</a></p><div class=\"mb-3\">Перед вами откроется форма для создания вопроса. Сначала нужно выбрать тип вопроса:<ul class=\"list-inside list-decimal\"><li class=\"pl-4\"><b>Один ответ</b> - у вопроса есть только один правильный ответ.</li><li class=\"pl-4\"><b>Несколько ответов</b> - в вопросе может быть несколько правильных ответов. Используйте селектор \"Считать ответ верным если\", чтобы определить, как засчитывать правильный ответ:<ul class=\"list-inside list-disc\"><li class=\"pl-6\"><i>\"Выбраны ВСЕ правильные варианты ответа\"</i> - ответ будет засчитан, если выбраны все правильные варианты.</li><li class=\"pl-6\"><i>\"Выбран ЛЮБОЙ из правильных вариантов ответа\"</i> - ответ считается верным, если выбран хотя бы один из правильных вариантов. (в случае выбора неверного ответа, весь ответ не засчитывается)</li></ul></li><li class=\"pl-4\"><b>Ввод слова</b> -  здесь нужно ввести ответ самостоятельно. Можно указать несколько допустимых ответов. Перед проверкой у ответа убираются знаки препинания и лишние пробелы, буква \"ё\" заменяется на \"е\". Способ проверки выбирается в форме вопроса:<ul class=\"list-inside list-disc\"><li class=\"pl-6\"><i>\"Допускаются опечатки\"</i> - ответ засчитывается, если число опечаток не превышает заданного (по умолчанию 2) или доля совпадения не ниже заданной. Регистр букв не учитывается.</li><li class=\"pl-6\"><i>\"Без учета регистра\"</i> - ответ должен совпасть с одним из допустимых, регистр букв не учитывается.</li><li class=\"pl-6\"><i>\"Точное совпадение\"</i> - ответ должен совпасть с одним из допустимых с учетом регистра.</li><li class=\"pl-6\"><i>\"Регулярное выражение\"</i> - допустимые ответы являются регулярными выражениями, ответ должен совпасть с одним из них целиком.</li></ul>Если нужно задать вопрос с пропуском слова, используйте символ \"_\" для указания места пропуска (по желанию).</li><li class=\"pl-4\"><b>Число</b> - участнику нужно ввести число. Укажите правильный ответ и допустимую погрешность (абсолютную или в процентах) либо диапазон допустимых значений. Дробную часть можно отделять как точкой, так и запятой.</li><li class=\"pl-4\"><b>Пропуски</b> - ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var2 string
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(`в тексте вопроса может быть несколько пропусков. Правильные ответы указываются в фигурных скобках, например: "Столица {Франции} - {Париж}". Несколько допустимых ответов разделяются символом "|". Ответ засчитывается, только если все пропуски заполнены верно.`)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `templ/admin/static/faq/index.templ`, Line: 39, Col: 501}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("</li><li class=\"pl-4\"><b>Порядок</b> - участнику нужно расставить варианты ответа в правильном порядке. Укажите варианты в том порядке, который считается верным, участникам они будут показаны перемешанными. Ответ засчитывается, только если все варианты стоят на своих местах.</li><li class=\"pl-4\"><b>Пары</b> - участнику нужно сопоставить левую и правую части пар. Части показываются в двух перемешанных колонках. Ответ засчитывается, только если все пары сопоставлены верно.</li><li class=\"pl-4\"><b>Область на картинке</b> - автор загружает изображение и отмечает на нем одну или несколько правильных областей: прямоугольники или многоугольники. Участник нажимает на изображение, ответ засчитывается, если точка попала в любую из отмеченных областей. Области задаются в долях от размеров изображения, поэтому проверка не зависит от размера экрана.</li><li class=\"pl-4\"><b>Развернутый ответ</b> - участник пишет ответ в свободной форме. Такие ответы не проверяются автоматически: автор игры проверяет их на вкладке \"Проверка\" страницы игры, засчитывает или не засчитывает ответ и может оставить комментарий. До проверки ответ отображается у участника как \"На проверке\".</li><li class=\"pl-4\"><b>Опрос</b> - вопрос без правильного ответа, участник может выбрать один или несколько вариантов. После ответа участник увидит, как распределились голоса всех участников. Опросы не учитываются в количестве правильных ответов и в статистике.</li></ul></div><p>Каждый тип вопроса требует заполнения текста вопроса, также можно добавить изображение, если это необходимо. <br>Ввод вариантов ответа зависит от выбранного типа вопроса.</p><p class=\"mt-3\">По умолчанию вопросы идут по порядку. В блоке \"Переходы\" под вариантами ответа можно выбрать, какой вопрос показать следующим: после любого ответа или, в вопросах с выбором одного варианта и опросах, после конкретного варианта. Так можно собрать тест с ветвлением или опрос, который пропускает лишние вопросы. Переходы не должны зацикливаться, а на каждый вопрос должен вести хотя бы один путь от первого вопроса – иначе игру не получится начать. Переходы не работают в live-игре и при перемешивании вопросов.</p><p class=\"mt-3\">Вопросы можно загрузить сразу из файла в формате GIFT, который используется в Moodle: вкладка \"Из файла GIFT\" в окне добавления вопроса. Вопросы с выбором ответа превращаются в вопросы с одним или несколькими ответами, короткий ответ – в ввод слова, \"верно/неверно\" – в вопрос с двумя вариантами. Категория вопроса становится его тегом. Если в файле есть неподдерживаемые вопросы или ошибки, не добавляется ни один вопрос, а под формой выводится список ошибок с номерами строк.</p><p class=\"mt-3\">Вопросы можно также загрузить из таблицы CSV или XLSX (вкладка \"Из таблицы\") и выгрузить в таблицу кнопками \"Скачать CSV\" и \"Скачать XLSX\" на странице игры. Выгруженную таблицу можно отредактировать и загрузить в другую игру. Первая строка таблицы – названия колонок, каждая следующая строка – один вопрос. Обязательны только колонки type и text:</p><ul class=\"list-inside list-disc\"><li class=\"pl-4\"><b>type</b> – тип вопроса: choice, one_of_choice, multiple_choice, fill_the_gap, ordering, matching, numeric, cloze, poll, multiple_poll, open_text или hotspot.</li><li class=\"pl-4\"><b>text</b> – текст вопроса. В вопросе с пропусками пропуски записываются в фигурных скобках, как в форме создания вопроса.</li><li class=\"pl-4\"><b>options</b> – варианты ответа, каждый с новой строки в одной ячейке. Для порядка – в правильном порядке, для пар – \"левая часть -> правая часть\", для числа – число или диапазон \"1..10\", для областей на картинке – \"подпись -> x1 y1,x2 y2,...\" с координатами от 0 до 1.</li><li class=\"pl-4\"><b>correct</b> – номера правильных вариантов через запятую, начиная с 1. Нужна только для вопросов с выбором ответа.</li><li class=\"pl-4\"><b>image</b> – идентификатор уже загруженного изображения, как в выгрузке.</li><li class=\"pl-4\"><b>points</b> и <b>time_limit</b> – баллы за вопрос и время на ответ в секундах.</li><li class=\"pl-4\"><b>tag</b> – тег вопроса.</li><li class=\"pl-4\"><b>text_match</b> – проверка ввода слова: exact, case_insensitive, regex или fuzzy. Для fuzzy можно указать число опечаток \"fuzzy:2\" или долю совпадения \"fuzzy:80%\".</li></ul><p class=\"mt-3\">Таблица проверяется целиком: если хотя бы в одной строке есть ошибка, не добавляется ни один вопрос, а под формой выводятся ошибки всех строк. Переходы между вопросами в таблицу не выгружаются.</p><p class=\"mt-3\">Игру целиком можно написать в Markdown и вставить кнопкой \"Вставить Markdown\" на странице игры. Заголовок \"# \" задает название игры, каждый заголовок \"## \" начинает новый вопрос. Строки под заголовком продолжают текст вопроса, строка вида \"![](/files/images/имя)\" добавляет к вопросу уже загруженное в quizzly изображение. Варианты ответа записываются списком с флажками: \"- [x]\" – правильный, \"- [ ]\" – неправильный. Вопрос с одним правильным вариантом становится вопросом с одним ответом, с несколькими – вопросом с несколькими ответами. Другой тип задается строкой \"&lt;!-- type: poll --&gt;\" под заголовком вопроса: так записываются опросы (poll, multiple_poll) и вопросы, где засчитывается любой из правильных вариантов (one_of_choice). Кнопка \"Скачать Markdown\" выгружает игру в том же формате, если в ней только вопросы с выбором ответа и опросы: вопросы других типов в Markdown не выразить.</p></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -42,12 +55,12 @@ func HowToCreateGame() templ.Component {
 			defer templ.ReleaseBuffer(templ_7745c5c3_Buffer)
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var2 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var2 == nil {
-			templ_7745c5c3_Var2 = templ.NopComponent
+		templ_7745c5c3_Var3 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var3 == nil {
+			templ_7745c5c3_Var3 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\"><p class=\"mb-3\">Чтобы создать игру, у вас должен быть список заранее подготовленных вопросов. Если у вас еще нет списка вопросов, ознакомьтесь с руководством <a href=\"#how-to-create-question\" class=\"link link-primary no-underline\">\"Как создать вопрос?\"</a> для их создания.</p><p class=\"mb-3\">Если у вас уже есть готовые вопросы, в боковом меню нажмите <a href=\"/admin/game/new\" target=\"_blank\" class=\"btn btn-sm text-amber-500 bg-transparent hover:text-white hover:bg-amber-500 border-0 align-middle shadow-none\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"size-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"M12 9v6m3-3H9m12 0a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z\"></path></svg> <span>Новая игра</span></a> или зайдите в раздел <a href=\"/admin/game/list\" target=\"_blank\" class=\"link link-primary no-underline\"><b>\"Список игр\"</b></a> и нажмите кнопку <a href=\"/admin/game/new\" target=\"_blank\" class=\"btn btn-sm bg-success hover:bg-green-600 border-0 text-white align-middle\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"size-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"M12 9v6m3-3H9m12 0a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z\"></path></svg> <span>Создать новую игру</span></a></p><div>Вы попадете на форму создания новой игры. Здесь вам нужно выбрать вопросы, которые войдут в вашу игру. Придумайте название игры и настройте параметры, если это необходимо. Вот какие настройки сейчас доступны:<ul class=\"list-inside list-decimal\"><li class=\"pl-4\"><b>Перемешать вопросы</b> – если эта опция включена, каждый игрок будет видеть вопросы в случайном порядке. Если игрок не ответил на вопрос, он увидит его снова при следующем входе в игру.</li><li class=\"pl-4\"><b>Перемешать ответы</b> – при включении этой функции ответы на каждый вопрос будут перемешиваться для каждого игрока. Это помогает избежать запоминания игроками правильного порядка ответов.</li><li class=\"pl-4\"><b>Показывать правильный ответ в случае неудачи</b> – когда этот параметр включен, при неправильном ответе игрока на экран результатов будет выводиться правильный ответ. Обратите внимание, что кнопка \"играть снова\" всегда активна, так что игрок может запомнить правильные ответы и пройти викторину без ошибок во второй раз.</li></ul></div></div>")
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\"><p class=\"mb-3\">Чтобы создать игру, у вас должен быть список заранее подготовленных вопросов. Если у вас еще нет списка вопросов, ознакомьтесь с руководством <a href=\"#how-to-create-question\" class=\"link link-primary no-underline\">\"Как создать вопрос?\"</a> для их создания.</p><p class=\"mb-3\">Если у вас уже есть готовые вопросы, в боковом меню нажмите <a href=\"/admin/game/new\" target=\"_blank\" class=\"btn btn-sm text-amber-500 bg-transparent hover:text-white hover:bg-amber-500 border-0 align-middle shadow-none\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"size-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"M12 9v6m3-3H9m12 0a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z\"></path></svg> <span>Новая игра</span></a> или зайдите в раздел <a href=\"/admin/game/list\" target=\"_blank\" class=\"link link-primary no-underline\"><b>\"Список игр\"</b></a> и нажмите кнопку <a href=\"/admin/game/new\" target=\"_blank\" class=\"btn btn-sm bg-success hover:bg-green-600 border-0 text-white align-middle\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"size-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"M12 9v6m3-3H9m12 0a9 9 0 1 1-18 0 9 9 0 0 1 18 0Z\"></path></svg> <span>Создать новую игру</span></a></p><div>Вы попадете на форму создания новой игры. Здесь вам нужно выбрать вопросы, которые войдут в вашу игру. Придумайте название игры и настройте параметры, если это необходимо. Вот какие настройки сейчас доступны:<ul class=\"list-inside list-decimal\"><li class=\"pl-4\"><b>Перемешать вопросы</b> – если эта опция включена, каждый игрок будет видеть вопросы в случайном порядке. Если игрок не ответил на вопрос, он увидит его снова при следующем входе в игру.</li><li class=\"pl-4\"><b>Перемешать ответы</b> – при включении этой функции ответы на каждый вопрос будут перемешиваться для каждого игрока. Это помогает избежать запоминания игроками правильного порядка ответов.</li><li class=\"pl-4\"><b>Показывать правильный ответ в случае неудачи</b> – когда этот параметр включен, при неправильном ответе игрока на экран результатов будет выводиться правильный ответ. Обратите внимание, что кнопка \"играть снова\" всегда активна, так что игрок может запомнить правильные ответы и пройти викторину без ошибок во второй раз.</li><li class=\"pl-4\"><b>Время на ответ</b> – время в секундах, за которое игрок должен ответить на вопрос. Время можно указать и для отдельного вопроса при его создании, тогда оно заменит настройку игры. Во время игры игрок видит обратный отсчет, а ответ, отправленный после окончания времени, засчитывается как неправильный.</li><li class=\"pl-4\"><b>Бонус за скорость</b> – за каждый вопрос начисляются баллы, которые указываются при создании вопроса (по умолчанию 1). Если опция включена, за быстрый правильный ответ баллы увеличиваются вплоть до двойных.</li><li class=\"pl-4\"><b>Штраф за неправильный ответ</b> – за неправильный ответ баллы вопроса вычитаются из результата игрока.</li><li class=\"pl-4\"><b>Частичные баллы</b> – в вопросах с несколькими правильными ответами игрок получает часть баллов за каждый выбранный правильный вариант.</li><li class=\"pl-4\"><b>Скрыть таблицу лидеров</b> – по умолчанию на странице результатов есть ссылка на таблицу лидеров, где игроки упорядочены по баллам или количеству правильных ответов, а при равенстве – по времени прохождения. Если опция включена, таблица доступна только вам во вкладке \"Рейтинг\" на странице игры.</li><li class=\"pl-4\"><b>Вопросов для игрока</b> – сколько случайных вопросов выпадет каждому игроку, например 10 из 40. Набор выбирается один раз при первом входе в игру и не меняется при обновлении страницы и повторной игре. Результат игрока считается только по выпавшим вопросам.</li><li class=\"pl-4\"><b>Выбирать вопросы равномерно по тегам</b> – при создании вопроса можно указать тег, например тему или сложность. Если опция включена, из каждого тега выбирается доля вопросов, пропорциональная их количеству в игре.</li><li class=\"pl-4\"><b>Число попыток</b> – сколько раз игрок может пройти игру с помощью кнопки \"Сыграть еще раз\". Если поле пустое, число попыток не ограничено. Каждая попытка сохраняется, во вкладке \"Участники\" можно включить показ всех попыток.</li><li class=\"pl-4\"><b>Учитывать попытку</b> – какая из завершенных попыток попадает в результат игрока и таблицу лидеров: последняя, лучшая по баллам или первая.</li><li class=\"pl-4\"><b>Начало и завершение игры</b> – время по Москве, когда игра запустится и завершится сама, без нажатия кнопок. Игроки, открывшие ссылку до начала, увидят, через сколько начнется игра. Время завершения можно изменить и после запуска.</li><li class=\"pl-4\"><b>Прохождение брошено через, минут</b> – если игрок ушел посреди игры и не отвечает дольше указанного времени, его прохождение получает статус <span class=\"badge badge-error text-white align-middle\">\"Брошено\"</span> и не считается завершенным. Вернувшись в игру, игрок продолжит с того же места.</li></ul></div><p class=\"mt-3\">Игру можно целиком перенести или сохранить копию: кнопка \"Скачать игру\" на странице игры скачивает zip-архив с настройками, вопросами, переходами между вопросами и изображениями. Чтобы восстановить игру, в разделе <a href=\"/admin/game/list\" target=\"_blank\" class=\"link link-primary no-underline\"><b>\"Список игр\"</b></a> нажмите \"Загрузить игру\" и выберите архив – будет создана новая игра от вашего имени. Игроки и их ответы в архив не попадают.</p><p class=\"mt-3\">Чтобы перенести вопросы в LMS, используйте кнопки \"Moodle XML\" и \"QTI 2.1\" на странице игры. Файл Moodle XML загружается в банк вопросов Moodle, zip-пакет QTI 2.1 – в системы, поддерживающие стандарт IMS QTI. Изображения вопросов включаются в файл. Вопросы с развернутым ответом выгружаются как эссе, а вопросы с областями на изображении в Moodle – как перетаскивание маркеров. Переходы между вопросами и настройки игры в LMS не переносятся.</p></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -66,9 +79,9 @@ func HowToStartGame() templ.Component {
 			defer templ.ReleaseBuffer(templ_7745c5c3_Buffer)
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var3 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var3 == nil {
-			templ_7745c5c3_Var3 = templ.NopComponent
+		templ_7745c5c3_Var4 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var4 == nil {
+			templ_7745c5c3_Var4 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\"><p class=\"mb-3\">Если вы уже создали игру (инструкции по созданию игры смотрите в разделе <a href=\"#how-to-create-game\" class=\"link link-primary no-underline\">\"Как создать игру?\"</a>), вы можете начать её, нажав на кнопку <button class=\"btn btn-sm bg-success hover:bg-green-600 border-0 text-white align-middle\"><svg xmlns=\"http://www.w3.org/2000/svg\" fill=\"none\" viewBox=\"0 0 24 24\" stroke-width=\"1.5\" stroke=\"currentColor\" class=\"size-6\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" d=\"M5.25 5.653c0-.856.917-1.398 1.667-.986l11.54 6.347a1.125 1.125 0 0 1 0 1.972l-11.54 6.347a1.125 1.125 0 0 1-1.667-.986V5.653Z\"></path></svg> <span>Начать</span></button> на странице игры.</p><p>Когда игра начнётся, вы сможете скопировать ссылку на неё, нажав <button class=\"btn btn-sm bg-amber-500 hover:bg-amber-600 text-white border-0 align-middle\">скопировать</button> рядом с адресом ссылки. После этого вы сможете поделиться этой ссылкой с друзьями, чтобы они тоже могли присоединиться к игре.</p></div>")
@@ -90,9 +103,9 @@ func HowToShareGame() templ.Component {
 			defer templ.ReleaseBuffer(templ_7745c5c3_Buffer)
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var4 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var4 == nil {
-			templ_7745c5c3_Var4 = templ.NopComponent
+		templ_7745c5c3_Var5 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var5 == nil {
+			templ_7745c5c3_Var5 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\">Чтобы поделиться игрой с друзьями, скопируйте ссылку на страницу игры и отправьте её им :)</div>")
@@ -114,12 +127,12 @@ func HowToExploreStatistics() templ.Component {
 			defer templ.ReleaseBuffer(templ_7745c5c3_Buffer)
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var5 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var5 == nil {
-			templ_7745c5c3_Var5 = templ.NopComponent
+		templ_7745c5c3_Var6 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var6 == nil {
+			templ_7745c5c3_Var6 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\">Чтобы узнать, сколько людей уже сыграли в игру, перейдите на страницу игры и откройте вкладку <b>\"Участники\"</b>. Там вы найдёте следующую информацию о каждом игроке:<ul class=\"list-inside list-decimal\"><li class=\"pl-4\"><b>Имя игрока</b> – сейчас это случайно сгенерированное имя, но в будущем планируется возможность вводить своё имя.</li><li class=\"pl-4\"><b>Процент прохождения игры</b> – количество правильных ответов, выраженное в процентах.</li><li class=\"pl-4\"><b>Дата старта</b> – день, когда игроку был показан первый вопрос.</li><li class=\"pl-4\"><b>Дата последнего ответа</b> – день, когда игрок дал последний ответ. Эту дату можно считать окончанием игры, если статус прохождения – <span class=\"badge bg-orange-500 text-white align-middle\">\"Завершено\"</span>.</li><li class=\"pl-4\"><b>Статус прохождения</b> – возможные статусы: <span class=\"badge bg-success text-white align-middle\">\"В процессе\"</span> – игрок ещё проходит игру; <span class=\"badge bg-orange-500 text-white align-middle\">\"Завершено\"</span> – игра пройдена.</li></ul></div>")
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\">Чтобы узнать, сколько людей уже сыграли в игру, перейдите на страницу игры и откройте вкладку <b>\"Участники\"</b>. Там вы найдёте следующую информацию о каждом игроке:<ul class=\"list-inside list-decimal\"><li class=\"pl-4\"><b>Имя игрока</b> – сейчас это случайно сгенерированное имя, но в будущем планируется возможность вводить своё имя.</li><li class=\"pl-4\"><b>Попытка</b> – номер попытки игрока. По умолчанию показывается только последняя попытка каждого игрока, переключатель <b>\"Показать все попытки\"</b> выводит их все.</li><li class=\"pl-4\"><b>Процент прохождения игры</b> – количество правильных ответов, выраженное в процентах.</li><li class=\"pl-4\"><b>Дата старта</b> – день, когда игроку был показан первый вопрос.</li><li class=\"pl-4\"><b>Дата последнего ответа</b> – день, когда игрок дал последний ответ. Эту дату можно считать окончанием игры, если статус прохождения – <span class=\"badge bg-orange-500 text-white align-middle\">\"Завершено\"</span>.</li><li class=\"pl-4\"><b>Статус прохождения</b> – возможные статусы: <span class=\"badge bg-success text-white align-middle\">\"В процессе\"</span> – игрок ещё проходит игру; <span class=\"badge bg-orange-500 text-white align-middle\">\"Завершено\"</span> – игра пройдена; <span class=\"badge badge-error text-white align-middle\">\"Брошено\"</span> – игрок давно не отвечал.</li></ul></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -138,9 +151,9 @@ func WhatAboutEndGame() templ.Component {
 			defer templ.ReleaseBuffer(templ_7745c5c3_Buffer)
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var6 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var6 == nil {
-			templ_7745c5c3_Var6 = templ.NopComponent
+		templ_7745c5c3_Var7 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var7 == nil {
+			templ_7745c5c3_Var7 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString("<div class=\"pt-4 leading-relaxed\">Когда игра завершена, новые игроки не смогут присоединиться по ссылке и играть в неё. Однако, страницы с результатами участников останутся доступными и будут продолжать работать.</div>")
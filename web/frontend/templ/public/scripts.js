@@ -1,5 +1,6 @@
 let timeouts = [];
 let playPageQuestionFormPromise = null;
+let questionTimerInterval = null;
 
 function clearTimeouts() {
     timeouts.forEach(timeout => clearTimeout(timeout));
@@ -15,11 +16,13 @@ function initPlayPageQuestionForm() {
     if (form != null) {
         const submitBtn = document.getElementById('play-page-submit-button');
         const submit = document.getElementById('play-page-submit');
-        const textInput = form.querySelector('input[type="text"]');
+        // В вопросах с пропусками полей ввода несколько, достаточно заполнить хотя бы одно
+        const textInputs = Array.from(form.querySelectorAll('input[type="text"], textarea[name="answer"]'));
+        const hasText = () => textInputs.some(textInput => textInput.value.trim().length > 0);
 
-        if (textInput) {
+        textInputs.forEach(textInput => {
             textInput.addEventListener('input', function () {
-                if (textInput.value.trim().length > 0) {
+                if (hasText()) {
                     submitBtn.disabled = false;
                     submit.classList.remove("hidden", "opacity-0");
                     submit.classList.add("animate-fade-in-up");
@@ -29,13 +32,17 @@ function initPlayPageQuestionForm() {
                     submit.classList.remove("animate-fade-in-up");
                 }
             });
-        }
+        });
 
         form.addEventListener('change', function () {
             const hasChecked = form.querySelector('input[type="checkbox"]:checked, input[type="radio"]:checked');
-            const hasValidText = textInput ? textInput.value.trim().length > 0 : false;
+            const hasValidText = hasText();
+            const hasSortable = form.querySelector('[data-sortable-item]');
+            const selects = Array.from(form.querySelectorAll('select[name="answer"]'));
+            const hasAllSelected = selects.length > 0 && selects.every(select => select.value !== '');
+            const hasPoint = Array.from(form.querySelectorAll('input[data-hotspot-answer]')).some(input => input.value !== '');
 
-            if (hasChecked || hasValidText) {
+            if (hasChecked || hasValidText || hasSortable || hasAllSelected || hasPoint) {
                 submitBtn.disabled = false;
                 submit.classList.remove("hidden", "opacity-0");
                 submit.classList.add("animate-fade-in-up");
@@ -45,7 +52,72 @@ function initPlayPageQuestionForm() {
                 submit.classList.remove("animate-fade-in-up");
             }
         });
+
+        // В вопросах на упорядочивание любой порядок является ответом
+        if (form.querySelector('[data-sortable-item]')) {
+            submitBtn.disabled = false;
+            submit.classList.remove("hidden", "opacity-0");
+            submit.classList.add("animate-fade-in-up");
+        }
+    }
+}
+
+// selectHotspotPoint координаты точки передаются в долях от размеров изображения
+function selectHotspotPoint(event, image) {
+    const rect = image.getBoundingClientRect();
+    const x = Math.min(Math.max((event.clientX - rect.left) / rect.width, 0), 1);
+    const y = Math.min(Math.max((event.clientY - rect.top) / rect.height, 0), 1);
+
+    const container = image.parentElement;
+    const input = container.querySelector('input[data-hotspot-answer]');
+    input.value = x.toFixed(4) + ' ' + y.toFixed(4);
+
+    const marker = container.querySelector('[data-hotspot-marker]');
+    marker.style.left = (x * 100) + '%';
+    marker.style.top = (y * 100) + '%';
+    marker.classList.remove('hidden');
+
+    input.dispatchEvent(new Event('change', {bubbles: true}));
+}
+
+// initQuestionTimer после окончания времени форма блокируется и запрашивает следующий вопрос,
+// ответ, отправленный в последний момент, сервер примет в течение data-grace
+function initQuestionTimer() {
+    clearInterval(questionTimerInterval);
+
+    const timer = document.getElementById('play-page-timer');
+    if (timer == null) {
+        return;
     }
+
+    const form = timer.closest('form');
+    const value = timer.querySelector('[data-timer-value]');
+    const progress = timer.querySelector('progress');
+    const deadline = Date.now() + parseInt(timer.dataset.remaining);
+    const grace = parseInt(timer.dataset.grace);
+
+    const tick = () => {
+        const remaining = Math.max(deadline - Date.now(), 0);
+        value.textContent = Math.ceil(remaining / 1000) + ' с';
+        progress.value = remaining;
+        if (remaining > 0) {
+            return;
+        }
+
+        clearInterval(questionTimerInterval);
+        value.textContent = 'Время вышло';
+        form.inert = true;
+        document.getElementById('play-page-submit-button').disabled = true;
+
+        setTimeout(() => {
+            if (document.body.contains(timer)) {
+                htmx.ajax('GET', timer.dataset.url, {target: form, select: '#play-page-question-form', swap: 'outerHTML'});
+            }
+        }, grace);
+    };
+
+    tick();
+    questionTimerInterval = setInterval(tick, 250);
 }
 
 function beforeRequestPlayPageQuestionForm(event) {
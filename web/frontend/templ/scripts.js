@@ -65,4 +65,58 @@ function uuidv4() {
 
 htmx.on("htmx:responseError", function (evt) {
     addToast(evt.detail.xhr.responseText, 'error')
-})
\ No newline at end of file
+})
+
+function initSortable(id) {
+    const container = document.getElementById(id);
+    if (!container || container.dataset.sortableInitialized) {
+        return;
+    }
+    container.dataset.sortableInitialized = "true";
+
+    let dragged = null;
+    container.addEventListener("dragstart", function (event) {
+        dragged = event.target.closest("[data-sortable-item]");
+        if (dragged) {
+            dragged.classList.add("opacity-50");
+            event.dataTransfer.effectAllowed = "move";
+        }
+    });
+    container.addEventListener("dragover", function (event) {
+        if (!dragged) {
+            return;
+        }
+        event.preventDefault();
+
+        const target = event.target.closest("[data-sortable-item]");
+        if (!target || target === dragged || target.parentNode !== container) {
+            return;
+        }
+
+        const rect = target.getBoundingClientRect();
+        const after = event.clientY > rect.top + rect.height / 2;
+        container.insertBefore(dragged, after ? target.nextSibling : target);
+    });
+    container.addEventListener("dragend", function () {
+        if (dragged) {
+            dragged.classList.remove("opacity-50");
+        }
+        dragged = null;
+        container.dispatchEvent(new Event("change", {bubbles: true}));
+    });
+}
+
+function moveSortableItem(element, direction) {
+    const item = element.closest("[data-sortable-item]");
+    if (!item) {
+        return;
+    }
+
+    const sibling = direction < 0 ? item.previousElementSibling : item.nextElementSibling;
+    if (!sibling) {
+        return;
+    }
+
+    item.parentNode.insertBefore(item, direction < 0 ? sibling : sibling.nextSibling);
+    item.dispatchEvent(new Event("change", {bubbles: true}));
+}